@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+
+	"task-management/internal/config"
+	"task-management/internal/database"
+	"task-management/internal/repository"
+	"task-management/internal/services"
+	"task-management/internal/storage"
+)
+
+// app holds the services shared by the server and the administrative
+// commands, wired the same way for both.
+type app struct {
+	auth         services.AuthService
+	webhook      services.WebhookService
+	stream       services.StreamService
+	todo         services.TodoService
+	notification services.NotificationService
+	comment      services.CommentService
+	attachment   services.AttachmentService
+	calendar     services.CalendarService
+	stats        services.StatsService
+	timeEntry    services.TimeEntryService
+	workspace    services.WorkspaceService
+	assignee     services.AssigneeService
+	template     services.TemplateService
+}
+
+// connect opens the database and, depending on DB_AUTO_MIGRATE, applies the
+// pending migrations or makes sure there are none.
+func connect(cfg *config.Config) error {
+	if err := database.Connect(cfg); err != nil {
+		return err
+	}
+	if cfg.Database.AutoMigrate {
+		return database.Migrate()
+	}
+	if err := database.CheckMigrations(); err != nil {
+		return fmt.Errorf("database is not up to date: %w", err)
+	}
+	return nil
+}
+
+// newApp builds the repositories and services on the connected database.
+func newApp(cfg *config.Config) (*app, error) {
+	db := database.GetDB()
+
+	// Initialize repositories
+	userRepo := repository.NewUserRepository(db)
+	tokenRepo := repository.NewTokenRepository(db)
+	todoRepo := repository.NewTodoRepository(db)
+	webhookRepo := repository.NewWebhookRepository(db)
+	eventRepo := repository.NewEventRepository(db)
+	commentRepo := repository.NewCommentRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	attachmentRepo := repository.NewAttachmentRepository(db)
+	calendarFeedRepo := repository.NewCalendarFeedRepository(db)
+	statsRepo := repository.NewStatsRepository(db)
+	timeEntryRepo := repository.NewTimeEntryRepository(db)
+	workspaceRepo := repository.NewWorkspaceRepository(db)
+	templateRepo := repository.NewTemplateRepository(db)
+
+	// Initialize attachment storage
+	blobStore, err := storage.New(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize attachment storage: %w", err)
+	}
+
+	// Initialize services
+	a := &app{}
+	a.auth = services.NewAuthService(userRepo, tokenRepo, cfg)
+	a.webhook = services.NewWebhookService(webhookRepo, cfg)
+	a.stream = services.NewStreamService(eventRepo, workspaceRepo, cfg)
+	a.todo = services.NewTodoService(todoRepo, workspaceRepo, services.MultiPublisher(a.webhook, a.stream), cfg)
+	a.notification = services.NewNotificationService(notificationRepo)
+	a.comment = services.NewCommentService(commentRepo, userRepo, workspaceRepo, a.todo, a.notification)
+	a.attachment = services.NewAttachmentService(attachmentRepo, a.todo, blobStore, cfg)
+	a.calendar = services.NewCalendarService(calendarFeedRepo, todoRepo)
+	a.stats = services.NewStatsService(statsRepo)
+	a.timeEntry = services.NewTimeEntryService(timeEntryRepo, a.todo)
+	a.workspace = services.NewWorkspaceService(workspaceRepo, userRepo, a.notification)
+	a.assignee = services.NewAssigneeService(todoRepo, workspaceRepo, a.todo, a.notification)
+	a.template = services.NewTemplateService(templateRepo, workspaceRepo, a.todo)
+	return a, nil
+}
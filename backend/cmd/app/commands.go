@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"task-management/internal/config"
+)
+
+const usage = `usage: app [command]
+
+commands:
+  serve                        run the API server (default)
+  migrate <command>            manage database migrations, see "app migrate"
+  user create                  create a user
+  user reset-password <name>   set a new password and revoke the user's tokens
+  user deactivate <name>       block a user from logging in
+  token revoke <token>         revoke a single access token
+  token revoke -user <name>    revoke every token of a user
+  seed                         load demo users, workspaces and todos
+  config check                 validate the configuration and dependencies`
+
+// run dispatches the command line to a subcommand.
+func run(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return serve(cfg)
+	}
+
+	switch args[0] {
+	case "serve":
+		return serve(cfg)
+	case "migrate":
+		return runMigrate(cfg, args[1:])
+	case "user":
+		return runUser(cfg, args[1:])
+	case "token":
+		return runToken(cfg, args[1:])
+	case "seed":
+		return runSeed(cfg, args[1:])
+	case "config":
+		return runConfig(cfg, args[1:])
+	case "help", "-h", "--help":
+		fmt.Println(usage)
+		return nil
+	}
+	return fmt.Errorf("unknown command %q\n%s", args[0], usage)
+}
+
+// commandApp connects to the database and builds the services for a
+// one-off command.
+func commandApp(cfg *config.Config) (*app, error) {
+	if err := connect(cfg); err != nil {
+		return nil, err
+	}
+	return newApp(cfg)
+}
+
+func runUser(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+
+	switch args[0] {
+	case "create":
+		flags := flag.NewFlagSet("user create", flag.ContinueOnError)
+		username := flags.String("username", "", "username (required)")
+		email := flags.String("email", "", "email address (required)")
+		fullName := flags.String("full-name", "", "full name")
+		password := flags.String("password", "", "password, read from stdin when omitted")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *username == "" || *email == "" {
+			return errors.New("usage: app user create -username name -email address [-full-name name] [-password password]")
+		}
+		pw, err := readPassword(*password)
+		if err != nil {
+			return err
+		}
+
+		a, err := commandApp(cfg)
+		if err != nil {
+			return err
+		}
+		user, err := a.auth.Register(*username, *email, pw, *fullName)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Created user %s (id %d)\n", user.Username, user.ID)
+		return nil
+
+	case "reset-password":
+		flags := flag.NewFlagSet("user reset-password", flag.ContinueOnError)
+		password := flags.String("password", "", "new password, read from stdin when omitted")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+		if flags.NArg() != 1 {
+			return errors.New("usage: app user reset-password [-password password] <username>")
+		}
+		pw, err := readPassword(*password)
+		if err != nil {
+			return err
+		}
+
+		a, err := commandApp(cfg)
+		if err != nil {
+			return err
+		}
+		user, err := a.auth.ResetPassword(flags.Arg(0), pw)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Password of %s reset, existing tokens revoked\n", user.Username)
+		return nil
+
+	case "deactivate":
+		if len(args) != 2 {
+			return errors.New("usage: app user deactivate <username>")
+		}
+		a, err := commandApp(cfg)
+		if err != nil {
+			return err
+		}
+		user, err := a.auth.Deactivate(args[1])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Deactivated %s, existing tokens revoked\n", user.Username)
+		return nil
+	}
+
+	return fmt.Errorf("unknown user command %q\n%s", args[0], usage)
+}
+
+func runToken(cfg *config.Config, args []string) error {
+	if len(args) == 0 || args[0] != "revoke" {
+		return errors.New(usage)
+	}
+
+	flags := flag.NewFlagSet("token revoke", flag.ContinueOnError)
+	username := flags.String("user", "", "revoke every token of this user")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	if (*username == "") == (flags.NArg() == 0) || flags.NArg() > 1 {
+		return errors.New("usage: app token revoke <token> | app token revoke -user <username>")
+	}
+
+	a, err := commandApp(cfg)
+	if err != nil {
+		return err
+	}
+
+	if *username != "" {
+		user, err := a.auth.RevokeUserTokens(*username)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Revoked every token of %s\n", user.Username)
+		return nil
+	}
+
+	revoked, err := a.auth.RevokeToken(strings.TrimPrefix(flags.Arg(0), "Bearer "))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Revoked token %s of user %d until %s\n", revoked.TokenID, revoked.UserID, revoked.ExpiresAt.Format("2006-01-02 15:04:05 MST"))
+	return nil
+}
+
+// readPassword returns the flag value or, when it is empty, the first line
+// of stdin, so passwords need not appear in the process list.
+func readPassword(value string) (string, error) {
+	if value != "" {
+		return value, nil
+	}
+
+	fmt.Fprint(os.Stderr, "Password: ")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", errors.New("no password given")
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"task-management/internal/config"
+	"task-management/internal/database"
+	"task-management/internal/models"
+	"task-management/internal/storage"
+)
+
+const defaultJWTSecret = "your-secret-key"
+
+type checkLevel string
+
+const (
+	checkOK   checkLevel = "ok"
+	checkWarn checkLevel = "warn"
+	checkFail checkLevel = "FAIL"
+)
+
+type configCheck struct {
+	level  checkLevel
+	name   string
+	detail string
+}
+
+func runConfig(cfg *config.Config, args []string) error {
+	if len(args) == 0 || args[0] != "check" {
+		return fmt.Errorf("usage: app config check [-offline]")
+	}
+
+	flags := flag.NewFlagSet("config check", flag.ContinueOnError)
+	offline := flags.Bool("offline", false, "skip the database and storage checks")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	checks := checkConfig(cfg)
+	if !*offline {
+		checks = append(checks, checkDependencies(cfg)...)
+	}
+
+	failed := 0
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	for _, check := range checks {
+		if check.level == checkFail {
+			failed++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", check.level, check.name, check.detail)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d check(s) failed", failed)
+	}
+	return nil
+}
+
+// checkConfig validates the settings on their own. Unparsable numbers are
+// loaded as zero, so they show up as out of range.
+func checkConfig(cfg *config.Config) []configCheck {
+	var checks []configCheck
+	check := func(ok bool, level checkLevel, name, problem, detail string) {
+		if ok {
+			checks = append(checks, configCheck{checkOK, name, detail})
+		} else {
+			checks = append(checks, configCheck{level, name, problem})
+		}
+	}
+
+	secret := cfg.JWT.Secret
+	switch {
+	case secret == "" || secret == defaultJWTSecret:
+		checks = append(checks, configCheck{checkFail, "JWT_SECRET", "is unset or the default value"})
+	case len(secret) < 32:
+		checks = append(checks, configCheck{checkWarn, "JWT_SECRET", "is shorter than 32 characters"})
+	default:
+		checks = append(checks, configCheck{checkOK, "JWT_SECRET", "set"})
+	}
+	check(cfg.JWT.Expiration > 0, checkFail, "JWT_EXPIRATION_HOURS", "must be a positive number", cfg.JWT.Expiration.String())
+
+	port, err := strconv.Atoi(cfg.Server.Port)
+	check(err == nil && port > 0 && port < 65536, checkFail, "SERVER_PORT", "must be a port number", cfg.Server.Port)
+
+	_, err = models.ParseStatusWorkflow(cfg.Todo.StatusTransitions)
+	problem := ""
+	if err != nil {
+		problem = err.Error() + ", the default workflow would be used"
+	}
+	check(err == nil, checkFail, "TODO_STATUS_TRANSITIONS", problem, cfg.Todo.StatusTransitions)
+
+	check(cfg.Todo.BulkMaxItems > 0, checkFail, "TODO_BULK_MAX_ITEMS", "must be a positive number", strconv.Itoa(cfg.Todo.BulkMaxItems))
+	check(cfg.Todo.ImportMaxRows > 0, checkFail, "TODO_IMPORT_MAX_ROWS", "must be a positive number", strconv.Itoa(cfg.Todo.ImportMaxRows))
+	check(cfg.Todo.TrashRetentionDays > 0, checkWarn, "TODO_TRASH_RETENTION_DAYS", "is not positive, trashed todos are never purged", strconv.Itoa(cfg.Todo.TrashRetentionDays))
+	check(cfg.Webhook.MaxAttempts > 0, checkFail, "WEBHOOK_MAX_ATTEMPTS", "must be a positive number", strconv.Itoa(cfg.Webhook.MaxAttempts))
+	check(cfg.Webhook.Timeout > 0, checkFail, "WEBHOOK_TIMEOUT_SECONDS", "must be a positive number", cfg.Webhook.Timeout.String())
+	check(cfg.Stream.Heartbeat > 0, checkFail, "STREAM_HEARTBEAT_SECONDS", "must be a positive number", cfg.Stream.Heartbeat.String())
+
+	switch cfg.Database.Driver {
+	case database.DriverPostgres:
+		checks = append(checks, configCheck{checkOK, "DB_DRIVER", cfg.Database.Driver})
+	case database.DriverSQLite:
+		checks = append(checks, configCheck{checkWarn, "DB_DRIVER", "sqlite is meant for development and tests"})
+	default:
+		checks = append(checks, configCheck{checkFail, "DB_DRIVER", "must be postgres or sqlite"})
+	}
+
+	driver := cfg.Storage.Driver
+	check(driver == "local" || driver == "s3", checkFail, "STORAGE_DRIVER", "must be local or s3", driver)
+	check(cfg.Storage.MaxFileSize > 0, checkFail, "ATTACHMENT_MAX_FILE_MB", "must be a positive number", fmt.Sprintf("%d MB", cfg.Storage.MaxFileSize>>20))
+	check(cfg.Storage.UserQuota >= cfg.Storage.MaxFileSize, checkWarn, "ATTACHMENT_USER_QUOTA_MB", "is smaller than the maximum file size", fmt.Sprintf("%d MB", cfg.Storage.UserQuota>>20))
+
+	return checks
+}
+
+// checkDependencies connects to the database and the attachment storage.
+func checkDependencies(cfg *config.Config) []configCheck {
+	var checks []configCheck
+
+	if err := database.Connect(cfg); err != nil {
+		checks = append(checks, configCheck{checkFail, "database", err.Error()})
+	} else {
+		detail := fmt.Sprintf("connected to %s on %s:%s", cfg.Database.Name, cfg.Database.Host, cfg.Database.Port)
+		if cfg.Database.Driver == database.DriverSQLite {
+			detail = "opened " + cfg.Database.SQLitePath
+		}
+		checks = append(checks, configCheck{checkOK, "database", detail})
+		checks = append(checks, checkMigrations(cfg))
+	}
+
+	if _, err := storage.New(cfg); err != nil {
+		checks = append(checks, configCheck{checkFail, "storage", err.Error()})
+	} else {
+		checks = append(checks, configCheck{checkOK, "storage", cfg.Storage.Driver + " store is reachable"})
+	}
+
+	return checks
+}
+
+func checkMigrations(cfg *config.Config) configCheck {
+	migrator, err := database.NewMigrator(database.GetDB())
+	if err != nil {
+		return configCheck{checkFail, "migrations", err.Error()}
+	}
+	pending, err := migrator.Pending(context.Background())
+	if err != nil {
+		return configCheck{checkFail, "migrations", err.Error()}
+	}
+	switch {
+	case pending == 0:
+		return configCheck{checkOK, "migrations", "up to date"}
+	case cfg.Database.AutoMigrate:
+		return configCheck{checkWarn, "migrations", fmt.Sprintf("%d pending, applied on the next start", pending)}
+	}
+	return configCheck{checkFail, "migrations", fmt.Sprintf("%d pending and DB_AUTO_MIGRATE is off", pending)}
+}
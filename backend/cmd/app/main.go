@@ -1,15 +1,17 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"net/http"
+	"os"
 	"time"
 
 	"task-management/internal/auth"
 	"task-management/internal/config"
 	"task-management/internal/database"
 	"task-management/internal/handlers"
-	"task-management/internal/repository"
 	"task-management/internal/services"
 
 	_ "task-management/docs"
@@ -44,49 +46,87 @@ func main() {
 	// Load configuration
 	cfg := config.Load()
 
-	// Connect to database
-	if err := database.Connect(cfg); err != nil {
-		log.Fatal("Failed to connect to database:", err)
+	if err := run(cfg, os.Args[1:]); err != nil {
+		log.Fatal(err)
 	}
+}
 
-	// Run migrations
-	if err := database.Migrate(); err != nil {
-		log.Fatal("Failed to migrate database:", err)
+// serve runs the HTTP API together with the background jobs.
+func serve(cfg *config.Config) error {
+	// Connect to database and run migrations
+	if err := connect(cfg); err != nil {
+		return err
 	}
 
-	// Initialize repositories
-	userRepo := repository.NewUserRepository(database.GetDB())
-	todoRepo := repository.NewTodoRepository(database.GetDB())
+	a, err := newApp(cfg)
+	if err != nil {
+		return err
+	}
 
-	// Initialize services
-	authService := services.NewAuthService(userRepo, cfg)
-	todoService := services.NewTodoService(todoRepo)
+	// Fan out todo events from every instance and run the cleanup jobs
+	ctx := context.Background()
+	go database.Listen(ctx, cfg, services.StreamChannel, a.stream.HandleNotification)
+	go a.stream.Run(ctx)
+	go a.todo.RunTrashRetention(ctx)
+	go a.attachment.RunOrphanCleanup(ctx)
 
 	// Initialize handlers
-	authHandler := handlers.NewAuthHandler(authService)
-	todoHandler := handlers.NewTodoHandler(todoService)
+	h := routeHandlers{
+		auth:         handlers.NewAuthHandler(a.auth),
+		todo:         handlers.NewTodoHandler(a.todo, cfg.Todo.RequireIfMatch),
+		webhook:      handlers.NewWebhookHandler(a.webhook),
+		stream:       handlers.NewStreamHandler(a.stream, cfg.Stream.Heartbeat, allowedOrigins),
+		comment:      handlers.NewCommentHandler(a.comment),
+		notification: handlers.NewNotificationHandler(a.notification),
+		attachment:   handlers.NewAttachmentHandler(a.attachment, cfg.Storage.MaxFileSize),
+		calendar:     handlers.NewCalendarHandler(a.calendar),
+		stats:        handlers.NewStatsHandler(a.stats),
+		timeEntry:    handlers.NewTimeEntryHandler(a.timeEntry),
+		workspace:    handlers.NewWorkspaceHandler(a.workspace),
+		assignee:     handlers.NewAssigneeHandler(a.assignee),
+		template:     handlers.NewTemplateHandler(a.template),
+	}
 
 	// Setup routes
-	router := setupRoutes(authHandler, todoHandler, cfg)
+	router := setupRoutes(h, cfg, a.auth)
 
 	// Start server
 	serverAddr := cfg.Server.Host + ":" + cfg.Server.Port
 	log.Printf("Server starting on %s", serverAddr)
 	log.Printf("Swagger documentation available at http://%s/swagger/index.html", serverAddr)
 	if err := router.Run(serverAddr); err != nil {
-		log.Fatal("Failed to start server:", err)
+		return fmt.Errorf("failed to start server: %w", err)
 	}
+	return nil
 }
 
-func setupRoutes(authHandler *handlers.AuthHandler, todoHandler *handlers.TodoHandler, cfg *config.Config) *gin.Engine {
+var allowedOrigins = []string{"https://app.fauzanghaza.com", "http://localhost:8080"}
+
+type routeHandlers struct {
+	auth         *handlers.AuthHandler
+	todo         *handlers.TodoHandler
+	webhook      *handlers.WebhookHandler
+	stream       *handlers.StreamHandler
+	comment      *handlers.CommentHandler
+	notification *handlers.NotificationHandler
+	attachment   *handlers.AttachmentHandler
+	calendar     *handlers.CalendarHandler
+	stats        *handlers.StatsHandler
+	timeEntry    *handlers.TimeEntryHandler
+	workspace    *handlers.WorkspaceHandler
+	assignee     *handlers.AssigneeHandler
+	template     *handlers.TemplateHandler
+}
+
+func setupRoutes(h routeHandlers, cfg *config.Config, revocations auth.RevocationChecker) *gin.Engine {
 	router := gin.Default()
 
 	// ✅ CORS middleware (gunakan library resmi gin-contrib/cors)
 	router.Use(cors.New(cors.Config{
-		AllowOrigins:     []string{"https://app.fauzanghaza.com", "http://localhost:8080"},
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowOrigins:     allowedOrigins,
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "Last-Event-ID", "If-Match", "If-None-Match", handlers.WorkspaceHeader},
+		ExposeHeaders:    []string{"Content-Length", "ETag", handlers.WorkspaceHeader},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
@@ -107,20 +147,137 @@ func setupRoutes(authHandler *handlers.AuthHandler, todoHandler *handlers.TodoHa
 
 	authRoutes := api.Group("/auth")
 	{
-		authRoutes.POST("/register", authHandler.Register)
-		authRoutes.POST("/login-vulnerable", authHandler.LoginVulnerable)
+		authRoutes.POST("/register", h.auth.Register)
+		authRoutes.POST("/login-vulnerable", h.auth.LoginVulnerable)
+	}
+
+	stream := api.Group("/stream")
+	stream.Use(auth.StreamAuthMiddleware(cfg, revocations))
+	{
+		stream.GET("", h.stream.Stream)
+		stream.GET("/ws", h.stream.WebSocket)
 	}
 
+	// Calendar apps cannot send headers; the secret token authenticates the feed
+	api.GET("/calendar/:token/todos.ics", h.calendar.CalendarFeed)
+
 	protected := api.Group("/")
-	protected.Use(auth.AuthMiddleware(cfg))
+	protected.Use(auth.AuthMiddleware(cfg, revocations))
 	{
-		todos := protected.Group("/todos")
+		// Todo routes act on the workspace picked by the X-Workspace-ID header
+		todos := protected.Group("/todos", h.workspace.ResolveWorkspace)
+		{
+			todos.POST("/", h.todo.CreateTodo)
+			todos.GET("/", h.todo.GetTodos)
+			todos.POST("/quick", h.todo.QuickAddTodo)
+			todos.GET("/export", h.todo.ExportTodos)
+			todos.POST("/import", h.todo.ImportTodos)
+			todos.POST("/bulk", h.todo.BulkTodos)
+			todos.GET("/trash", h.todo.GetTrash)
+			todos.GET("/subtask-rules", h.todo.GetSubtaskRules)
+			todos.PUT("/subtask-rules", h.todo.UpdateSubtaskRules)
+			todos.GET("public/:id", h.todo.GetByPublicID)
+			todos.GET("/:id", h.todo.GetTodo)
+			todos.PUT("/:id", h.todo.UpdateTodo)
+			todos.PATCH("/:id", h.todo.PatchTodo)
+			todos.DELETE("/:id", h.todo.DeleteTodo)
+			todos.POST("/:id/restore", h.todo.RestoreTodo)
+			todos.POST("/:id/move", h.todo.MoveTodo)
+			todos.DELETE("/:id/purge", h.todo.PurgeTodo)
+			todos.GET("/:id/history", h.todo.GetTodoHistory)
+			todos.POST("/:id/revert/:revision", h.todo.RevertTodo)
+			todos.GET("/:id/comments", h.comment.GetComments)
+			todos.POST("/:id/comments", h.comment.CreateComment)
+			todos.PUT("/:id/comments/:commentId", h.comment.UpdateComment)
+			todos.DELETE("/:id/comments/:commentId", h.comment.DeleteComment)
+			todos.GET("/:id/comments/:commentId/history", h.comment.GetCommentHistory)
+			todos.GET("/:id/attachments", h.attachment.GetAttachments)
+			todos.POST("/:id/attachments", h.attachment.UploadAttachment)
+			todos.GET("/:id/attachments/:attachmentId", h.attachment.DownloadAttachment)
+			todos.DELETE("/:id/attachments/:attachmentId", h.attachment.DeleteAttachment)
+			todos.PUT("/:id/subtasks/:subtaskId", h.todo.UpdateSubtask)
+			todos.GET("/:id/blockers", h.todo.GetBlockers)
+			todos.POST("/:id/blockers", h.todo.AddBlocker)
+			todos.DELETE("/:id/blockers/:blockerId", h.todo.RemoveBlocker)
+			todos.GET("/:id/assignees", h.assignee.GetAssignees)
+			todos.POST("/:id/assignees", h.assignee.AssignTodo)
+			todos.DELETE("/:id/assignees/:userId", h.assignee.UnassignTodo)
+			todos.POST("/:id/timer/start", h.timeEntry.StartTimer)
+			todos.GET("/:id/time", h.timeEntry.GetTodoTime)
+			todos.GET("/:id/time-entries", h.timeEntry.GetTimeEntries)
+			todos.POST("/:id/time-entries", h.timeEntry.CreateTimeEntry)
+			todos.PUT("/:id/time-entries/:entryId", h.timeEntry.UpdateTimeEntry)
+			todos.DELETE("/:id/time-entries/:entryId", h.timeEntry.DeleteTimeEntry)
+		}
+
+		templates := protected.Group("/templates", h.workspace.ResolveWorkspace)
+		{
+			templates.GET("/", h.template.GetTemplates)
+			templates.POST("/", h.template.CreateTemplate)
+			templates.GET("/:id", h.template.GetTemplate)
+			templates.PUT("/:id", h.template.UpdateTemplate)
+			templates.DELETE("/:id", h.template.DeleteTemplate)
+			templates.POST("/:id/instantiate", h.template.InstantiateTemplate)
+		}
+
+		protected.GET("/board", h.workspace.ResolveWorkspace, h.todo.GetBoard)
+		protected.GET("/stats", h.workspace.ResolveWorkspace, h.stats.GetStats)
+
+		protected.GET("/timer", h.timeEntry.GetRunningTimer)
+		protected.POST("/timer/stop", h.timeEntry.StopTimer)
+
+		timeRoutes := protected.Group("/time")
+		{
+			timeRoutes.GET("/categories", h.timeEntry.GetCategoryTime)
+			timeRoutes.GET("/report", h.timeEntry.GetTimeReport)
+		}
+
+		workspaces := protected.Group("/workspaces")
+		{
+			workspaces.GET("/", h.workspace.GetWorkspaces)
+			workspaces.POST("/", h.workspace.CreateWorkspace)
+			workspaces.GET("/:id", h.workspace.GetWorkspace)
+			workspaces.PUT("/:id", h.workspace.UpdateWorkspace)
+			workspaces.DELETE("/:id", h.workspace.DeleteWorkspace)
+			workspaces.POST("/:id/switch", h.workspace.SwitchWorkspace)
+			workspaces.GET("/:id/members", h.workspace.GetMembers)
+			workspaces.PUT("/:id/members/:userId", h.workspace.UpdateMember)
+			workspaces.DELETE("/:id/members/:userId", h.workspace.RemoveMember)
+			workspaces.GET("/:id/invitations", h.workspace.GetInvitations)
+			workspaces.POST("/:id/invitations", h.workspace.InviteMember)
+			workspaces.DELETE("/:id/invitations/:invitationId", h.workspace.RevokeInvitation)
+		}
+
+		invitations := protected.Group("/invitations")
+		{
+			invitations.GET("/", h.workspace.GetMyInvitations)
+			invitations.POST("/:id/accept", h.workspace.AcceptInvitation)
+			invitations.POST("/:id/decline", h.workspace.DeclineInvitation)
+		}
+
+		calendar := protected.Group("/calendar")
+		{
+			calendar.GET("/feed", h.calendar.GetCalendarFeed)
+			calendar.POST("/feed", h.calendar.RegenerateCalendarToken)
+			calendar.DELETE("/feed", h.calendar.RevokeCalendarFeed)
+		}
+
+		notifications := protected.Group("/notifications")
+		{
+			notifications.GET("/", h.notification.GetNotifications)
+			notifications.POST("/read-all", h.notification.MarkAllNotificationsRead)
+			notifications.POST("/:id/read", h.notification.MarkNotificationRead)
+		}
+
+		webhooks := protected.Group("/webhooks")
 		{
-			todos.POST("/", todoHandler.CreateTodo)
-			todos.GET("/", todoHandler.GetTodos)
-			todos.GET("public/:id", todoHandler.GetByPublicID)
-			todos.PUT("/:id", todoHandler.UpdateTodo)
-			todos.DELETE("/:id", todoHandler.DeleteTodo)
+			webhooks.POST("/", h.webhook.CreateWebhook)
+			webhooks.GET("/", h.webhook.GetWebhooks)
+			webhooks.GET("/:id", h.webhook.GetWebhook)
+			webhooks.PUT("/:id", h.webhook.UpdateWebhook)
+			webhooks.DELETE("/:id", h.webhook.DeleteWebhook)
+			webhooks.GET("/:id/deliveries", h.webhook.GetDeliveries)
+			webhooks.POST("/:id/deliveries/:deliveryId/redeliver", h.webhook.Redeliver)
 		}
 
 		protected.GET("/profile", func(c *gin.Context) {
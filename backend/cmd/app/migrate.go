@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"task-management/internal/config"
+	"task-management/internal/database"
+)
+
+const migrateUsage = `usage: app migrate <command>
+
+commands:
+  up              apply all pending migrations
+  down [n]        roll back the last n migrations (default 1)
+  status          list migrations and whether they are applied
+  create <name>   add an empty migration pair for every database driver`
+
+// runMigrate implements `app migrate`. Only create works without a database.
+func runMigrate(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	if args[0] == "create" {
+		flags := flag.NewFlagSet("migrate create", flag.ContinueOnError)
+		dir := flags.String("dir", database.MigrationsDir, "migrations directory")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+		if flags.NArg() == 0 {
+			return errors.New("usage: app migrate create [-dir path] <name>")
+		}
+		paths, err := database.CreateMigration(*dir, strings.Join(flags.Args(), "_"))
+		for _, path := range paths {
+			fmt.Printf("Created %s\n", path)
+		}
+		return err
+	}
+
+	if args[0] != "up" && args[0] != "down" && args[0] != "status" {
+		return errors.New(migrateUsage)
+	}
+
+	if err := database.Connect(cfg); err != nil {
+		return err
+	}
+	migrator, err := database.NewMigrator(database.GetDB())
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, migration := range applied {
+			fmt.Printf("Applied %04d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Println("No pending migrations")
+		}
+		return nil
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				return errors.New("usage: app migrate down [n], n must be a positive number")
+			}
+		}
+		rolledBack, err := migrator.Down(ctx, steps)
+		for _, migration := range rolledBack {
+			fmt.Printf("Rolled back %04d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(rolledBack) == 0 {
+			fmt.Println("No migrations to roll back")
+		}
+		return nil
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT\tNOTE")
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05 MST")
+			}
+			note := ""
+			if status.Modified {
+				note = "modified since applied"
+			} else if status.Unknown {
+				note = "not in this binary"
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", status.Version, status.Name, appliedAt, note)
+		}
+		return w.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"task-management/internal/config"
+	"task-management/internal/models"
+	"task-management/internal/services"
+)
+
+type seedUser struct {
+	username string
+	fullName string
+}
+
+// seedTodo describes a demo todo. Due is in days from now; nil means no due
+// date.
+type seedTodo struct {
+	title       string
+	description string
+	priority    models.Priority
+	category    models.Category
+	status      models.Status
+	due         *int
+	estimate    *int
+	assignee    string
+	comments    []seedComment
+	tracked     time.Duration
+}
+
+type seedComment struct {
+	author string
+	body   string
+}
+
+var seedUsers = []seedUser{
+	{username: "demo", fullName: "Demo User"},
+	{username: "alice", fullName: "Alice Wijaya"},
+	{username: "bob", fullName: "Bob Santoso"},
+}
+
+func intPtr(n int) *int {
+	return &n
+}
+
+var personalSeedTodos = []seedTodo{
+	{title: "Pay electricity bill", priority: models.PriorityHigh, category: models.CategoryPersonal, due: intPtr(2)},
+	{title: "Renew passport", description: "Book an appointment at the immigration office and bring two photos", priority: models.PriorityMedium, category: models.CategoryPersonal, due: intPtr(21)},
+	{title: "Buy groceries", description: "Milk, eggs, rice, coffee", priority: models.PriorityLow, category: models.CategoryShopping, due: intPtr(0)},
+	{title: "Dentist check-up", priority: models.PriorityMedium, category: models.CategoryHealth, status: models.StatusDone, due: intPtr(-3)},
+	{title: "Morning run 5k", priority: models.PriorityLow, category: models.CategoryHealth, status: models.StatusInProgress},
+	{title: "Read \"Designing Data-Intensive Applications\"", priority: models.PriorityLow, category: models.CategoryOther, estimate: intPtr(600)},
+	{title: "Call the landlord about the leaking tap", priority: models.PriorityHigh, category: models.CategoryPersonal, due: intPtr(-1)},
+}
+
+var teamSeedTodos = []seedTodo{
+	{
+		title: "Prepare Q3 roadmap", description: "Collect input from support and sales, draft the themes",
+		priority: models.PriorityHigh, category: models.CategoryWork, status: models.StatusInProgress,
+		due: intPtr(5), estimate: intPtr(240), assignee: "demo", tracked: 95 * time.Minute,
+		comments: []seedComment{
+			{author: "alice", body: "@demo I added the support themes to the shared doc."},
+			{author: "demo", body: "Thanks! I'll merge them into the draft tomorrow."},
+		},
+	},
+	{
+		title: "Fix login rate limiting", description: "Repeated failed logins should be throttled per account",
+		priority: models.PriorityHigh, category: models.CategoryWork,
+		due: intPtr(1), estimate: intPtr(180), assignee: "alice",
+		comments: []seedComment{{author: "bob", body: "Saw this in the logs again last night."}},
+	},
+	{
+		title: "Update onboarding docs", priority: models.PriorityMedium, category: models.CategoryWork,
+		due: intPtr(10), estimate: intPtr(90), assignee: "alice",
+	},
+	{
+		title: "Release 1.4.0", description: "Tag, build images and publish the changelog",
+		priority: models.PriorityMedium, category: models.CategoryWork, status: models.StatusDone,
+		due: intPtr(-7), estimate: intPtr(60), assignee: "demo", tracked: 75 * time.Minute,
+	},
+	{
+		title: "Order new team laptops", priority: models.PriorityLow, category: models.CategoryShopping,
+		due: intPtr(14),
+	},
+}
+
+// runSeed loads demo data through the services, so it goes through the same
+// validation, revisions and events as data created through the API.
+func runSeed(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	password := flags.String("password", "demo1234", "password of the demo users")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	a, err := commandApp(cfg)
+	if err != nil {
+		return err
+	}
+
+	users := make(map[string]uint, len(seedUsers))
+	for _, u := range seedUsers {
+		user, err := a.auth.Register(u.username, u.username+"@example.com", *password, u.fullName)
+		if err != nil {
+			if strings.Contains(err.Error(), "already exists") {
+				return fmt.Errorf("user %s already exists, the demo data seems to be loaded", u.username)
+			}
+			return fmt.Errorf("create user %s: %w", u.username, err)
+		}
+		users[u.username] = user.ID
+	}
+	demo := users["demo"]
+
+	personal, err := a.workspace.ResolveWorkspace(demo, 0)
+	if err != nil {
+		return err
+	}
+	team, err := a.workspace.CreateWorkspace(demo, "Product Team")
+	if err != nil {
+		return err
+	}
+	for username, role := range map[string]models.WorkspaceRole{"alice": models.RoleMember, "bob": models.RoleGuest} {
+		invitation, err := a.workspace.Invite(team.ID, demo, services.InviteInput{Username: username, Role: role})
+		if err != nil {
+			return fmt.Errorf("invite %s: %w", username, err)
+		}
+		if _, err := a.workspace.AcceptInvitation(invitation.ID, users[username]); err != nil {
+			return fmt.Errorf("accept invitation for %s: %w", username, err)
+		}
+	}
+
+	if _, err := a.todo.UpdateSubtaskRules(demo, true, true); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, todo := range personalSeedTodos {
+		if err := seedOne(a, personal.ID, demo, users, todo, now); err != nil {
+			return err
+		}
+	}
+	for _, todo := range teamSeedTodos {
+		if err := seedOne(a, team.ID, demo, users, todo, now); err != nil {
+			return err
+		}
+	}
+
+	template, err := a.template.CreateTemplate(team.ID, demo, services.TemplateInput{
+		Name:      "Weekly review",
+		Title:     "Weekly review {{week}}",
+		Priority:  models.PriorityMedium,
+		Category:  models.CategoryWork,
+		DueOffset: intPtr(3 * 24 * 60),
+		Subtasks:  []string{"Close finished todos", "Check overdue items", "Plan next week"},
+	})
+	if err != nil {
+		return fmt.Errorf("create template: %w", err)
+	}
+	_, week := now.ISOWeek()
+	if _, err := a.template.InstantiateTemplate(template.ID, demo, map[string]string{"week": fmt.Sprintf("W%02d", week)}, now, ""); err != nil {
+		return fmt.Errorf("instantiate template: %w", err)
+	}
+
+	fmt.Printf("Seeded users demo, alice and bob with password %q\n", *password)
+	fmt.Printf("demo has %d personal todos and shares %d todos with them in %q\n", len(personalSeedTodos), len(teamSeedTodos)+1, team.Name)
+	return nil
+}
+
+func seedOne(a *app, workspaceID, owner uint, users map[string]uint, seed seedTodo, now time.Time) error {
+	var dueDate *time.Time
+	if seed.due != nil {
+		due := time.Date(now.Year(), now.Month(), now.Day(), 17, 0, 0, 0, now.Location()).AddDate(0, 0, *seed.due)
+		dueDate = &due
+	}
+
+	todo, err := a.todo.CreateTodo(workspaceID, owner, seed.title, seed.description, seed.priority, seed.category, dueDate, seed.estimate)
+	if err != nil {
+		return fmt.Errorf("create todo %q: %w", seed.title, err)
+	}
+
+	// The default workflow only reaches done through in progress.
+	var steps []models.Status
+	switch seed.status {
+	case models.StatusInProgress:
+		steps = []models.Status{models.StatusInProgress}
+	case models.StatusDone:
+		steps = []models.Status{models.StatusInProgress, models.StatusDone}
+	}
+	for _, status := range steps {
+		if todo, err = a.todo.UpdateTodo(todo.ID, owner, map[string]interface{}{"status": status}, nil); err != nil {
+			return fmt.Errorf("move todo %q to %s: %w", seed.title, status, err)
+		}
+	}
+
+	if seed.assignee != "" {
+		if _, err := a.assignee.Assign(todo.ID, users[seed.assignee], owner); err != nil {
+			return fmt.Errorf("assign todo %q: %w", seed.title, err)
+		}
+	}
+	for _, comment := range seed.comments {
+		if _, err := a.comment.CreateComment(todo.ID, users[comment.author], comment.body); err != nil {
+			return fmt.Errorf("comment on todo %q: %w", seed.title, err)
+		}
+	}
+	if seed.tracked > 0 {
+		started := now.Add(-24 * time.Hour)
+		ended := started.Add(seed.tracked)
+		if _, err := a.timeEntry.CreateEntry(todo.ID, owner, services.TimeEntryInput{StartedAt: &started, EndedAt: &ended}); err != nil {
+			return fmt.Errorf("track time on todo %q: %w", seed.title, err)
+		}
+	}
+	return nil
+}
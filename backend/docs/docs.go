@@ -24,52 +24,6 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/login": {
-            "post": {
-                "description": "Authenticate user with username and password, returns JWT token",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Authentication"
-                ],
-                "summary": "User login",
-                "parameters": [
-                    {
-                        "description": "Login Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.LoginRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Login successful with token",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.AuthResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Invalid credentials",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.AuthResponse"
-                        }
-                    },
-                    "422": {
-                        "description": "Validation error",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.AuthResponse"
-                        }
-                    }
-                }
-            }
-        },
         "/auth/login-vulnerable": {
             "post": {
                 "description": "Demonstrasi endpoint rentan SQL injection. Hanya untuk testing lokal/edukasi.",
@@ -162,36 +116,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/todos": {
+        "/board": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all todos for the authenticated user with optional filters",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the authenticated user's todos grouped into todo, inprogress and done columns, each in manual order",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Todos"
                 ],
-                "summary": "Get all todos",
+                "summary": "Get the Kanban board",
                 "parameters": [
-                    {
-                        "enum": [
-                            "pending",
-                            "in_progress",
-                            "completed"
-                        ],
-                        "type": "string",
-                        "description": "Filter by status",
-                        "name": "status",
-                        "in": "query"
-                    },
                     {
                         "enum": [
                             "personal",
@@ -208,7 +148,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Todos retrieved successfully",
+                        "description": "Board retrieved successfully",
                         "schema": {
                             "$ref": "#/definitions/handlers.TodoResponse"
                         }
@@ -226,44 +166,96 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/calendar/feed": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new todo item for the authenticated user",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Show whether the authenticated user has an iCalendar feed. The secret URL is only returned when the token is (re)generated.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Todos"
+                    "Calendar"
                 ],
-                "summary": "Create a new todo",
-                "parameters": [
-                    {
-                        "description": "Create Todo Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                "summary": "Get the calendar feed",
+                "responses": {
+                    "200": {
+                        "description": "Calendar feed retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/handlers.CreateTodoRequest"
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Calendar feed not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
                         }
                     }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issue a new secret token for the authenticated user's iCalendar feed and return the subscription URL. URLs built from the previous token stop working.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Calendar"
                 ],
+                "summary": "Create or regenerate the calendar feed URL",
                 "responses": {
                     "200": {
-                        "description": "Todo created successfully",
+                        "description": "Calendar feed token generated successfully",
                         "schema": {
                             "$ref": "#/definitions/handlers.TodoResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete the authenticated user's iCalendar feed so its URL stops working",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Calendar"
+                ],
+                "summary": "Revoke the calendar feed",
+                "responses": {
+                    "200": {
+                        "description": "Calendar feed revoked successfully",
                         "schema": {
                             "$ref": "#/definitions/handlers.TodoResponse"
                         }
@@ -274,8 +266,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.TodoResponse"
                         }
                     },
-                    "422": {
-                        "description": "Validation error",
+                    "404": {
+                        "description": "Calendar feed not found",
                         "schema": {
                             "$ref": "#/definitions/handlers.TodoResponse"
                         }
@@ -283,69 +275,104 @@ const docTemplate = `{
                 }
             }
         },
-        "/todos/public/{id}": {
+        "/calendar/{token}/todos.ics": {
             "get": {
-                "description": "Get detail of a todo (and subtasks) using its public ID",
+                "description": "Subscribe to a user's todos from a calendar app. The secret token in the URL authenticates the request. Todos are rendered as VTODO; with events=true todos with a due date are also rendered as VEVENT.",
                 "produces": [
-                    "application/json"
+                    "text/calendar"
                 ],
                 "tags": [
-                    "todos"
+                    "Calendar"
                 ],
-                "summary": "Get todo by public ID",
+                "summary": "iCalendar feed",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Public ID of the Todo",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Calendar feed token",
+                        "name": "token",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Also render due dates as events",
+                        "name": "events",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/utils.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                        "description": "iCalendar feed",
                         "schema": {
-                            "$ref": "#/definitions/utils.Response"
+                            "type": "file"
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Calendar feed not found",
                         "schema": {
-                            "$ref": "#/definitions/utils.Response"
+                            "$ref": "#/definitions/handlers.TodoResponse"
                         }
                     }
                 }
             }
         },
-        "/todos/{id}": {
+        "/invitations": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a specific todo by ID for the authenticated user",
-                "consumes": [
+                "description": "List pending workspace invitations addressed to the authenticated user's account or email address",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "List invitations for the authenticated user",
+                "responses": {
+                    "200": {
+                        "description": "Invitations retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/invitations/{id}/accept": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Join the workspace with the role given in the invitation",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Todos"
+                    "Workspaces"
                 ],
-                "summary": "Get a todo by ID",
+                "summary": "Accept an invitation",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Todo ID",
+                        "description": "Invitation ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -353,13 +380,13 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Todo retrieved successfully",
+                        "description": "Invitation accepted successfully",
                         "schema": {
                             "$ref": "#/definitions/handlers.TodoResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid todo ID",
+                        "description": "Invalid invitation ID",
                         "schema": {
                             "$ref": "#/definitions/handlers.TodoResponse"
                         }
@@ -371,57 +398,46 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Todo not found",
+                        "description": "Invitation not found",
                         "schema": {
                             "$ref": "#/definitions/handlers.TodoResponse"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/invitations/{id}/decline": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update a specific todo by ID for the authenticated user",
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Todos"
+                    "Workspaces"
                 ],
-                "summary": "Update a todo",
+                "summary": "Decline an invitation",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Todo ID",
+                        "description": "Invitation ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Update Todo Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.UpdateTodoRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Todo updated successfully",
+                        "description": "Invitation declined successfully",
                         "schema": {
                             "$ref": "#/definitions/handlers.TodoResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request or todo ID",
+                        "description": "Invalid invitation ID",
                         "schema": {
                             "$ref": "#/definitions/handlers.TodoResponse"
                         }
@@ -432,78 +448,4508 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.TodoResponse"
                         }
                     },
-                    "422": {
-                        "description": "Validation error",
+                    "404": {
+                        "description": "Invitation not found",
                         "schema": {
                             "$ref": "#/definitions/handlers.TodoResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/notifications": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a specific todo by ID for the authenticated user",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "List the most recent in-app notifications of the authenticated user",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Todos"
+                    "Notifications"
                 ],
-                "summary": "Delete a todo",
+                "summary": "List notifications",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Todo ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "boolean",
+                        "description": "Only return unread notifications",
+                        "name": "unread",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Todo deleted successfully",
+                        "description": "Notifications retrieved successfully",
                         "schema": {
                             "$ref": "#/definitions/handlers.TodoResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request or todo ID",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/handlers.TodoResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/handlers.TodoResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "handlers.AuthResponse": {
-            "type": "object",
-            "properties": {
-                "data": {},
-                "message": {
-                    "type": "string",
-                    "example": "Operation successful"
-                },
+        },
+        "/notifications/read-all": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Mark all notifications as read",
+                "responses": {
+                    "200": {
+                        "description": "Notifications marked as read",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/{id}/read": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Mark a notification as read",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notification marked as read",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or notification ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Counts of the authenticated user's todos by status, priority and category, overdue and due-today counts, completion rate, average cycle time from creation to completion, and a daily created/completed series. The range defaults to the last 30 days and may span at most 366 days.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stats"
+                ],
+                "summary": "Get productivity statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First day of the series (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day of the series (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "UTC",
+                        "description": "IANA timezone used for days, e.g. Asia/Jakarta",
+                        "name": "tz",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stats retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid range or timezone",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stream": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Push todo create/update/delete events from every workspace the user belongs to as Server-Sent Events. Send Last-Event-ID (header or last_event_id query) to resume after a disconnect.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Stream"
+                ],
+                "summary": "Stream todo events (SSE)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Resume after this event ID",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JWT, for clients that cannot set headers",
+                        "name": "access_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event stream",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stream/ws": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "WebSocket variant of /stream. Every message is a JSON StreamMessage.",
+                "tags": [
+                    "Stream"
+                ],
+                "summary": "Stream todo events (WebSocket)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Resume after this event ID",
+                        "name": "last_event_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JWT, for clients that cannot set headers",
+                        "name": "access_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/templates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the todo templates of the current workspace",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "List templates",
+                "responses": {
+                    "200": {
+                        "description": "Templates retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a todo template in the current workspace. Title, description and subtasks may contain {{variables}}; due_offset_minutes sets the due date relative to instantiation.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Create a template",
+                "parameters": [
+                    {
+                        "description": "Template Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Template created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Workspace guests cannot manage templates",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/templates/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Get a template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Template retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid template ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Template not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace every field of a template",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Update a template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Template Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Template updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or template ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Workspace guests cannot manage templates",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Template not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Delete a template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Template deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid template ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Workspace guests cannot manage templates",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Template not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/templates/{id}/instantiate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a todo with its subtasks from the template in one transaction. Every {{variable}} the template uses must be given. The due date is the template's offset added to from, which defaults to now. Whole days of the offset are calendar days in timezone, so the due time keeps its wall clock time across DST changes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Create a todo from a template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Instantiate Template Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.InstantiateTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Todo created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or template ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Workspace guests cannot create todos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Template not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Missing variables or unknown timezone",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/time/categories": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Total tracked seconds of the authenticated user per todo category",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time tracking"
+                ],
+                "summary": "Get tracked time per category",
+                "responses": {
+                    "200": {
+                        "description": "Tracked time retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/time/report": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Tracked seconds per day or per week (starting Monday) over a date range. Entries count towards the day they started. The range defaults to the last 30 days and may span at most 366 days.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time tracking"
+                ],
+                "summary": "Get a time report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First day of the report (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day of the report (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "UTC",
+                        "description": "IANA timezone used for days, e.g. Asia/Jakarta",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "day",
+                            "week"
+                        ],
+                        "type": "string",
+                        "default": "day",
+                        "description": "Grouping",
+                        "name": "group_by",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Time report retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid range, timezone or grouping",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timer": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time tracking"
+                ],
+                "summary": "Get the running timer",
+                "responses": {
+                    "200": {
+                        "description": "Running timer retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No timer is running",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timer/stop": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stop the authenticated user's running timer and record the time entry",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time tracking"
+                ],
+                "summary": "Stop the running timer",
+                "responses": {
+                    "200": {
+                        "description": "Timer stopped successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No timer is running",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all todos of the current workspace with optional filters. assignee=me lists the todos assigned to the authenticated user, whoever created them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Todos"
+                ],
+                "summary": "Get all todos",
+                "parameters": [
+                    {
+                        "enum": [
+                            "todo",
+                            "inprogress",
+                            "done"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "personal",
+                            "work",
+                            "shopping",
+                            "health",
+                            "other"
+                        ],
+                        "type": "string",
+                        "description": "Filter by category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by assignee: me or a user ID",
+                        "name": "assignee",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Todos retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid assignee",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new todo item for the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Todos"
+                ],
+                "summary": "Create a new todo",
+                "parameters": [
+                    {
+                        "description": "Create Todo Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateTodoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Todo created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Workspace guests cannot change todos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Select todos by IDs or by filter and set status, priority, category or due date, delete or restore them in a single transaction. Returns a result per todo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Todos"
+                ],
+                "summary": "Apply an action to many todos",
+                "parameters": [
+                    {
+                        "description": "Bulk Todo Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BulkTodoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Bulk operation completed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or batch too large",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Workspace guests cannot change todos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download every todo of the authenticated user, including subtasks, as JSON or CSV. Accepts the same filters as GET /todos. The response is streamed.",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Todos"
+                ],
+                "summary": "Export todos",
+                "parameters": [
+                    {
+                        "enum": [
+                            "json",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Export format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "todo",
+                            "inprogress",
+                            "done"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "personal",
+                            "work",
+                            "shopping",
+                            "health",
+                            "other"
+                        ],
+                        "type": "string",
+                        "description": "Filter by category",
+                        "name": "category",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Exported todos",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid format",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Import todos from a file in our own JSON or CSV export format, a Todoist CSV export or a Trello board JSON export. With dry_run=true the parsed rows and their validation errors are returned without importing anything. Otherwise every row must be valid and all todos are created at once.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Todos"
+                ],
+                "summary": "Import todos",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "File to import",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "todoist",
+                            "trello"
+                        ],
+                        "type": "string",
+                        "description": "Import format",
+                        "name": "format",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Only preview the import",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Import preview or result",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or file",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Workspace guests cannot change todos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Some rows are invalid; nothing was imported",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/public/{id}": {
+            "get": {
+                "description": "Get detail of a todo (and subtasks) using its public ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todos"
+                ],
+                "summary": "Get todo by public ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Public ID of the Todo",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/quick": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Parse a line such as \"Pay rent tomorrow 9am !high #personal\" or \"Bayar listrik besok jam 7 malam !tinggi #pribadi\" into a todo. Dates and times in English or Indonesian set the due date in the given time zone (default UTC), !high/!medium/!low (or !1-!3, !tinggi/!sedang/!rendah) the priority, and #category or @category the category; the remaining words form the title. The response lists every recognised token. With dry_run=true nothing is created.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Todos"
+                ],
+                "summary": "Quick-add a todo from one line of text",
+                "parameters": [
+                    {
+                        "description": "Quick Add Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.QuickAddRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Only parse the text",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Parse breakdown and the created todo",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Workspace guests cannot change todos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "No title or unknown time zone",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/subtask-rules": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the authenticated user's rules for completing a todo when all of its subtasks are done and reopening it when one is unchecked",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Todos"
+                ],
+                "summary": "Get subtask rules",
+                "responses": {
+                    "200": {
+                        "description": "Subtask rules retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Turn auto-completion and auto-reopening of todos on or off. Omitted fields keep their current value. The rules apply to subtasks the user checks or unchecks.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Todos"
+                ],
+                "summary": "Update subtask rules",
+                "parameters": [
+                    {
+                        "description": "Subtask Rules Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SubtaskRulesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Subtask rules updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the authenticated user's soft-deleted todos, most recently deleted first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Todos"
+                ],
+                "summary": "List deleted todos",
+                "responses": {
+                    "200": {
+                        "description": "Trash retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a single todo of the authenticated user. The ETag header carries the todo version for use with If-Match.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Todos"
+                ],
+                "summary": "Get a todo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached version",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Todo retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified"
+                    },
+                    "400": {
+                        "description": "Invalid todo ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Todo not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a specific todo by ID for the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Todos"
+                ],
+                "summary": "Update a todo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being modified",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Todo Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateTodoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Todo updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or todo ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Workspace guests cannot change todos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Todo is blocked by open todos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Modified since it was read; data holds the current version",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid enum value or status transition not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header is required",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a specific todo by ID for the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Todos"
+                ],
+                "summary": "Delete a todo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being modified",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Todo deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or todo ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Workspace guests cannot change todos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Modified since it was read; data holds the current version",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header is required",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Patch the title, description, priority, category, status, due_date or estimate_minutes of a todo with a JSON Merge Patch (RFC 7396, application/merge-patch+json) or a JSON Patch (RFC 6902, application/json-patch+json). Setting description, due_date or estimate_minutes to null clears it.",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Todos"
+                ],
+                "summary": "Partially update a todo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being modified",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Merge patch object or JSON patch operations",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Todo updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid todo ID or malformed patch",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Workspace guests cannot change todos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Test operation failed or todo is blocked",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Modified since it was read; data holds the current version",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported patch format",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid field value or status transition not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header is required",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}/assignees": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Assignees"
+                ],
+                "summary": "List assignees of a todo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Assignees retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid todo ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Todo not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Assign a todo to a member of its workspace. The assignee is notified unless they assigned themselves.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Assignees"
+                ],
+                "summary": "Assign a todo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Assign Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AssignRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Todo assigned successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or todo ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Workspace guests cannot change todos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Todo not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "User is already assigned",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "The user is not a member of the todo's workspace",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}/assignees/{userId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove an assignee from a todo and notify them. Anyone who can see the todo may unassign themselves.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Assignees"
+                ],
+                "summary": "Unassign a todo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID of the assignee",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Todo unassigned successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid todo or user ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Workspace guests cannot change todos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Todo or assignee not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}/attachments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "List attachments of a todo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Attachments retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or todo ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Attach a file to a todo. The content type is detected from the file content.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Upload an attachment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File to upload",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Attachment uploaded successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request, file too large or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Workspace guests cannot change todos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}/attachments/{attachmentId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download the file. It is always served with Content-Disposition: attachment.",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Download an attachment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attachment ID",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Attachment content",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Attachment not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Delete an attachment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attachment ID",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Attachment deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Workspace guests cannot change todos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}/blockers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the todos that must be done before this todo can be completed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Todos"
+                ],
+                "summary": "List blockers of a todo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Blockers retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid todo ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark another todo as blocking this one. Dependencies that would create a cycle are rejected.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Todos"
+                ],
+                "summary": "Add a blocker to a todo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Add Blocker Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AddBlockerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Blocker added successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or todo ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Workspace guests cannot change todos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Dependency already exists or would create a cycle",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}/blockers/{blockerId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Todos"
+                ],
+                "summary": "Remove a blocker from a todo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Blocking todo ID",
+                        "name": "blockerId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Blocker removed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or todo ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Workspace guests cannot change todos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}/comments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "List comments of a todo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Comments retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or todo ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a markdown comment. Every @username mentioned who is a member of the todo's workspace receives a notification.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Comment on a todo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Comment created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or todo ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}/comments/{commentId}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Only the author can edit a comment. The previous body is kept in the comment history.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Edit a comment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Comment updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Only the author can delete a comment",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Delete a comment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Comment deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}/comments/{commentId}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the previous bodies of an edited comment, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Get comment edit history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Comment history retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List every recorded change of a todo, newest first, with the old and new value of each changed field",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Todos"
+                ],
+                "summary": "Get todo revision history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "History retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or todo ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}/move": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move a todo to a status column and place it between two neighbours. after_id is the todo directly above and before_id the todo directly below; with neither the todo goes to the end of the column.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Todos"
+                ],
+                "summary": "Move a todo on the Kanban board",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Move Todo Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MoveTodoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Todo moved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request, todo ID or neighbour",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Workspace guests cannot change todos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Todo is blocked by open todos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid status, transition not allowed or neighbours out of order",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}/purge": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Permanently remove a todo that is already in the trash, together with its subtasks",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Todos"
+                ],
+                "summary": "Permanently delete a todo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Todo purged successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or todo ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Workspace guests cannot change todos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move a todo and the subtasks deleted with it out of the trash",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Todos"
+                ],
+                "summary": "Restore a deleted todo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Todo restored successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or todo ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Workspace guests cannot change todos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}/revert/{revision}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restore the fields captured by a revision. The revert is recorded as a new revision.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Todos"
+                ],
+                "summary": "Revert a todo to a previous revision",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision number",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Todo reverted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request, todo ID or revision",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Workspace guests cannot change todos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Todo is blocked by open todos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Status transition not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}/subtasks/{subtaskId}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rename a subtask or mark it as completed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Todos"
+                ],
+                "summary": "Update a subtask",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being modified",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Subtask ID",
+                        "name": "subtaskId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Subtask Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateSubtaskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Subtask updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request, todo ID or subtask ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Workspace guests cannot change todos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Modified since it was read; data holds the current version",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header is required",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}/time": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Total tracked seconds of a todo compared with its estimate. remaining_seconds is negative once the estimate is exceeded. A running timer is reported but not counted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time tracking"
+                ],
+                "summary": "Get the time tracked on a todo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tracked time retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid todo ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Todo not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}/time-entries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time tracking"
+                ],
+                "summary": "List time entries of a todo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Time entries retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid todo ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Todo not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record time spent on a todo. started_at and ended_at are required and an entry may span at most 24 hours.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time tracking"
+                ],
+                "summary": "Add a time entry manually",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Time Entry Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TimeEntryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Time entry created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or todo ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Workspace guests cannot change todos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Todo not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid time range",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}/time-entries/{entryId}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the start, end or note of a time entry. Omitted fields are left unchanged; setting ended_at on a running timer stops it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time tracking"
+                ],
+                "summary": "Edit a time entry",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Time entry ID",
+                        "name": "entryId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Time Entry Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TimeEntryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Time entry updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Todo or time entry not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid time range",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time tracking"
+                ],
+                "summary": "Delete a time entry",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Time entry ID",
+                        "name": "entryId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Time entry deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Todo or time entry not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}/timer/start": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Start tracking time on a todo. Only one timer can run per user; stop it before starting another.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time tracking"
+                ],
+                "summary": "Start a timer on a todo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Start Timer Request",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.StartTimerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Timer started successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or todo ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Workspace guests cannot change todos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Todo not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "A timer is already running",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the webhooks registered by the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List webhooks",
+                "responses": {
+                    "200": {
+                        "description": "Webhooks retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Register an endpoint that receives signed events for the todos of every workspace the user belongs to. The signing secret is only returned once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Register a webhook",
+                "parameters": [
+                    {
+                        "description": "Create Webhook Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get a webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the url, subscribed events or active flag of a webhook",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Update a webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Webhook Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or webhook ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Delete a webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or webhook ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the most recent delivery attempts of a webhook",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deliveries retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries/{deliveryId}/redeliver": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send the payload of a previous delivery again, with a fresh retry schedule",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Redeliver a webhook event",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "deliveryId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Redelivery scheduled",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook or delivery not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the workspaces the authenticated user belongs to with their role in each, personal workspace first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "List workspaces",
+                "responses": {
+                    "200": {
+                        "description": "Workspaces retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a shared workspace owned by the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "Create a workspace",
+                "parameters": [
+                    {
+                        "description": "Workspace Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.WorkspaceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Workspace created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "Get a workspace",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workspace ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Workspace retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid workspace ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Workspace not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rename a workspace. Requires the admin role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "Rename a workspace",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workspace ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Workspace Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.WorkspaceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Workspace updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or workspace ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient workspace role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Workspace not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a shared workspace together with its todos. Only the owner can delete it; the personal workspace cannot be deleted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "Delete a workspace",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workspace ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Workspace deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or workspace ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient workspace role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Workspace not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/{id}/invitations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Requires the admin role",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "List pending invitations of a workspace",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workspace ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Invitations retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid workspace ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient workspace role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Workspace not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Invite a user by username or an email address, which may belong to someone without an account yet. Requires the admin role; only the owner can invite admins. The role defaults to member.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "Invite someone to a workspace",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workspace ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Invite Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.InviteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Invitation sent successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or already a member",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient workspace role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Workspace or user not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/{id}/invitations/{invitationId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Requires the admin role",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "Revoke an invitation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workspace ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Invitation ID",
+                        "name": "invitationId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Invitation revoked successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid workspace or invitation ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient workspace role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Workspace or invitation not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/{id}/members": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "List workspace members",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workspace ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Members retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid workspace ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Workspace not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/{id}/members/{userId}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admins can change the role of members and guests. Only the owner can appoint or demote admins; giving someone the owner role transfers ownership and makes the previous owner an admin.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "Change a member's role",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workspace ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID of the member",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Member Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Member updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request, workspace or user ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient workspace role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Workspace or member not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a member from the workspace. Anyone but the owner can remove themselves; removing others requires the admin role, and only the owner can remove admins.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "Remove a member or leave a workspace",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workspace ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID of the member",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Member removed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request, workspace or user ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient workspace role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Workspace or member not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/{id}/switch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Make a workspace the default for requests without an X-Workspace-ID header",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "Switch the current workspace",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workspace ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Workspace switched successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid workspace ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Workspace not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TodoResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handlers.AddBlockerRequest": {
+            "type": "object",
+            "required": [
+                "blocker_id"
+            ],
+            "properties": {
+                "blocker_id": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "handlers.AssignRequest": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
+                "user_id": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "handlers.AuthResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "message": {
+                    "type": "string",
+                    "example": "Operation successful"
+                },
                 "status": {
                     "type": "string",
                     "example": "success"
                 }
             }
         },
+        "handlers.BulkTodoFilter": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "enum": [
+                        "personal",
+                        "work",
+                        "shopping",
+                        "health",
+                        "other"
+                    ],
+                    "example": "work"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "todo",
+                        "inprogress",
+                        "done"
+                    ],
+                    "example": "done"
+                }
+            }
+        },
+        "handlers.BulkTodoRequest": {
+            "type": "object",
+            "required": [
+                "action"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "set_status",
+                        "set_priority",
+                        "set_category",
+                        "set_due_date",
+                        "delete",
+                        "restore"
+                    ],
+                    "example": "set_status"
+                },
+                "filter": {
+                    "$ref": "#/definitions/handlers.BulkTodoFilter"
+                },
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2,
+                        3
+                    ]
+                },
+                "value": {
+                    "type": "string",
+                    "example": "done"
+                }
+            }
+        },
+        "handlers.CommentRequest": {
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "example": "Looks good, @johndoe can you **review**?"
+                }
+            }
+        },
         "handlers.CreateTodoRequest": {
             "type": "object",
             "required": [
@@ -533,6 +4979,10 @@ const docTemplate = `{
                     "type": "string",
                     "example": "2024-12-31T23:59:59Z"
                 },
+                "estimate_minutes": {
+                    "type": "integer",
+                    "example": 90
+                },
                 "priority": {
                     "enum": [
                         "low",
@@ -552,6 +5002,71 @@ const docTemplate = `{
                 }
             }
         },
+        "handlers.CreateWebhookRequest": {
+            "type": "object",
+            "required": [
+                "events",
+                "url"
+            ],
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.EventType"
+                    },
+                    "example": [
+                        "todo.created",
+                        "todo.completed"
+                    ]
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/todos"
+                }
+            }
+        },
+        "handlers.InstantiateTemplateRequest": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "description": "From is the moment the due offset counts from; it defaults to now.",
+                    "type": "string",
+                    "example": "2024-12-01T09:00:00Z"
+                },
+                "timezone": {
+                    "description": "Timezone is the IANA time zone whole days of the offset are counted\nin; it defaults to UTC.",
+                    "type": "string",
+                    "example": "Europe/Berlin"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handlers.InviteRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "jane@example.com"
+                },
+                "role": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.WorkspaceRole"
+                        }
+                    ],
+                    "example": "member"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "jane"
+                }
+            }
+        },
         "handlers.LoginRequest": {
             "type": "object",
             "required": [
@@ -569,6 +5084,51 @@ const docTemplate = `{
                 }
             }
         },
+        "handlers.MoveTodoRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "after_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "before_id": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "status": {
+                    "enum": [
+                        "todo",
+                        "inprogress",
+                        "done"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Status"
+                        }
+                    ],
+                    "example": "inprogress"
+                }
+            }
+        },
+        "handlers.QuickAddRequest": {
+            "type": "object",
+            "required": [
+                "text"
+            ],
+            "properties": {
+                "text": {
+                    "type": "string",
+                    "example": "Pay rent tomorrow 9am !high #personal"
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Asia/Jakarta"
+                }
+            }
+        },
         "handlers.RegisterRequest": {
             "type": "object",
             "required": [
@@ -596,6 +5156,100 @@ const docTemplate = `{
                 }
             }
         },
+        "handlers.StartTimerRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "example": "Client call"
+                }
+            }
+        },
+        "handlers.SubtaskRulesRequest": {
+            "type": "object",
+            "properties": {
+                "auto_complete": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "auto_reopen": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "handlers.TemplateRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "title"
+            ],
+            "properties": {
+                "category": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Category"
+                        }
+                    ],
+                    "example": "work"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Ship {{version}} to production"
+                },
+                "due_offset_minutes": {
+                    "type": "integer",
+                    "example": 4320
+                },
+                "estimate_minutes": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Release checklist"
+                },
+                "priority": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Priority"
+                        }
+                    ],
+                    "example": "high"
+                },
+                "subtasks": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Tag {{version}}",
+                        "Publish release notes"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Release {{version}}"
+                }
+            }
+        },
+        "handlers.TimeEntryRequest": {
+            "type": "object",
+            "properties": {
+                "ended_at": {
+                    "type": "string",
+                    "example": "2024-12-31T10:30:00Z"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Drafted the proposal"
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2024-12-31T09:00:00Z"
+                }
+            }
+        },
         "handlers.TodoResponse": {
             "type": "object",
             "properties": {
@@ -610,6 +5264,43 @@ const docTemplate = `{
                 }
             }
         },
+        "handlers.UpdateMemberRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.WorkspaceRole"
+                        }
+                    ],
+                    "example": "member"
+                }
+            }
+        },
+        "handlers.UpdateSubtaskRequest": {
+            "type": "object",
+            "properties": {
+                "is_completed": {
+                    "enum": [
+                        "yes",
+                        "no"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.CompletionStatus"
+                        }
+                    ],
+                    "example": "yes"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Buy milk"
+                }
+            }
+        },
         "handlers.UpdateTodoRequest": {
             "type": "object",
             "properties": {
@@ -636,6 +5327,10 @@ const docTemplate = `{
                     "type": "string",
                     "example": "2024-12-31T23:59:59Z"
                 },
+                "estimate_minutes": {
+                    "type": "integer",
+                    "example": 120
+                },
                 "priority": {
                     "enum": [
                         "low",
@@ -651,16 +5346,16 @@ const docTemplate = `{
                 },
                 "status": {
                     "enum": [
-                        "pending",
-                        "in_progress",
-                        "completed"
+                        "todo",
+                        "inprogress",
+                        "done"
                     ],
                     "allOf": [
                         {
                             "$ref": "#/definitions/models.Status"
                         }
                     ],
-                    "example": "completed"
+                    "example": "done"
                 },
                 "title": {
                     "type": "string",
@@ -668,6 +5363,40 @@ const docTemplate = `{
                 }
             }
         },
+        "handlers.UpdateWebhookRequest": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.EventType"
+                    },
+                    "example": [
+                        "todo.updated"
+                    ]
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/todos"
+                }
+            }
+        },
+        "handlers.WorkspaceRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Marketing"
+                }
+            }
+        },
         "models.Category": {
             "type": "string",
             "enum": [
@@ -685,6 +5414,36 @@ const docTemplate = `{
                 "CategoryOther"
             ]
         },
+        "models.CompletionStatus": {
+            "type": "string",
+            "enum": [
+                "yes",
+                "no"
+            ],
+            "x-enum-varnames": [
+                "CompletionYes",
+                "CompletionNo"
+            ]
+        },
+        "models.EventType": {
+            "type": "string",
+            "enum": [
+                "todo.created",
+                "todo.updated",
+                "todo.completed",
+                "todo.deleted",
+                "todo.restored",
+                "subtask.completed"
+            ],
+            "x-enum-varnames": [
+                "EventTodoCreated",
+                "EventTodoUpdated",
+                "EventTodoCompleted",
+                "EventTodoDeleted",
+                "EventTodoRestored",
+                "EventSubtaskCompleted"
+            ]
+        },
         "models.Priority": {
             "type": "string",
             "enum": [
@@ -711,6 +5470,21 @@ const docTemplate = `{
                 "StatusDone"
             ]
         },
+        "models.WorkspaceRole": {
+            "type": "string",
+            "enum": [
+                "owner",
+                "admin",
+                "member",
+                "guest"
+            ],
+            "x-enum-varnames": [
+                "RoleOwner",
+                "RoleAdmin",
+                "RoleMember",
+                "RoleGuest"
+            ]
+        },
         "utils.Response": {
             "type": "object",
             "properties": {
@@ -1,6 +1,8 @@
 package auth
 
 import (
+    "crypto/rand"
+    "encoding/hex"
     "fmt"
     "time"
     
@@ -14,11 +16,21 @@ type Claims struct {
     jwt.RegisteredClaims
 }
 
+// GenerateToken issues a token with a random ID, so it can be revoked on
+// its own, and an issue time, so all of a user's tokens can be revoked.
 func GenerateToken(userID uint, cfg *config.Config) (string, error) {
-    expirationTime := time.Now().Add(cfg.JWT.Expiration)
+    id := make([]byte, 16)
+    if _, err := rand.Read(id); err != nil {
+        return "", err
+    }
+
+    now := time.Now()
+    expirationTime := now.Add(cfg.JWT.Expiration)
     claims := &Claims{
         UserID: userID,
         RegisteredClaims: jwt.RegisteredClaims{
+            ID:        hex.EncodeToString(id),
+            IssuedAt:  jwt.NewNumericDate(now),
             ExpiresAt: jwt.NewNumericDate(expirationTime),
         },
     }
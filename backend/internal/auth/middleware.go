@@ -9,7 +9,15 @@ import (
     "github.com/gin-gonic/gin"
 )
 
-func AuthMiddleware(cfg *config.Config) gin.HandlerFunc {
+// RevocationChecker reports whether a valid token was revoked before it
+// expired.
+type RevocationChecker interface {
+    IsRevoked(claims *Claims) (bool, error)
+}
+
+// AuthMiddleware accepts valid bearer tokens that revocations does not
+// reject. A nil checker skips the revocation check.
+func AuthMiddleware(cfg *config.Config, revocations RevocationChecker) gin.HandlerFunc {
     return func(c *gin.Context) {
         authHeader := c.GetHeader("Authorization")
         if authHeader == "" {
@@ -31,8 +39,37 @@ func AuthMiddleware(cfg *config.Config) gin.HandlerFunc {
             c.Abort()
             return
         }
+
+        if revocations != nil {
+            revoked, err := revocations.IsRevoked(claims)
+            if err != nil {
+                c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check token"})
+                c.Abort()
+                return
+            }
+            if revoked {
+                c.JSON(http.StatusUnauthorized, gin.H{"error": "Token has been revoked"})
+                c.Abort()
+                return
+            }
+        }
         
         c.Set("userID", claims.UserID)
         c.Next()
     }
+}
+
+// StreamAuthMiddleware behaves like AuthMiddleware but also accepts the token
+// in the access_token query parameter, because browsers cannot set headers on
+// EventSource and WebSocket connections.
+func StreamAuthMiddleware(cfg *config.Config, revocations RevocationChecker) gin.HandlerFunc {
+    authenticate := AuthMiddleware(cfg, revocations)
+    return func(c *gin.Context) {
+        if c.GetHeader("Authorization") == "" {
+            if token := c.Query("access_token"); token != "" {
+                c.Request.Header.Set("Authorization", "Bearer "+token)
+            }
+        }
+        authenticate(c)
+    }
 }
\ No newline at end of file
@@ -13,15 +13,28 @@ type Config struct {
     Database DatabaseConfig
     JWT      JWTConfig
     Server   ServerConfig
+    Webhook  WebhookConfig
+    Stream   StreamConfig
+    Todo     TodoConfig
+    Storage  StorageConfig
 }
 
 type DatabaseConfig struct {
+    // Driver is postgres or sqlite. SQLite keeps the database in the file
+    // at SQLitePath and only suits a single instance.
+    Driver     string
+    SQLitePath string
+
     Host     string
     Port     string
     Name     string
     User     string
     Password string
     SSLMode  string
+
+    // AutoMigrate applies pending migrations on startup. When disabled the
+    // server refuses to start until `migrate up` has been run.
+    AutoMigrate bool
 }
 
 type JWTConfig struct {
@@ -34,21 +47,75 @@ type ServerConfig struct {
     Port string
 }
 
+type WebhookConfig struct {
+    MaxAttempts    int
+    InitialBackoff time.Duration
+    Timeout        time.Duration
+}
+
+type StreamConfig struct {
+    Retention time.Duration
+    Heartbeat time.Duration
+}
+
+type TodoConfig struct {
+    BulkMaxItems       int
+    TrashRetentionDays int
+    ImportMaxRows      int
+    BlockInProgress    bool
+    StatusTransitions  string
+    RequireIfMatch     bool
+}
+
+type StorageConfig struct {
+    Driver      string
+    LocalPath   string
+    S3          S3Config
+    MaxFileSize int64
+    UserQuota   int64
+}
+
+type S3Config struct {
+    Endpoint  string
+    AccessKey string
+    SecretKey string
+    Bucket    string
+    Region    string
+    UseSSL    bool
+}
+
 func Load() *Config {
     if err := godotenv.Load(); err != nil {
         log.Println("No .env file found, using environment variables")
     }
     
     jwtExpHours, _ := strconv.Atoi(getEnv("JWT_EXPIRATION_HOURS", "24"))
+    webhookMaxAttempts, _ := strconv.Atoi(getEnv("WEBHOOK_MAX_ATTEMPTS", "5"))
+    webhookBackoffSeconds, _ := strconv.Atoi(getEnv("WEBHOOK_INITIAL_BACKOFF_SECONDS", "2"))
+    webhookTimeoutSeconds, _ := strconv.Atoi(getEnv("WEBHOOK_TIMEOUT_SECONDS", "10"))
+    streamRetentionHours, _ := strconv.Atoi(getEnv("STREAM_RETENTION_HOURS", "24"))
+    streamHeartbeatSeconds, _ := strconv.Atoi(getEnv("STREAM_HEARTBEAT_SECONDS", "25"))
+    bulkMaxItems, _ := strconv.Atoi(getEnv("TODO_BULK_MAX_ITEMS", "100"))
+    trashRetentionDays, _ := strconv.Atoi(getEnv("TODO_TRASH_RETENTION_DAYS", "30"))
+    importMaxRows, _ := strconv.Atoi(getEnv("TODO_IMPORT_MAX_ROWS", "1000"))
+    blockInProgress, _ := strconv.ParseBool(getEnv("TODO_BLOCK_IN_PROGRESS", "false"))
+    requireIfMatch, _ := strconv.ParseBool(getEnv("TODO_REQUIRE_IF_MATCH", "false"))
+    attachmentMaxFileMB, _ := strconv.ParseInt(getEnv("ATTACHMENT_MAX_FILE_MB", "10"), 10, 64)
+    attachmentUserQuotaMB, _ := strconv.ParseInt(getEnv("ATTACHMENT_USER_QUOTA_MB", "100"), 10, 64)
+    autoMigrate, _ := strconv.ParseBool(getEnv("DB_AUTO_MIGRATE", "true"))
+    s3UseSSL, _ := strconv.ParseBool(getEnv("S3_USE_SSL", "false"))
     
     return &Config{
         Database: DatabaseConfig{
-            Host:     getEnv("DB_HOST", "localhost"),
-            Port:     getEnv("DB_PORT", "5432"),
-            Name:     getEnv("DB_NAME", "taskmanagement"),
-            User:     getEnv("DB_USER", "postgres"),
-            Password: getEnv("DB_PASSWORD", ""),
-            SSLMode:  getEnv("DB_SSLMODE", "disable"),
+            Driver:      getEnv("DB_DRIVER", "postgres"),
+            SQLitePath:  getEnv("DB_SQLITE_PATH", "taskmanagement.db"),
+            Host:        getEnv("DB_HOST", "localhost"),
+            Port:        getEnv("DB_PORT", "5432"),
+            Name:        getEnv("DB_NAME", "taskmanagement"),
+            User:        getEnv("DB_USER", "postgres"),
+            Password:    getEnv("DB_PASSWORD", ""),
+            SSLMode:     getEnv("DB_SSLMODE", "disable"),
+            AutoMigrate: autoMigrate,
         },
         JWT: JWTConfig{
             Secret:     getEnv("JWT_SECRET", "your-secret-key"),
@@ -58,6 +125,37 @@ func Load() *Config {
             Host: getEnv("SERVER_HOST", "localhost"),
             Port: getEnv("SERVER_PORT", "8080"),
         },
+        Webhook: WebhookConfig{
+            MaxAttempts:    webhookMaxAttempts,
+            InitialBackoff: time.Duration(webhookBackoffSeconds) * time.Second,
+            Timeout:        time.Duration(webhookTimeoutSeconds) * time.Second,
+        },
+        Stream: StreamConfig{
+            Retention: time.Duration(streamRetentionHours) * time.Hour,
+            Heartbeat: time.Duration(streamHeartbeatSeconds) * time.Second,
+        },
+        Todo: TodoConfig{
+            BulkMaxItems:       bulkMaxItems,
+            TrashRetentionDays: trashRetentionDays,
+            ImportMaxRows:      importMaxRows,
+            BlockInProgress:    blockInProgress,
+            StatusTransitions:  getEnv("TODO_STATUS_TRANSITIONS", "todo:inprogress;inprogress:todo,done;done:todo"),
+            RequireIfMatch:     requireIfMatch,
+        },
+        Storage: StorageConfig{
+            Driver:    getEnv("STORAGE_DRIVER", "local"),
+            LocalPath: getEnv("STORAGE_LOCAL_PATH", "./uploads"),
+            S3: S3Config{
+                Endpoint:  getEnv("S3_ENDPOINT", "localhost:9000"),
+                AccessKey: getEnv("S3_ACCESS_KEY", ""),
+                SecretKey: getEnv("S3_SECRET_KEY", ""),
+                Bucket:    getEnv("S3_BUCKET", "attachments"),
+                Region:    getEnv("S3_REGION", "us-east-1"),
+                UseSSL:    s3UseSSL,
+            },
+            MaxFileSize: attachmentMaxFileMB << 20,
+            UserQuota:   attachmentUserQuotaMB << 20,
+        },
     }
 }
 
@@ -1,12 +1,13 @@
 package database
 
 import (
+    "context"
     "fmt"
     "log"
     
     "task-management/internal/config"
-    "task-management/internal/models"
     
+    "github.com/glebarez/sqlite"
     "gorm.io/driver/postgres"
     "gorm.io/gorm"
     "gorm.io/gorm/logger"
@@ -14,8 +15,14 @@ import (
 
 var DB *gorm.DB
 
-func Connect(cfg *config.Config) error {
-    dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s",
+const (
+    DriverPostgres = "postgres"
+    DriverSQLite   = "sqlite"
+)
+
+// DSN builds the Postgres connection string from the configuration.
+func DSN(cfg *config.Config) string {
+    return fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s",
         cfg.Database.Host,
         cfg.Database.User,
         cfg.Database.Password,
@@ -23,9 +30,38 @@ func Connect(cfg *config.Config) error {
         cfg.Database.Port,
         cfg.Database.SSLMode,
     )
-    
-    var err error
-    DB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{
+}
+
+// SQLiteDSN opens the SQLite database at path with foreign keys enforced,
+// as Postgres does, and waits for locks held by other connections instead
+// of failing.
+func SQLiteDSN(path string) string {
+    return "file:" + path + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+}
+
+// Dialector picks the GORM driver for DB_DRIVER.
+func Dialector(cfg *config.Config) (gorm.Dialector, error) {
+    switch cfg.Database.Driver {
+    case DriverPostgres, "":
+        return postgres.Open(DSN(cfg)), nil
+    case DriverSQLite:
+        return sqlite.Open(SQLiteDSN(cfg.Database.SQLitePath) + "&_pragma=journal_mode(WAL)"), nil
+    }
+    return nil, fmt.Errorf("unknown database driver %q, use %s or %s", cfg.Database.Driver, DriverPostgres, DriverSQLite)
+}
+
+// IsSQLite reports whether db runs on SQLite rather than Postgres.
+func IsSQLite(db *gorm.DB) bool {
+    return db.Dialector.Name() == DriverSQLite
+}
+
+func Connect(cfg *config.Config) error {
+    dialector, err := Dialector(cfg)
+    if err != nil {
+        return err
+    }
+
+    DB, err = gorm.Open(dialector, &gorm.Config{
         Logger: logger.Default.LogMode(logger.Info),
     })
     
@@ -37,18 +73,38 @@ func Connect(cfg *config.Config) error {
     return nil
 }
 
+// Migrate applies the pending migrations. Instances starting at the same
+// time wait for each other, so every migration runs exactly once.
 func Migrate() error {
-    err := DB.AutoMigrate(
-        &models.User{},
-        &models.Todo{},
-        &models.Subtask{},
-    )
-    
+    migrator, err := NewMigrator(DB)
+    if err != nil {
+        return err
+    }
+
+    applied, err := migrator.Up(context.Background())
     if err != nil {
         return fmt.Errorf("failed to migrate database: %w", err)
     }
-    
-    log.Println("Database migrated successfully")
+
+    log.Printf("Database migrated successfully, %d migration(s) applied", len(applied))
+    return nil
+}
+
+// CheckMigrations fails when migrations are pending, for deployments that
+// run `migrate up` as a separate step.
+func CheckMigrations() error {
+    migrator, err := NewMigrator(DB)
+    if err != nil {
+        return err
+    }
+
+    pending, err := migrator.Pending(context.Background())
+    if err != nil {
+        return fmt.Errorf("failed to check migrations: %w", err)
+    }
+    if pending > 0 {
+        return fmt.Errorf("%d migration(s) pending, run `migrate up` first", pending)
+    }
     return nil
 }
 
@@ -0,0 +1,61 @@
+// Package dbtest provides isolated, migrated in-memory databases for tests,
+// so they run without a Postgres server.
+package dbtest
+
+import (
+    "context"
+    "fmt"
+    "os"
+    "sync/atomic"
+    "testing"
+
+    "task-management/internal/database"
+
+    "github.com/glebarez/sqlite"
+    "gorm.io/gorm"
+    "gorm.io/gorm/logger"
+)
+
+var counter int64
+
+// New opens a fresh in-memory SQLite database with every migration applied
+// and closes it when the test finishes. Each call gets its own database,
+// shared by all connections of the returned pool.
+func New(tb testing.TB) *gorm.DB {
+    tb.Helper()
+
+    db := Open(tb)
+    migrator, err := database.NewMigrator(db)
+    if err != nil {
+        tb.Fatalf("dbtest: %v", err)
+    }
+    if _, err := migrator.Up(context.Background()); err != nil {
+        tb.Fatalf("dbtest: %v", err)
+    }
+    return db
+}
+
+// Open is New without the migrations, for tests that set up the schema
+// themselves.
+func Open(tb testing.TB) *gorm.DB {
+    tb.Helper()
+
+    name := fmt.Sprintf("/dbtest-%d-%d", os.Getpid(), atomic.AddInt64(&counter, 1))
+    db, err := gorm.Open(sqlite.Open(database.SQLiteDSN(name)+"&vfs=memdb"), &gorm.Config{
+        Logger: logger.Discard,
+    })
+    if err != nil {
+        tb.Fatalf("dbtest: failed to open database: %v", err)
+    }
+
+    sqlDB, err := db.DB()
+    if err != nil {
+        tb.Fatalf("dbtest: %v", err)
+    }
+    // The memdb is freed once its last connection closes, so keep one open.
+    sqlDB.SetMaxIdleConns(1)
+    sqlDB.SetConnMaxIdleTime(0)
+    sqlDB.SetConnMaxLifetime(0)
+    tb.Cleanup(func() { sqlDB.Close() })
+    return db
+}
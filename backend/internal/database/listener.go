@@ -0,0 +1,58 @@
+package database
+
+import (
+    "context"
+    "log"
+    "time"
+
+    "task-management/internal/config"
+
+    "github.com/jackc/pgx/v5"
+)
+
+const listenReconnectDelay = 5 * time.Second
+
+// Listen subscribes to a Postgres NOTIFY channel on a dedicated connection
+// and calls handle for every notification received. It reconnects on
+// failure and only returns once ctx is cancelled, or at once on SQLite.
+func Listen(ctx context.Context, cfg *config.Config, channel string, handle func(payload string)) {
+    // SQLite has no notifications; publishers deliver within the process.
+    if cfg.Database.Driver == DriverSQLite {
+        return
+    }
+
+    for {
+        err := listen(ctx, cfg, channel, handle)
+        if ctx.Err() != nil {
+            return
+        }
+
+        log.Printf("Listener on channel %s stopped: %v, reconnecting", channel, err)
+        select {
+        case <-ctx.Done():
+            return
+        case <-time.After(listenReconnectDelay):
+        }
+    }
+}
+
+func listen(ctx context.Context, cfg *config.Config, channel string, handle func(payload string)) error {
+    conn, err := pgx.Connect(ctx, DSN(cfg))
+    if err != nil {
+        return err
+    }
+    defer conn.Close(context.Background())
+
+    if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+        return err
+    }
+    log.Printf("Listening for notifications on channel %s", channel)
+
+    for {
+        notification, err := conn.WaitForNotification(ctx)
+        if err != nil {
+            return err
+        }
+        handle(notification.Payload)
+    }
+}
@@ -0,0 +1,387 @@
+package database
+
+import (
+    "context"
+    "crypto/sha256"
+    "embed"
+    "encoding/hex"
+    "errors"
+    "fmt"
+    "io/fs"
+    "os"
+    "path/filepath"
+    "regexp"
+    "sort"
+    "strconv"
+    "strings"
+    "time"
+
+    "gorm.io/gorm"
+)
+
+//go:embed migrations/postgres/*.sql migrations/sqlite/*.sql
+var migrationFiles embed.FS
+
+// MigrationsDir is where `migrate create` writes new migrations, relative
+// to the backend module root. It holds one directory per driver with the
+// same versions in each.
+const MigrationsDir = "internal/database/migrations"
+
+var migrationDialects = []string{DriverPostgres, DriverSQLite}
+
+// migrationLockKey identifies the advisory lock that serialises migration
+// runs across instances.
+const migrationLockKey int64 = 4839201755
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Migration is one versioned schema change. Checksum is the SHA-256 of the
+// up script and is recorded when the migration is applied, so edits to an
+// applied migration are detected instead of silently ignored.
+type Migration struct {
+    Version  int64
+    Name     string
+    Up       string
+    Down     string
+    Checksum string
+}
+
+// MigrationStatus reports a migration known to the binary, the database or
+// both. Modified means the applied checksum differs from the embedded
+// script; Unknown means the database has a version this binary lacks.
+type MigrationStatus struct {
+    Version   int64      `json:"version"`
+    Name      string     `json:"name"`
+    AppliedAt *time.Time `json:"applied_at"`
+    Modified  bool       `json:"modified"`
+    Unknown   bool       `json:"unknown"`
+}
+
+type schemaMigration struct {
+    Version   int64     `gorm:"primaryKey;autoIncrement:false"`
+    Name      string
+    Checksum  string
+    AppliedAt time.Time
+}
+
+func (schemaMigration) TableName() string {
+    return "schema_migrations"
+}
+
+// createSchemaMigrations is formatted with the timestamp type, as the
+// SQLite driver only reads datetime columns back as times.
+const createSchemaMigrations = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version bigint PRIMARY KEY,
+    name varchar(255) NOT NULL,
+    checksum varchar(64) NOT NULL,
+    applied_at %s NOT NULL
+)`
+
+// Migrator applies the embedded migrations to a database.
+type Migrator struct {
+    db         *gorm.DB
+    migrations []Migration
+}
+
+// NewMigrator loads the migrations written for db's driver.
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+    dialect := DriverPostgres
+    if IsSQLite(db) {
+        dialect = DriverSQLite
+    }
+    files, err := fs.Sub(migrationFiles, "migrations/"+dialect)
+    if err != nil {
+        return nil, err
+    }
+    migrations, err := LoadMigrations(files)
+    if err != nil {
+        return nil, err
+    }
+    return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// LoadMigrations reads the NNNN_name.up.sql / NNNN_name.down.sql pairs in
+// the root of fsys, ordered by version.
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+    entries, err := fs.ReadDir(fsys, ".")
+    if err != nil {
+        return nil, fmt.Errorf("failed to read migrations: %w", err)
+    }
+
+    byVersion := make(map[int64]*Migration)
+    for _, entry := range entries {
+        match := migrationFileName.FindStringSubmatch(entry.Name())
+        if match == nil {
+            return nil, fmt.Errorf("unexpected migration file %s", entry.Name())
+        }
+        version, _ := strconv.ParseInt(match[1], 10, 64)
+        content, err := fs.ReadFile(fsys, entry.Name())
+        if err != nil {
+            return nil, err
+        }
+
+        migration, ok := byVersion[version]
+        if !ok {
+            migration = &Migration{Version: version, Name: match[2]}
+            byVersion[version] = migration
+        } else if migration.Name != match[2] {
+            return nil, fmt.Errorf("migration %d has two names: %s and %s", version, migration.Name, match[2])
+        }
+        if match[3] == "up" {
+            migration.Up = string(content)
+            sum := sha256.Sum256(content)
+            migration.Checksum = hex.EncodeToString(sum[:])
+        } else {
+            migration.Down = string(content)
+        }
+    }
+
+    migrations := make([]Migration, 0, len(byVersion))
+    for _, migration := range byVersion {
+        if migration.Checksum == "" {
+            return nil, fmt.Errorf("migration %d_%s has no up script", migration.Version, migration.Name)
+        }
+        migrations = append(migrations, *migration)
+    }
+    sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+    return migrations, nil
+}
+
+// Up applies every pending migration in version order, each in its own
+// transaction, and returns the ones applied. It refuses to run when an
+// applied migration has been edited since.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+    var applied []Migration
+    err := m.locked(ctx, func(conn *gorm.DB) error {
+        done, err := m.applied(conn)
+        if err != nil {
+            return err
+        }
+        for _, migration := range m.migrations {
+            if record, ok := done[migration.Version]; ok && record.Checksum != migration.Checksum {
+                return fmt.Errorf("migration %d_%s was modified after it was applied", migration.Version, migration.Name)
+            }
+        }
+
+        for _, migration := range m.migrations {
+            if _, ok := done[migration.Version]; ok {
+                continue
+            }
+            err := conn.Transaction(func(tx *gorm.DB) error {
+                if err := execScript(tx, migration.Up); err != nil {
+                    return err
+                }
+                return tx.Create(&schemaMigration{
+                    Version:   migration.Version,
+                    Name:      migration.Name,
+                    Checksum:  migration.Checksum,
+                    AppliedAt: time.Now(),
+                }).Error
+            })
+            if err != nil {
+                return fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+            }
+            applied = append(applied, migration)
+        }
+        return nil
+    })
+    return applied, err
+}
+
+// Down rolls back the last steps applied migrations, newest first, and
+// returns the ones rolled back.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+    if steps < 1 {
+        return nil, errors.New("steps must be at least 1")
+    }
+
+    known := make(map[int64]Migration, len(m.migrations))
+    for _, migration := range m.migrations {
+        known[migration.Version] = migration
+    }
+
+    var rolledBack []Migration
+    err := m.locked(ctx, func(conn *gorm.DB) error {
+        var records []schemaMigration
+        if err := conn.Order("version DESC").Limit(steps).Find(&records).Error; err != nil {
+            return err
+        }
+        for _, record := range records {
+            migration, ok := known[record.Version]
+            if !ok {
+                return fmt.Errorf("migration %d_%s is not part of this binary", record.Version, record.Name)
+            }
+            err := conn.Transaction(func(tx *gorm.DB) error {
+                if err := execScript(tx, migration.Down); err != nil {
+                    return err
+                }
+                return tx.Where("version = ?", migration.Version).Delete(&schemaMigration{}).Error
+            })
+            if err != nil {
+                return fmt.Errorf("rollback of %d_%s failed: %w", migration.Version, migration.Name, err)
+            }
+            rolledBack = append(rolledBack, migration)
+        }
+        return nil
+    })
+    return rolledBack, err
+}
+
+// Status lists every migration known to the binary or recorded in the
+// database, ordered by version.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+    conn := m.db.WithContext(ctx)
+    done := make(map[int64]schemaMigration)
+    if conn.Migrator().HasTable(&schemaMigration{}) {
+        var err error
+        if done, err = m.applied(conn); err != nil {
+            return nil, err
+        }
+    }
+
+    statuses := make([]MigrationStatus, 0, len(m.migrations))
+    for _, migration := range m.migrations {
+        status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+        if record, ok := done[migration.Version]; ok {
+            appliedAt := record.AppliedAt
+            status.AppliedAt = &appliedAt
+            status.Modified = record.Checksum != migration.Checksum
+            delete(done, migration.Version)
+        }
+        statuses = append(statuses, status)
+    }
+    for _, record := range done {
+        appliedAt := record.AppliedAt
+        statuses = append(statuses, MigrationStatus{
+            Version:   record.Version,
+            Name:      record.Name,
+            AppliedAt: &appliedAt,
+            Unknown:   true,
+        })
+    }
+    sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+    return statuses, nil
+}
+
+// Pending counts the migrations that have not been applied yet.
+func (m *Migrator) Pending(ctx context.Context) (int, error) {
+    statuses, err := m.Status(ctx)
+    if err != nil {
+        return 0, err
+    }
+    pending := 0
+    for _, status := range statuses {
+        if status.AppliedAt == nil {
+            pending++
+        }
+    }
+    return pending, nil
+}
+
+func (m *Migrator) applied(conn *gorm.DB) (map[int64]schemaMigration, error) {
+    var records []schemaMigration
+    if err := conn.Find(&records).Error; err != nil {
+        return nil, err
+    }
+    done := make(map[int64]schemaMigration, len(records))
+    for _, record := range records {
+        done[record.Version] = record
+    }
+    return done, nil
+}
+
+// locked runs fn on a single connection holding the migration advisory
+// lock, so instances starting together apply each migration only once.
+// SQLite serves a single instance and has no advisory locks.
+func (m *Migrator) locked(ctx context.Context, fn func(conn *gorm.DB) error) error {
+    return m.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+        timestampType := "timestamptz"
+        if IsSQLite(conn) {
+            timestampType = "datetime"
+        } else {
+            if err := conn.Exec("SELECT pg_advisory_lock(?)", migrationLockKey).Error; err != nil {
+                return fmt.Errorf("failed to acquire migration lock: %w", err)
+            }
+            defer conn.Exec("SELECT pg_advisory_unlock(?)", migrationLockKey)
+        }
+
+        if err := conn.Exec(fmt.Sprintf(createSchemaMigrations, timestampType)).Error; err != nil {
+            return err
+        }
+        return fn(conn)
+    })
+}
+
+// addColumnIfNotExists matches the ADD COLUMN IF NOT EXISTS statements
+// SQLite cannot run itself.
+var addColumnIfNotExists = regexp.MustCompile(`ALTER TABLE "(\w+)" ADD COLUMN IF NOT EXISTS "(\w+)"[^;]*;`)
+
+// execScript runs a migration script. Scripts holding nothing but comments
+// are skipped.
+func execScript(tx *gorm.DB, script string) error {
+    blank := true
+    for _, line := range strings.Split(script, "\n") {
+        line = strings.TrimSpace(line)
+        if line != "" && !strings.HasPrefix(line, "--") {
+            blank = false
+            break
+        }
+    }
+    if blank {
+        return nil
+    }
+    if IsSQLite(tx) {
+        script = resolveAddColumns(tx, script)
+    }
+    return tx.Exec(script).Error
+}
+
+// resolveAddColumns rewrites ADD COLUMN IF NOT EXISTS for SQLite against the
+// schema as it was before the script ran: the statement is dropped when the
+// column exists or the table does not (the script is then expected to
+// create it complete), and runs as a plain ADD COLUMN otherwise.
+func resolveAddColumns(tx *gorm.DB, script string) string {
+    migrator := tx.Migrator()
+    return addColumnIfNotExists.ReplaceAllStringFunc(script, func(stmt string) string {
+        match := addColumnIfNotExists.FindStringSubmatch(stmt)
+        table, column := match[1], match[2]
+        if !migrator.HasTable(table) || migrator.HasColumn(table, column) {
+            return ""
+        }
+        return strings.Replace(stmt, " IF NOT EXISTS", "", 1)
+    })
+}
+
+// CreateMigration writes an empty up/down pair for the next version to the
+// directory of every driver under dir and returns their paths.
+func CreateMigration(dir, name string) ([]string, error) {
+    name = strings.Trim(strings.ToLower(regexp.MustCompile(`[^A-Za-z0-9]+`).ReplaceAllString(name, "_")), "_")
+    if name == "" {
+        return nil, errors.New("migration name is required")
+    }
+
+    var version int64 = 1
+    for _, dialect := range migrationDialects {
+        migrations, err := LoadMigrations(os.DirFS(filepath.Join(dir, dialect)))
+        if err != nil {
+            return nil, err
+        }
+        if n := len(migrations); n > 0 && migrations[n-1].Version >= version {
+            version = migrations[n-1].Version + 1
+        }
+    }
+
+    var paths []string
+    for _, dialect := range migrationDialects {
+        base := filepath.Join(dir, dialect, fmt.Sprintf("%04d_%s", version, name))
+        up, down := base+".up.sql", base+".down.sql"
+        if err := os.WriteFile(up, []byte("-- "+name+"\n"), 0o644); err != nil {
+            return paths, err
+        }
+        if err := os.WriteFile(down, []byte("-- Undo "+name+"\n"), 0o644); err != nil {
+            return paths, err
+        }
+        paths = append(paths, up, down)
+    }
+    return paths, nil
+}
@@ -0,0 +1,253 @@
+package database_test
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"task-management/internal/database"
+	"task-management/internal/database/dbtest"
+
+	"gorm.io/gorm"
+)
+
+// tables lists the database's tables, leaving out SQLite's internal ones.
+func tables(t *testing.T, db *gorm.DB) []string {
+	t.Helper()
+
+	all, err := db.Migrator().GetTables()
+	if err != nil {
+		t.Fatalf("list tables: %v", err)
+	}
+	var names []string
+	for _, name := range all {
+		if !strings.HasPrefix(name, "sqlite_") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func pending(t *testing.T, m *database.Migrator) int {
+	t.Helper()
+
+	n, err := m.Pending(context.Background())
+	if err != nil {
+		t.Fatalf("Pending: %v", err)
+	}
+	return n
+}
+
+func TestMigratorUpDown(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	m, err := database.NewMigrator(db)
+	if err != nil {
+		t.Fatalf("NewMigrator: %v", err)
+	}
+
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if len(statuses) == 0 || pending(t, m) != 0 {
+		t.Fatalf("dbtest.New left %d of %d migrations pending", pending(t, m), len(statuses))
+	}
+	migrated := tables(t, db)
+
+	// Up is a no-op once everything is applied.
+	if applied, err := m.Up(ctx); err != nil || len(applied) != 0 {
+		t.Fatalf("Up on a migrated database applied %d migrations: %v", len(applied), err)
+	}
+
+	last := statuses[len(statuses)-1]
+	rolledBack, err := m.Down(ctx, 1)
+	if err != nil {
+		t.Fatalf("Down(1): %v", err)
+	}
+	if len(rolledBack) != 1 || rolledBack[0].Version != last.Version {
+		t.Fatalf("Down(1) rolled back %+v, want only %d_%s", rolledBack, last.Version, last.Name)
+	}
+	if pending(t, m) != 1 {
+		t.Fatalf("Down(1) left %d migrations pending, want 1", pending(t, m))
+	}
+
+	rolledBack, err = m.Down(ctx, len(statuses))
+	if err != nil {
+		t.Fatalf("Down(all): %v", err)
+	}
+	if len(rolledBack) != len(statuses)-1 {
+		t.Fatalf("Down(all) rolled back %d migrations, want %d", len(rolledBack), len(statuses)-1)
+	}
+	for i := 1; i < len(rolledBack); i++ {
+		if rolledBack[i-1].Version < rolledBack[i].Version {
+			t.Fatalf("Down rolled back %d before %d", rolledBack[i-1].Version, rolledBack[i].Version)
+		}
+	}
+	if got := tables(t, db); !reflect.DeepEqual(got, []string{"schema_migrations"}) {
+		t.Fatalf("tables after rolling everything back = %v, want only schema_migrations", got)
+	}
+
+	applied, err := m.Up(ctx)
+	if err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	if len(applied) != len(statuses) {
+		t.Fatalf("Up applied %d migrations, want %d", len(applied), len(statuses))
+	}
+	if got := tables(t, db); !reflect.DeepEqual(got, migrated) {
+		t.Fatalf("tables after Up = %v, want %v", got, migrated)
+	}
+}
+
+func TestMigratorRejectsModifiedMigration(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	m, err := database.NewMigrator(db)
+	if err != nil {
+		t.Fatalf("NewMigrator: %v", err)
+	}
+
+	if err := db.Exec("UPDATE schema_migrations SET checksum = ? WHERE version = 1", "edited").Error; err != nil {
+		t.Fatalf("edit checksum: %v", err)
+	}
+
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if !statuses[0].Modified {
+		t.Errorf("Status()[0].Modified = false, want true")
+	}
+	if _, err := m.Up(ctx); err == nil {
+		t.Fatal("Up succeeded with a modified migration, want an error")
+	}
+}
+
+// baselineSchema is what AutoMigrate created for the last release before
+// versioned migrations, when only users, todos and subtasks existed.
+const baselineSchema = `
+CREATE TABLE "users" (
+    "user_id" integer PRIMARY KEY AUTOINCREMENT,
+    "username" text NOT NULL,
+    "email" text NOT NULL,
+    "password_hash" text NOT NULL,
+    "password" text NOT NULL,
+    "full_name" text,
+    "created_at" datetime,
+    "updated_at" datetime,
+    "is_active" numeric DEFAULT true
+);
+CREATE UNIQUE INDEX "idx_users_email" ON "users" ("email");
+CREATE UNIQUE INDEX "idx_users_username" ON "users" ("username");
+
+CREATE TABLE "todos" (
+    "todo_id" integer PRIMARY KEY AUTOINCREMENT,
+    "public_id" integer,
+    "user_id" integer NOT NULL,
+    "category_id" integer,
+    "title" text NOT NULL,
+    "description" text,
+    "priority" varchar(50) DEFAULT 'medium',
+    "category" varchar(50) DEFAULT 'personal',
+    "status" varchar(50) DEFAULT 'todo',
+    "due_date" datetime,
+    "created_at" datetime,
+    "updated_at" datetime,
+    "deleted_at" datetime,
+    CONSTRAINT "fk_users_todos" FOREIGN KEY ("user_id") REFERENCES "users"("user_id")
+);
+CREATE INDEX "idx_todos_deleted_at" ON "todos" ("deleted_at");
+CREATE UNIQUE INDEX "idx_todos_public_id" ON "todos" ("public_id");
+
+CREATE TABLE "subtasks" (
+    "subtask_id" integer PRIMARY KEY AUTOINCREMENT,
+    "todo_id" integer NOT NULL,
+    "title" text NOT NULL,
+    "is_completed" varchar(10) DEFAULT 'no',
+    "completed_at" datetime,
+    "created_at" datetime,
+    "updated_at" datetime,
+    CONSTRAINT "fk_todos_subtasks" FOREIGN KEY ("todo_id") REFERENCES "todos"("todo_id")
+);
+
+INSERT INTO "users" ("username", "email", "password_hash", "password", "full_name", "created_at", "updated_at")
+VALUES ('alice', 'alice@example.com', 'hash', 'secret', 'Alice', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP);
+INSERT INTO "todos" ("public_id", "user_id", "title", "created_at", "updated_at")
+VALUES (1, 1, 'Ship it', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP);
+INSERT INTO "subtasks" ("todo_id", "title", "created_at", "updated_at")
+VALUES (1, 'Write notes', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP);
+`
+
+func TestMigratorUpgradesBaselineSchema(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.Open(t)
+	if err := db.Exec(baselineSchema).Error; err != nil {
+		t.Fatalf("create baseline schema: %v", err)
+	}
+
+	m, err := database.NewMigrator(db)
+	if err != nil {
+		t.Fatalf("NewMigrator: %v", err)
+	}
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+
+	// The upgraded database must have the same tables and columns as a
+	// fresh one.
+	fresh := dbtest.New(t)
+	if got, want := tables(t, db), tables(t, fresh); !reflect.DeepEqual(got, want) {
+		t.Fatalf("tables = %v, want %v", got, want)
+	}
+	for _, table := range tables(t, fresh) {
+		if got, want := columns(t, db, table), columns(t, fresh, table); !reflect.DeepEqual(got, want) {
+			t.Errorf("columns of %s = %v, want %v", table, got, want)
+		}
+	}
+
+	var todo struct {
+		WorkspaceID uint
+		Version     uint
+		Rank        string
+	}
+	if err := db.Raw(`SELECT workspace_id, version, "rank" FROM todos WHERE todo_id = 1`).Scan(&todo).Error; err != nil {
+		t.Fatalf("load todo: %v", err)
+	}
+	var personal uint
+	if err := db.Raw("SELECT workspace_id FROM workspaces WHERE owner_id = 1 AND personal = true").Scan(&personal).Error; err != nil {
+		t.Fatalf("load personal workspace: %v", err)
+	}
+	if personal == 0 || todo.WorkspaceID != personal {
+		t.Errorf("todo workspace_id = %d, want the personal workspace %d", todo.WorkspaceID, personal)
+	}
+	if todo.Version != 1 || todo.Rank != "" {
+		t.Errorf("todo version = %d, rank = %q, want 1 and \"\"", todo.Version, todo.Rank)
+	}
+
+	var subtasks int64
+	if err := db.Table("subtasks").Where("todo_id = 1 AND deleted_at IS NULL AND version = 1").Count(&subtasks).Error; err != nil {
+		t.Fatalf("count subtasks: %v", err)
+	}
+	if subtasks != 1 {
+		t.Errorf("found %d live subtasks of the todo, want 1", subtasks)
+	}
+}
+
+func columns(t *testing.T, db *gorm.DB, table string) []string {
+	t.Helper()
+
+	types, err := db.Migrator().ColumnTypes(table)
+	if err != nil {
+		t.Fatalf("list columns of %s: %v", table, err)
+	}
+	names := make([]string, len(types))
+	for i, column := range types {
+		names[i] = column.Name()
+	}
+	sort.Strings(names)
+	return names
+}
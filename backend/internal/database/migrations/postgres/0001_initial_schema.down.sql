@@ -0,0 +1,20 @@
+DROP TABLE IF EXISTS "subtask_rules";
+DROP TABLE IF EXISTS "todo_templates";
+DROP TABLE IF EXISTS "todo_assignees";
+DROP TABLE IF EXISTS "time_entries";
+DROP TABLE IF EXISTS "todo_dependencies";
+DROP TABLE IF EXISTS "calendar_feeds";
+DROP TABLE IF EXISTS "attachments";
+DROP TABLE IF EXISTS "notifications";
+DROP TABLE IF EXISTS "comment_revisions";
+DROP TABLE IF EXISTS "comments";
+DROP TABLE IF EXISTS "todo_revisions";
+DROP TABLE IF EXISTS "todo_events";
+DROP TABLE IF EXISTS "webhook_deliveries";
+DROP TABLE IF EXISTS "webhooks";
+DROP TABLE IF EXISTS "subtasks";
+DROP TABLE IF EXISTS "todos";
+DROP TABLE IF EXISTS "workspace_invitations";
+DROP TABLE IF EXISTS "workspace_members";
+DROP TABLE IF EXISTS "workspaces";
+DROP TABLE IF EXISTS "users";
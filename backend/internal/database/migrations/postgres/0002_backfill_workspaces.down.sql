@@ -0,0 +1,2 @@
+-- The backfilled workspaces are indistinguishable from ones created later,
+-- so there is nothing to undo.
@@ -0,0 +1,24 @@
+-- Give every user a personal workspace and move todos created before
+-- workspaces existed into their owner's personal workspace.
+
+INSERT INTO workspaces (name, personal, owner_id, created_at, updated_at)
+SELECT 'Personal', true, u.user_id, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+FROM users u
+WHERE NOT EXISTS (
+    SELECT 1 FROM workspaces w
+    WHERE w.owner_id = u.user_id AND w.personal = true AND w.deleted_at IS NULL
+);
+
+INSERT INTO workspace_members (workspace_id, user_id, role, created_at, updated_at)
+SELECT w.workspace_id, w.owner_id, 'owner', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+FROM workspaces w
+WHERE w.deleted_at IS NULL AND NOT EXISTS (
+    SELECT 1 FROM workspace_members m
+    WHERE m.workspace_id = w.workspace_id AND m.user_id = w.owner_id
+);
+
+UPDATE todos SET workspace_id = (
+    SELECT w.workspace_id FROM workspaces w
+    WHERE w.owner_id = todos.user_id AND w.personal = true AND w.deleted_at IS NULL
+)
+WHERE workspace_id IS NULL OR workspace_id = 0;
@@ -0,0 +1,11 @@
+ALTER TABLE "users" ADD COLUMN "tokens_revoked_at" timestamptz;
+
+CREATE TABLE "revoked_tokens" (
+    "token_id" varchar(64),
+    "user_id" bigint NOT NULL,
+    "expires_at" timestamptz NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("token_id")
+);
+CREATE INDEX "idx_revoked_tokens_user_id" ON "revoked_tokens" ("user_id");
+CREATE INDEX "idx_revoked_tokens_expires_at" ON "revoked_tokens" ("expires_at");
@@ -0,0 +1,11 @@
+ALTER TABLE "todo_events" ADD COLUMN "workspace_id" bigint NOT NULL DEFAULT 0;
+
+-- Earlier events belong to the workspace of the todo they describe: todo
+-- payloads carry it, subtask payloads name their todo.
+UPDATE "todo_events" SET "workspace_id" = COALESCE(
+    ("payload"::jsonb ->> 'workspace_id')::bigint,
+    (SELECT "workspace_id" FROM "todos" WHERE "todo_id" = ("todo_events"."payload"::jsonb ->> 'todo_id')::bigint),
+    0
+);
+
+CREATE INDEX "idx_todo_events_workspace_id" ON "todo_events" ("workspace_id");
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "idx_todo_events_todo_id";
+ALTER TABLE "todo_events" DROP COLUMN IF EXISTS "todo_id";
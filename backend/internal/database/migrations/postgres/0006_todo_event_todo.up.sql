@@ -0,0 +1,9 @@
+ALTER TABLE "todo_events" ADD COLUMN "todo_id" bigint;
+
+-- Todo payloads are the todo itself, subtask payloads name their todo.
+UPDATE "todo_events" SET "todo_id" = CASE
+    WHEN "type" LIKE 'todo.%' THEN ("payload"::jsonb ->> 'id')::bigint
+    ELSE ("payload"::jsonb ->> 'todo_id')::bigint
+END;
+
+CREATE INDEX "idx_todo_events_todo_id" ON "todo_events" ("todo_id");
@@ -0,0 +1,294 @@
+-- Initial schema, matching the Postgres migration of the same version.
+-- todos.public_id is a second sequence in Postgres; here a trigger numbers
+-- the rows after insert.
+-- SQLite has no ADD COLUMN IF NOT EXISTS, so the migrator drops those
+-- statements when the table is missing (the CREATE TABLE before them makes
+-- it complete) or already has the column.
+
+CREATE TABLE IF NOT EXISTS "users" (
+    "user_id" integer PRIMARY KEY AUTOINCREMENT,
+    "username" text NOT NULL,
+    "email" text NOT NULL,
+    "password_hash" text NOT NULL,
+    "password" text NOT NULL,
+    "full_name" text,
+    "created_at" datetime,
+    "updated_at" datetime,
+    "is_active" numeric DEFAULT true,
+    "current_workspace_id" integer
+);
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "current_workspace_id" integer;
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_users_email" ON "users" ("email");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_users_username" ON "users" ("username");
+
+CREATE TABLE IF NOT EXISTS "workspaces" (
+    "workspace_id" integer PRIMARY KEY AUTOINCREMENT,
+    "name" text NOT NULL,
+    "personal" numeric NOT NULL DEFAULT false,
+    "owner_id" integer NOT NULL,
+    "created_at" datetime,
+    "updated_at" datetime,
+    "deleted_at" datetime
+);
+CREATE INDEX IF NOT EXISTS "idx_workspaces_deleted_at" ON "workspaces" ("deleted_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_workspaces_personal" ON "workspaces" ("owner_id") WHERE personal = true;
+CREATE INDEX IF NOT EXISTS "idx_workspaces_owner_id" ON "workspaces" ("owner_id");
+
+CREATE TABLE IF NOT EXISTS "workspace_members" (
+    "workspace_id" integer,
+    "user_id" integer,
+    "role" varchar(20) NOT NULL,
+    "created_at" datetime,
+    "updated_at" datetime,
+    PRIMARY KEY ("workspace_id","user_id"),
+    CONSTRAINT "fk_workspace_members_user" FOREIGN KEY ("user_id") REFERENCES "users"("user_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_workspace_members_user_id" ON "workspace_members" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "workspace_invitations" (
+    "workspace_invitation_id" integer PRIMARY KEY AUTOINCREMENT,
+    "workspace_id" integer NOT NULL,
+    "inviter_id" integer NOT NULL,
+    "invitee_id" integer,
+    "email" text,
+    "role" varchar(20) NOT NULL,
+    "created_at" datetime,
+    CONSTRAINT "fk_workspace_invitations_workspace" FOREIGN KEY ("workspace_id") REFERENCES "workspaces"("workspace_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_workspace_invitations_email" ON "workspace_invitations" ("email");
+CREATE INDEX IF NOT EXISTS "idx_workspace_invitations_invitee_id" ON "workspace_invitations" ("invitee_id");
+CREATE INDEX IF NOT EXISTS "idx_workspace_invitations_workspace_id" ON "workspace_invitations" ("workspace_id");
+
+CREATE TABLE IF NOT EXISTS "todos" (
+    "todo_id" integer PRIMARY KEY AUTOINCREMENT,
+    "public_id" integer,
+    "user_id" integer NOT NULL,
+    "workspace_id" integer,
+    "category_id" integer,
+    "title" text NOT NULL,
+    "description" text,
+    "priority" varchar(50) DEFAULT 'medium',
+    "category" varchar(50) DEFAULT 'personal',
+    "status" varchar(50) DEFAULT 'todo',
+    "due_date" datetime,
+    "estimate" integer,
+    "started_at" datetime,
+    "completed_at" datetime,
+    "rank" varchar(255) NOT NULL DEFAULT '',
+    "version" integer NOT NULL DEFAULT 1,
+    "created_at" datetime,
+    "updated_at" datetime,
+    "deleted_at" datetime,
+    CONSTRAINT "fk_users_todos" FOREIGN KEY ("user_id") REFERENCES "users"("user_id")
+);
+ALTER TABLE "todos" ADD COLUMN IF NOT EXISTS "workspace_id" integer;
+ALTER TABLE "todos" ADD COLUMN IF NOT EXISTS "estimate" integer;
+ALTER TABLE "todos" ADD COLUMN IF NOT EXISTS "started_at" datetime;
+ALTER TABLE "todos" ADD COLUMN IF NOT EXISTS "completed_at" datetime;
+ALTER TABLE "todos" ADD COLUMN IF NOT EXISTS "rank" varchar(255) NOT NULL DEFAULT '';
+ALTER TABLE "todos" ADD COLUMN IF NOT EXISTS "version" integer NOT NULL DEFAULT 1;
+CREATE INDEX IF NOT EXISTS "idx_todos_deleted_at" ON "todos" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_todos_workspace_board" ON "todos" ("workspace_id","status","rank");
+CREATE INDEX IF NOT EXISTS "idx_todos_workspace_id" ON "todos" ("workspace_id");
+CREATE INDEX IF NOT EXISTS "idx_todos_user_id" ON "todos" ("user_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_todos_public_id" ON "todos" ("public_id");
+
+CREATE TABLE IF NOT EXISTS "subtasks" (
+    "subtask_id" integer PRIMARY KEY AUTOINCREMENT,
+    "todo_id" integer NOT NULL,
+    "title" text NOT NULL,
+    "is_completed" varchar(10) DEFAULT 'no',
+    "completed_at" datetime,
+    "version" integer NOT NULL DEFAULT 1,
+    "created_at" datetime,
+    "updated_at" datetime,
+    "deleted_at" datetime,
+    CONSTRAINT "fk_todos_subtasks" FOREIGN KEY ("todo_id") REFERENCES "todos"("todo_id")
+);
+ALTER TABLE "subtasks" ADD COLUMN IF NOT EXISTS "version" integer NOT NULL DEFAULT 1;
+ALTER TABLE "subtasks" ADD COLUMN IF NOT EXISTS "deleted_at" datetime;
+CREATE INDEX IF NOT EXISTS "idx_subtasks_deleted_at" ON "subtasks" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "webhooks" (
+    "webhook_id" integer PRIMARY KEY AUTOINCREMENT,
+    "user_id" integer NOT NULL,
+    "url" text NOT NULL,
+    "secret" text NOT NULL,
+    "events" text,
+    "is_active" numeric DEFAULT true,
+    "created_at" datetime,
+    "updated_at" datetime,
+    CONSTRAINT "fk_webhooks_user" FOREIGN KEY ("user_id") REFERENCES "users"("user_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_webhooks_user_id" ON "webhooks" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "webhook_deliveries" (
+    "delivery_id" integer PRIMARY KEY AUTOINCREMENT,
+    "webhook_id" integer NOT NULL,
+    "delivery_uuid" varchar(64),
+    "event" varchar(50),
+    "payload" text,
+    "attempt" integer,
+    "status_code" integer,
+    "response_body" text,
+    "error" text,
+    "success" numeric,
+    "duration_ms" integer,
+    "created_at" datetime
+);
+CREATE INDEX IF NOT EXISTS "idx_webhook_deliveries_delivery_id" ON "webhook_deliveries" ("delivery_uuid");
+CREATE INDEX IF NOT EXISTS "idx_webhook_deliveries_webhook_id" ON "webhook_deliveries" ("webhook_id");
+
+CREATE TABLE IF NOT EXISTS "todo_events" (
+    "event_id" integer PRIMARY KEY AUTOINCREMENT,
+    "user_id" integer NOT NULL,
+    "type" varchar(50) NOT NULL,
+    "payload" text,
+    "created_at" datetime
+);
+CREATE INDEX IF NOT EXISTS "idx_todo_events_created_at" ON "todo_events" ("created_at");
+CREATE INDEX IF NOT EXISTS "idx_todo_events_user_id" ON "todo_events" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "todo_revisions" (
+    "revision_id" integer PRIMARY KEY AUTOINCREMENT,
+    "todo_id" integer NOT NULL,
+    "revision" integer NOT NULL,
+    "user_id" integer NOT NULL,
+    "action" varchar(20) NOT NULL,
+    "changes" text,
+    "snapshot" text,
+    "reverted_from" integer,
+    "created_at" datetime
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_todo_revision" ON "todo_revisions" ("todo_id","revision");
+
+CREATE TABLE IF NOT EXISTS "comments" (
+    "comment_id" integer PRIMARY KEY AUTOINCREMENT,
+    "todo_id" integer NOT NULL,
+    "user_id" integer NOT NULL,
+    "body" text NOT NULL,
+    "mentions" text,
+    "edited_at" datetime,
+    "created_at" datetime,
+    "updated_at" datetime,
+    "deleted_at" datetime
+);
+CREATE INDEX IF NOT EXISTS "idx_comments_todo_id" ON "comments" ("todo_id");
+CREATE INDEX IF NOT EXISTS "idx_comments_deleted_at" ON "comments" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "comment_revisions" (
+    "comment_revision_id" integer PRIMARY KEY AUTOINCREMENT,
+    "comment_id" integer NOT NULL,
+    "body" text,
+    "created_at" datetime
+);
+CREATE INDEX IF NOT EXISTS "idx_comment_revisions_comment_id" ON "comment_revisions" ("comment_id");
+
+CREATE TABLE IF NOT EXISTS "notifications" (
+    "notification_id" integer PRIMARY KEY AUTOINCREMENT,
+    "user_id" integer NOT NULL,
+    "actor_id" integer,
+    "type" varchar(50) NOT NULL,
+    "todo_id" integer,
+    "comment_id" integer,
+    "message" text,
+    "read_at" datetime,
+    "created_at" datetime
+);
+CREATE INDEX IF NOT EXISTS "idx_notifications_user_id" ON "notifications" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "attachments" (
+    "attachment_id" integer PRIMARY KEY AUTOINCREMENT,
+    "todo_id" integer NOT NULL,
+    "user_id" integer NOT NULL,
+    "file_name" text NOT NULL,
+    "content_type" varchar(255),
+    "size" integer,
+    "checksum" varchar(64),
+    "storage_key" text NOT NULL,
+    "created_at" datetime
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_attachments_storage_key" ON "attachments" ("storage_key");
+CREATE INDEX IF NOT EXISTS "idx_attachments_user_id" ON "attachments" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_attachments_todo_id" ON "attachments" ("todo_id");
+
+CREATE TABLE IF NOT EXISTS "calendar_feeds" (
+    "calendar_feed_id" integer PRIMARY KEY AUTOINCREMENT,
+    "user_id" integer NOT NULL,
+    "token_hash" varchar(64) NOT NULL,
+    "created_at" datetime,
+    "updated_at" datetime
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_calendar_feeds_token_hash" ON "calendar_feeds" ("token_hash");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_calendar_feeds_user_id" ON "calendar_feeds" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "todo_dependencies" (
+    "todo_id" integer,
+    "blocker_id" integer,
+    "created_at" datetime,
+    PRIMARY KEY ("todo_id","blocker_id"),
+    CONSTRAINT "fk_todo_dependencies_todo" FOREIGN KEY ("todo_id") REFERENCES "todos"("todo_id"),
+    CONSTRAINT "fk_todo_dependencies_blocker" FOREIGN KEY ("blocker_id") REFERENCES "todos"("todo_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_todo_dependencies_blocker_id" ON "todo_dependencies" ("blocker_id");
+
+CREATE TABLE IF NOT EXISTS "time_entries" (
+    "time_entry_id" integer PRIMARY KEY AUTOINCREMENT,
+    "todo_id" integer NOT NULL,
+    "user_id" integer NOT NULL,
+    "note" text,
+    "started_at" datetime NOT NULL,
+    "ended_at" datetime,
+    "duration" integer NOT NULL DEFAULT 0,
+    "created_at" datetime,
+    "updated_at" datetime
+);
+CREATE INDEX IF NOT EXISTS "idx_time_entries_started_at" ON "time_entries" ("started_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_time_entries_running" ON "time_entries" ("user_id") WHERE ended_at IS NULL;
+CREATE INDEX IF NOT EXISTS "idx_time_entries_user_id" ON "time_entries" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_time_entries_todo_id" ON "time_entries" ("todo_id");
+
+CREATE TABLE IF NOT EXISTS "todo_assignees" (
+    "todo_id" integer,
+    "user_id" integer,
+    "assigned_by" integer NOT NULL,
+    "created_at" datetime,
+    PRIMARY KEY ("todo_id","user_id"),
+    CONSTRAINT "fk_todos_assignees" FOREIGN KEY ("todo_id") REFERENCES "todos"("todo_id"),
+    CONSTRAINT "fk_todo_assignees_user" FOREIGN KEY ("user_id") REFERENCES "users"("user_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_todo_assignees_user_id" ON "todo_assignees" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "todo_templates" (
+    "todo_template_id" integer PRIMARY KEY AUTOINCREMENT,
+    "workspace_id" integer NOT NULL,
+    "user_id" integer NOT NULL,
+    "name" text NOT NULL,
+    "title" text NOT NULL,
+    "description" text,
+    "priority" varchar(50) DEFAULT 'medium',
+    "category" varchar(50) DEFAULT 'personal',
+    "due_offset" integer,
+    "estimate" integer,
+    "subtasks" text,
+    "created_at" datetime,
+    "updated_at" datetime,
+    "deleted_at" datetime
+);
+CREATE INDEX IF NOT EXISTS "idx_todo_templates_deleted_at" ON "todo_templates" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_todo_templates_workspace_id" ON "todo_templates" ("workspace_id");
+
+CREATE TABLE IF NOT EXISTS "subtask_rules" (
+    "user_id" integer,
+    "auto_complete" numeric NOT NULL DEFAULT false,
+    "auto_reopen" numeric NOT NULL DEFAULT false,
+    "updated_at" datetime,
+    PRIMARY KEY ("user_id")
+);
+
+CREATE TRIGGER IF NOT EXISTS "trg_todos_public_id" AFTER INSERT ON "todos"
+WHEN NEW."public_id" IS NULL
+BEGIN
+    UPDATE "todos" SET "public_id" = (SELECT COALESCE(MAX("public_id"), 0) + 1 FROM "todos")
+    WHERE "todo_id" = NEW."todo_id";
+END;
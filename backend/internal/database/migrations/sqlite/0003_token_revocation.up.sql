@@ -0,0 +1,10 @@
+ALTER TABLE "users" ADD COLUMN "tokens_revoked_at" datetime;
+
+CREATE TABLE "revoked_tokens" (
+    "token_id" varchar(64) PRIMARY KEY,
+    "user_id" integer NOT NULL,
+    "expires_at" datetime NOT NULL,
+    "created_at" datetime
+);
+CREATE INDEX "idx_revoked_tokens_user_id" ON "revoked_tokens" ("user_id");
+CREATE INDEX "idx_revoked_tokens_expires_at" ON "revoked_tokens" ("expires_at");
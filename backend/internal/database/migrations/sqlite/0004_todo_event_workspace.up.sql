@@ -0,0 +1,11 @@
+ALTER TABLE "todo_events" ADD COLUMN "workspace_id" integer NOT NULL DEFAULT 0;
+
+-- Earlier events belong to the workspace of the todo they describe: todo
+-- payloads carry it, subtask payloads name their todo.
+UPDATE "todo_events" SET "workspace_id" = COALESCE(
+    json_extract("payload", '$.workspace_id'),
+    (SELECT "workspace_id" FROM "todos" WHERE "todo_id" = json_extract("todo_events"."payload", '$.todo_id')),
+    0
+);
+
+CREATE INDEX "idx_todo_events_workspace_id" ON "todo_events" ("workspace_id");
@@ -0,0 +1,2 @@
+-- The plaintext passwords are gone for good; the column comes back empty.
+ALTER TABLE "users" ADD COLUMN "password" text NOT NULL DEFAULT '';
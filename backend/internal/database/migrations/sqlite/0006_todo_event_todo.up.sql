@@ -0,0 +1,9 @@
+ALTER TABLE "todo_events" ADD COLUMN "todo_id" integer;
+
+-- Todo payloads are the todo itself, subtask payloads name their todo.
+UPDATE "todo_events" SET "todo_id" = CASE
+    WHEN "type" LIKE 'todo.%' THEN json_extract("payload", '$.id')
+    ELSE json_extract("payload", '$.todo_id')
+END;
+
+CREATE INDEX "idx_todo_events_todo_id" ON "todo_events" ("todo_id");
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"task-management/internal/services"
+	"task-management/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AssigneeHandler struct {
+	assigneeService services.AssigneeService
+}
+
+type AssignRequest struct {
+	UserID uint `json:"user_id" binding:"required" example:"2"`
+}
+
+func NewAssigneeHandler(assigneeService services.AssigneeService) *AssigneeHandler {
+	return &AssigneeHandler{
+		assigneeService: assigneeService,
+	}
+}
+
+// GetAssignees godoc
+// @Summary List assignees of a todo
+// @Tags Assignees
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Success 200 {object} TodoResponse "Assignees retrieved successfully"
+// @Failure 400 {object} TodoResponse "Invalid todo ID"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 404 {object} TodoResponse "Todo not found"
+// @Router /todos/{id}/assignees [get]
+func (h *AssigneeHandler) GetAssignees(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid todo ID")
+		return
+	}
+
+	assignees, err := h.assigneeService.GetAssignees(uint(todoID), userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, assigneeErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Assignees retrieved successfully", assignees)
+}
+
+// AssignTodo godoc
+// @Summary Assign a todo
+// @Description Assign a todo to a member of its workspace. The assignee is notified unless they assigned themselves.
+// @Tags Assignees
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Param request body AssignRequest true "Assign Request"
+// @Success 200 {object} TodoResponse "Todo assigned successfully"
+// @Failure 400 {object} TodoResponse "Invalid request or todo ID"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 403 {object} TodoResponse "Workspace guests cannot change todos"
+// @Failure 404 {object} TodoResponse "Todo not found"
+// @Failure 409 {object} TodoResponse "User is already assigned"
+// @Failure 422 {object} TodoResponse "The user is not a member of the todo's workspace"
+// @Router /todos/{id}/assignees [post]
+func (h *AssigneeHandler) AssignTodo(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid todo ID")
+		return
+	}
+
+	var req AssignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	assignee, err := h.assigneeService.Assign(uint(todoID), req.UserID, userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, assigneeErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Todo assigned successfully", assignee)
+}
+
+// UnassignTodo godoc
+// @Summary Unassign a todo
+// @Description Remove an assignee from a todo and notify them. Anyone who can see the todo may unassign themselves.
+// @Tags Assignees
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Param userId path int true "User ID of the assignee"
+// @Success 200 {object} TodoResponse "Todo unassigned successfully"
+// @Failure 400 {object} TodoResponse "Invalid todo or user ID"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 403 {object} TodoResponse "Workspace guests cannot change todos"
+// @Failure 404 {object} TodoResponse "Todo or assignee not found"
+// @Router /todos/{id}/assignees/{userId} [delete]
+func (h *AssigneeHandler) UnassignTodo(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid todo ID")
+		return
+	}
+
+	assigneeID, err := strconv.ParseUint(c.Param("userId"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid user ID")
+		return
+	}
+
+	if err := h.assigneeService.Unassign(uint(todoID), uint(assigneeID), userID.(uint)); err != nil {
+		utils.ErrorResponse(c, assigneeErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Todo unassigned successfully", nil)
+}
+
+func assigneeErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrWorkspaceForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrInvalidValue):
+		return http.StatusUnprocessableEntity
+	case err.Error() == "user is already assigned":
+		return http.StatusConflict
+	case strings.HasSuffix(err.Error(), "not found"):
+		return http.StatusNotFound
+	}
+	return http.StatusBadRequest
+}
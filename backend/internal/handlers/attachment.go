@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"task-management/internal/services"
+	"task-management/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead is the room left for multipart headers and boundaries on
+// top of the maximum file size.
+const multipartOverhead = 1 << 20
+
+type AttachmentHandler struct {
+	attachmentService services.AttachmentService
+	maxFileSize       int64
+}
+
+func NewAttachmentHandler(attachmentService services.AttachmentService, maxFileSize int64) *AttachmentHandler {
+	return &AttachmentHandler{
+		attachmentService: attachmentService,
+		maxFileSize:       maxFileSize,
+	}
+}
+
+// GetAttachments godoc
+// @Summary List attachments of a todo
+// @Tags Attachments
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Success 200 {object} TodoResponse "Attachments retrieved successfully"
+// @Failure 400 {object} TodoResponse "Invalid request or todo ID"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Router /todos/{id}/attachments [get]
+func (h *AttachmentHandler) GetAttachments(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid todo ID")
+		return
+	}
+
+	attachments, err := h.attachmentService.GetAttachments(uint(todoID), userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Attachments retrieved successfully", attachments)
+}
+
+// UploadAttachment godoc
+// @Summary Upload an attachment
+// @Description Attach a file to a todo. The content type is detected from the file content.
+// @Tags Attachments
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Param file formData file true "File to upload"
+// @Success 200 {object} TodoResponse "Attachment uploaded successfully"
+// @Failure 400 {object} TodoResponse "Invalid request, file too large or quota exceeded"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 403 {object} TodoResponse "Workspace guests cannot change todos"
+// @Router /todos/{id}/attachments [post]
+func (h *AttachmentHandler) UploadAttachment(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid todo ID")
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxFileSize+multipartOverhead)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		utils.ValidationErrorResponse(c, "A file is required and must not exceed the maximum size")
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Failed to read uploaded file")
+		return
+	}
+	defer file.Close()
+
+	attachment, err := h.attachmentService.Upload(c.Request.Context(), uint(todoID), userID.(uint), fileHeader.Filename, file, fileHeader.Size)
+	if err != nil {
+		utils.ErrorResponse(c, todoErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Attachment uploaded successfully", attachment)
+}
+
+// DownloadAttachment godoc
+// @Summary Download an attachment
+// @Description Download the file. It is always served with Content-Disposition: attachment.
+// @Tags Attachments
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Param attachmentId path int true "Attachment ID"
+// @Success 200 {file} file "Attachment content"
+// @Failure 400 {object} TodoResponse "Invalid request"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 404 {object} TodoResponse "Attachment not found"
+// @Router /todos/{id}/attachments/{attachmentId} [get]
+func (h *AttachmentHandler) DownloadAttachment(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	todoID, attachmentID, ok := parseAttachmentParams(c)
+	if !ok {
+		return
+	}
+
+	attachment, blob, err := h.attachmentService.Open(c.Request.Context(), todoID, attachmentID, userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		return
+	}
+	defer blob.Close()
+
+	c.Header("Content-Type", attachment.ContentType)
+	c.Header("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Content-Security-Policy", "default-src 'none'; sandbox")
+	c.Status(http.StatusOK)
+
+	io.Copy(c.Writer, blob)
+}
+
+// DeleteAttachment godoc
+// @Summary Delete an attachment
+// @Tags Attachments
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Param attachmentId path int true "Attachment ID"
+// @Success 200 {object} TodoResponse "Attachment deleted successfully"
+// @Failure 400 {object} TodoResponse "Invalid request"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 403 {object} TodoResponse "Workspace guests cannot change todos"
+// @Router /todos/{id}/attachments/{attachmentId} [delete]
+func (h *AttachmentHandler) DeleteAttachment(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	todoID, attachmentID, ok := parseAttachmentParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.attachmentService.DeleteAttachment(c.Request.Context(), todoID, attachmentID, userID.(uint)); err != nil {
+		utils.ErrorResponse(c, todoErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Attachment deleted successfully", nil)
+}
+
+func parseAttachmentParams(c *gin.Context) (uint, uint, bool) {
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid todo ID")
+		return 0, 0, false
+	}
+
+	attachmentID, err := strconv.ParseUint(c.Param("attachmentId"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid attachment ID")
+		return 0, 0, false
+	}
+
+	return uint(todoID), uint(attachmentID), true
+}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"task-management/internal/services"
+	"task-management/internal/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CalendarHandler struct {
+	calendarService services.CalendarService
+}
+
+type CalendarFeedResponse struct {
+	URL       string    `json:"url,omitempty" example:"https://api.example.com/api/v1/calendar/3f2a.../todos.ics"`
+	Token     string    `json:"token,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewCalendarHandler(calendarService services.CalendarService) *CalendarHandler {
+	return &CalendarHandler{
+		calendarService: calendarService,
+	}
+}
+
+// GetCalendarFeed godoc
+// @Summary Get the calendar feed
+// @Description Show whether the authenticated user has an iCalendar feed. The secret URL is only returned when the token is (re)generated.
+// @Tags Calendar
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} TodoResponse "Calendar feed retrieved successfully"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 404 {object} TodoResponse "Calendar feed not found"
+// @Router /calendar/feed [get]
+func (h *CalendarHandler) GetCalendarFeed(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	feed, err := h.calendarService.GetFeed(userID.(uint))
+	if err != nil {
+		if err.Error() == "calendar feed not found" {
+			utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Calendar feed retrieved successfully", CalendarFeedResponse{
+		CreatedAt: feed.CreatedAt,
+		UpdatedAt: feed.UpdatedAt,
+	})
+}
+
+// RegenerateCalendarToken godoc
+// @Summary Create or regenerate the calendar feed URL
+// @Description Issue a new secret token for the authenticated user's iCalendar feed and return the subscription URL. URLs built from the previous token stop working.
+// @Tags Calendar
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} TodoResponse "Calendar feed token generated successfully"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 500 {object} TodoResponse "Internal server error"
+// @Router /calendar/feed [post]
+func (h *CalendarHandler) RegenerateCalendarToken(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	token, feed, err := h.calendarService.RegenerateToken(userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Calendar feed token generated successfully", CalendarFeedResponse{
+		URL:       calendarFeedURL(c, token),
+		Token:     token,
+		CreatedAt: feed.CreatedAt,
+		UpdatedAt: feed.UpdatedAt,
+	})
+}
+
+// RevokeCalendarFeed godoc
+// @Summary Revoke the calendar feed
+// @Description Delete the authenticated user's iCalendar feed so its URL stops working
+// @Tags Calendar
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} TodoResponse "Calendar feed revoked successfully"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 404 {object} TodoResponse "Calendar feed not found"
+// @Router /calendar/feed [delete]
+func (h *CalendarHandler) RevokeCalendarFeed(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	if err := h.calendarService.RevokeFeed(userID.(uint)); err != nil {
+		if err.Error() == "calendar feed not found" {
+			utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Calendar feed revoked successfully", nil)
+}
+
+// CalendarFeed godoc
+// @Summary iCalendar feed
+// @Description Subscribe to a user's todos from a calendar app. The secret token in the URL authenticates the request. Todos are rendered as VTODO; with events=true todos with a due date are also rendered as VEVENT.
+// @Tags Calendar
+// @Produce text/calendar
+// @Param token path string true "Calendar feed token"
+// @Param events query bool false "Also render due dates as events" default(false)
+// @Success 200 {file} file "iCalendar feed"
+// @Failure 404 {object} TodoResponse "Calendar feed not found"
+// @Router /calendar/{token}/todos.ics [get]
+func (h *CalendarHandler) CalendarFeed(c *gin.Context) {
+	userID, err := h.calendarService.FeedOwner(c.Param("token"))
+	if err != nil {
+		if err.Error() == "calendar feed not found" {
+			utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	includeEvents := c.Query("events") == "true"
+
+	c.Header("Content-Type", "text/calendar; charset=utf-8")
+	c.Header("Content-Disposition", `inline; filename="todos.ics"`)
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Header("Referrer-Policy", "no-referrer")
+	c.Status(http.StatusOK)
+
+	// Headers are already sent, so a failure can only be logged.
+	if err := h.calendarService.WriteFeed(userID, includeEvents, c.Writer); err != nil {
+		log.Printf("calendar: failed to render feed for user %d: %v", userID, err)
+	}
+}
+
+func calendarFeedURL(c *gin.Context, token string) string {
+	scheme := "http"
+	if c.Request.TLS != nil || strings.EqualFold(c.GetHeader("X-Forwarded-Proto"), "https") {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s/api/v1/calendar/%s/todos.ics", scheme, c.Request.Host, token)
+}
@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"task-management/internal/services"
+	"task-management/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CommentHandler struct {
+	commentService services.CommentService
+}
+
+type CommentRequest struct {
+	Body string `json:"body" binding:"required" example:"Looks good, @johndoe can you **review**?"`
+}
+
+func NewCommentHandler(commentService services.CommentService) *CommentHandler {
+	return &CommentHandler{
+		commentService: commentService,
+	}
+}
+
+// GetComments godoc
+// @Summary List comments of a todo
+// @Tags Comments
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Success 200 {object} TodoResponse "Comments retrieved successfully"
+// @Failure 400 {object} TodoResponse "Invalid request or todo ID"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Router /todos/{id}/comments [get]
+func (h *CommentHandler) GetComments(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid todo ID")
+		return
+	}
+
+	comments, err := h.commentService.GetComments(uint(todoID), userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Comments retrieved successfully", comments)
+}
+
+// CreateComment godoc
+// @Summary Comment on a todo
+// @Description Add a markdown comment. Every @username mentioned who is a member of the todo's workspace receives a notification.
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Param request body CommentRequest true "Comment Request"
+// @Success 200 {object} TodoResponse "Comment created successfully"
+// @Failure 400 {object} TodoResponse "Invalid request or todo ID"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Router /todos/{id}/comments [post]
+func (h *CommentHandler) CreateComment(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid todo ID")
+		return
+	}
+
+	var req CommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	comment, err := h.commentService.CreateComment(uint(todoID), userID.(uint), req.Body)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Comment created successfully", comment)
+}
+
+// UpdateComment godoc
+// @Summary Edit a comment
+// @Description Only the author can edit a comment. The previous body is kept in the comment history.
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Param commentId path int true "Comment ID"
+// @Param request body CommentRequest true "Comment Request"
+// @Success 200 {object} TodoResponse "Comment updated successfully"
+// @Failure 400 {object} TodoResponse "Invalid request"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Router /todos/{id}/comments/{commentId} [put]
+func (h *CommentHandler) UpdateComment(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	todoID, commentID, ok := parseCommentParams(c)
+	if !ok {
+		return
+	}
+
+	var req CommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	comment, err := h.commentService.UpdateComment(todoID, commentID, userID.(uint), req.Body)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Comment updated successfully", comment)
+}
+
+// DeleteComment godoc
+// @Summary Delete a comment
+// @Description Only the author can delete a comment
+// @Tags Comments
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Param commentId path int true "Comment ID"
+// @Success 200 {object} TodoResponse "Comment deleted successfully"
+// @Failure 400 {object} TodoResponse "Invalid request"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Router /todos/{id}/comments/{commentId} [delete]
+func (h *CommentHandler) DeleteComment(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	todoID, commentID, ok := parseCommentParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.commentService.DeleteComment(todoID, commentID, userID.(uint)); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Comment deleted successfully", nil)
+}
+
+// GetCommentHistory godoc
+// @Summary Get comment edit history
+// @Description List the previous bodies of an edited comment, newest first
+// @Tags Comments
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Param commentId path int true "Comment ID"
+// @Success 200 {object} TodoResponse "Comment history retrieved successfully"
+// @Failure 400 {object} TodoResponse "Invalid request"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Router /todos/{id}/comments/{commentId}/history [get]
+func (h *CommentHandler) GetCommentHistory(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	todoID, commentID, ok := parseCommentParams(c)
+	if !ok {
+		return
+	}
+
+	revisions, err := h.commentService.GetCommentHistory(todoID, commentID, userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Comment history retrieved successfully", revisions)
+}
+
+func parseCommentParams(c *gin.Context) (uint, uint, bool) {
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid todo ID")
+		return 0, 0, false
+	}
+
+	commentID, err := strconv.ParseUint(c.Param("commentId"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid comment ID")
+		return 0, 0, false
+	}
+
+	return uint(todoID), uint(commentID), true
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"task-management/internal/services"
+	"task-management/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setETag sends the resource version as a strong entity tag.
+func setETag(c *gin.Context, version uint) {
+	c.Header("ETag", `"`+strconv.FormatUint(uint64(version), 10)+`"`)
+}
+
+// parseIfMatch reads the If-Match header. A missing header or "*" places no
+// constraint on the version, unless required is set, in which case a
+// missing header is answered with 428 and ok is false. Weak tags never match
+// because If-Match uses strong comparison.
+func parseIfMatch(c *gin.Context, required bool) (match services.VersionMatch, ok bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		if required {
+			utils.ErrorResponse(c, http.StatusPreconditionRequired, "If-Match header is required")
+			return nil, false
+		}
+		return nil, true
+	}
+	if header == "*" {
+		return nil, true
+	}
+
+	match = services.VersionMatch{}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if strings.HasPrefix(tag, "W/") || len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+			continue
+		}
+		version, err := strconv.ParseUint(tag[1:len(tag)-1], 10, 32)
+		if err != nil {
+			continue
+		}
+		match = append(match, uint(version))
+	}
+	return match, true
+}
+
+// etagMatches reports whether an If-None-Match header matches the version.
+func etagMatches(header string, version uint) bool {
+	etag := `"` + strconv.FormatUint(uint64(version), 10) + `"`
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// respondConflict answers a ConflictError with 412 and the current
+// representation. It reports whether err was a conflict.
+func respondConflict(c *gin.Context, err error) bool {
+	var conflict *services.ConflictError
+	if !errors.As(err, &conflict) {
+		return false
+	}
+
+	setETag(c, conflict.Version)
+	c.JSON(http.StatusPreconditionFailed, utils.Response{
+		Status:  "error",
+		Message: conflict.Error(),
+		Data:    conflict.Current,
+	})
+	return true
+}
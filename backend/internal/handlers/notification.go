@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"task-management/internal/services"
+	"task-management/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler struct {
+	notificationService services.NotificationService
+}
+
+func NewNotificationHandler(notificationService services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+	}
+}
+
+// GetNotifications godoc
+// @Summary List notifications
+// @Description List the most recent in-app notifications of the authenticated user
+// @Tags Notifications
+// @Produce json
+// @Security BearerAuth
+// @Param unread query bool false "Only return unread notifications"
+// @Success 200 {object} TodoResponse "Notifications retrieved successfully"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 500 {object} TodoResponse "Internal server error"
+// @Router /notifications [get]
+func (h *NotificationHandler) GetNotifications(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	unreadOnly := c.Query("unread") == "true"
+
+	notifications, err := h.notificationService.GetNotifications(userID.(uint), unreadOnly)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Notifications retrieved successfully", notifications)
+}
+
+// MarkNotificationRead godoc
+// @Summary Mark a notification as read
+// @Tags Notifications
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Notification ID"
+// @Success 200 {object} TodoResponse "Notification marked as read"
+// @Failure 400 {object} TodoResponse "Invalid request or notification ID"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Router /notifications/{id}/read [post]
+func (h *NotificationHandler) MarkNotificationRead(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	notificationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid notification ID")
+		return
+	}
+
+	if err := h.notificationService.MarkRead(uint(notificationID), userID.(uint)); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Notification marked as read", nil)
+}
+
+// MarkAllNotificationsRead godoc
+// @Summary Mark all notifications as read
+// @Tags Notifications
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} TodoResponse "Notifications marked as read"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 500 {object} TodoResponse "Internal server error"
+// @Router /notifications/read-all [post]
+func (h *NotificationHandler) MarkAllNotificationsRead(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	if err := h.notificationService.MarkAllRead(userID.(uint)); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Notifications marked as read", nil)
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"task-management/internal/services"
+	"task-management/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StatsHandler struct {
+	statsService services.StatsService
+}
+
+func NewStatsHandler(statsService services.StatsService) *StatsHandler {
+	return &StatsHandler{
+		statsService: statsService,
+	}
+}
+
+// GetStats godoc
+// @Summary Get productivity statistics
+// @Description Counts of the authenticated user's todos by status, priority and category, overdue and due-today counts, completion rate, average cycle time from creation to completion, and a daily created/completed series. The range defaults to the last 30 days and may span at most 366 days.
+// @Tags Stats
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First day of the series (YYYY-MM-DD)"
+// @Param to query string false "Last day of the series (YYYY-MM-DD)"
+// @Param tz query string false "IANA timezone used for days, e.g. Asia/Jakarta" default(UTC)
+// @Success 200 {object} TodoResponse "Stats retrieved successfully"
+// @Failure 400 {object} TodoResponse "Invalid range or timezone"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 500 {object} TodoResponse "Internal server error"
+// @Router /stats [get]
+func (h *StatsHandler) GetStats(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	stats, err := h.statsService.GetStats(c.GetUint("workspaceID"), userID.(uint), services.StatsQuery{
+		From:     c.Query("from"),
+		To:       c.Query("to"),
+		Timezone: c.Query("tz"),
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidValue) {
+			utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Stats retrieved successfully", stats)
+}
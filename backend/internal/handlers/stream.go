@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"task-management/internal/models"
+	"task-management/internal/services"
+	"task-management/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+type StreamHandler struct {
+	streamService services.StreamService
+	heartbeat     time.Duration
+	upgrader      websocket.Upgrader
+}
+
+// StreamMessage is the JSON frame sent to WebSocket clients.
+type StreamMessage struct {
+	ID    uint64           `json:"id"`
+	Event models.EventType `json:"event"`
+	Data  json.RawMessage  `json:"data"`
+}
+
+func NewStreamHandler(streamService services.StreamService, heartbeat time.Duration, allowedOrigins []string) *StreamHandler {
+	return &StreamHandler{
+		streamService: streamService,
+		heartbeat:     heartbeat,
+		upgrader: websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool {
+				origin := r.Header.Get("Origin")
+				if origin == "" {
+					return true
+				}
+				for _, allowed := range allowedOrigins {
+					if origin == allowed {
+						return true
+					}
+				}
+				return false
+			},
+		},
+	}
+}
+
+// Stream godoc
+// @Summary Stream todo events (SSE)
+// @Description Push todo create/update/delete events from every workspace the user belongs to as Server-Sent Events. Send Last-Event-ID (header or last_event_id query) to resume after a disconnect.
+// @Tags Stream
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Param Last-Event-ID header string false "Resume after this event ID"
+// @Param access_token query string false "JWT, for clients that cannot set headers"
+// @Success 200 {string} string "Event stream"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Router /stream [get]
+func (h *StreamHandler) Stream(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	lastEventID, err := parseLastEventID(c)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid Last-Event-ID")
+		return
+	}
+
+	sub, err := h.streamService.Subscribe(userID.(uint), lastEventID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer sub.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	write := func(event models.TodoEvent) error {
+		_, err := fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, event.Payload)
+		return err
+	}
+
+	fmt.Fprintf(c.Writer, "retry: 3000\n\n")
+	for _, event := range sub.Replay {
+		if err := write(event); err != nil {
+			return
+		}
+		lastEventID = event.ID
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(c.Writer, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		case event, ok := <-sub.Events:
+			if !ok {
+				return
+			}
+			if event.ID <= lastEventID {
+				continue
+			}
+			if err := write(event); err != nil {
+				return
+			}
+			lastEventID = event.ID
+			c.Writer.Flush()
+		}
+	}
+}
+
+// WebSocket godoc
+// @Summary Stream todo events (WebSocket)
+// @Description WebSocket variant of /stream. Every message is a JSON StreamMessage.
+// @Tags Stream
+// @Security BearerAuth
+// @Param last_event_id query int false "Resume after this event ID"
+// @Param access_token query string false "JWT, for clients that cannot set headers"
+// @Success 101 {string} string "Switching Protocols"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Router /stream/ws [get]
+func (h *StreamHandler) WebSocket(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	lastEventID, err := parseLastEventID(c)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid Last-Event-ID")
+		return
+	}
+
+	sub, err := h.streamService.Subscribe(userID.(uint), lastEventID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer sub.Close()
+
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	// The stream is one-way; reading is only needed to process control
+	// frames and notice when the client goes away.
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	write := func(event models.TodoEvent) error {
+		return conn.WriteJSON(StreamMessage{
+			ID:    event.ID,
+			Event: event.Type,
+			Data:  json.RawMessage(event.Payload),
+		})
+	}
+
+	for _, event := range sub.Replay {
+		if err := write(event); err != nil {
+			return
+		}
+		lastEventID = event.ID
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case <-heartbeat.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(10*time.Second)); err != nil {
+				return
+			}
+		case event, ok := <-sub.Events:
+			if !ok {
+				conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "subscriber too slow"))
+				return
+			}
+			if event.ID <= lastEventID {
+				continue
+			}
+			if err := write(event); err != nil {
+				return
+			}
+			lastEventID = event.ID
+		}
+	}
+}
+
+func parseLastEventID(c *gin.Context) (uint64, error) {
+	raw := c.GetHeader("Last-Event-ID")
+	if raw == "" {
+		raw = c.Query("last_event_id")
+	}
+	if raw == "" {
+		return 0, nil
+	}
+	return strconv.ParseUint(raw, 10, 64)
+}
@@ -0,0 +1,299 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"task-management/internal/models"
+	"task-management/internal/services"
+	"task-management/internal/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TemplateHandler struct {
+	templateService services.TemplateService
+}
+
+type TemplateRequest struct {
+	Name        string          `json:"name" binding:"required" example:"Release checklist"`
+	Title       string          `json:"title" binding:"required" example:"Release {{version}}"`
+	Description string          `json:"description" example:"Ship {{version}} to production"`
+	Priority    models.Priority `json:"priority" example:"high"`
+	Category    models.Category `json:"category" example:"work"`
+	DueOffset   *int            `json:"due_offset_minutes" example:"4320"`
+	Estimate    *int            `json:"estimate_minutes" example:"120"`
+	Subtasks    []string        `json:"subtasks" example:"Tag {{version}},Publish release notes"`
+}
+
+type InstantiateTemplateRequest struct {
+	Variables map[string]string `json:"variables"`
+	// From is the moment the due offset counts from; it defaults to now.
+	From string `json:"from" example:"2024-12-01T09:00:00Z"`
+	// Timezone is the IANA time zone whole days of the offset are counted
+	// in; it defaults to UTC.
+	Timezone string `json:"timezone" example:"Europe/Berlin"`
+}
+
+func NewTemplateHandler(templateService services.TemplateService) *TemplateHandler {
+	return &TemplateHandler{
+		templateService: templateService,
+	}
+}
+
+// GetTemplates godoc
+// @Summary List templates
+// @Description List the todo templates of the current workspace
+// @Tags Templates
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} TodoResponse "Templates retrieved successfully"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 500 {object} TodoResponse "Internal server error"
+// @Router /templates [get]
+func (h *TemplateHandler) GetTemplates(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	templates, err := h.templateService.GetTemplates(c.GetUint("workspaceID"), userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, templateErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Templates retrieved successfully", templates)
+}
+
+// CreateTemplate godoc
+// @Summary Create a template
+// @Description Create a todo template in the current workspace. Title, description and subtasks may contain {{variables}}; due_offset_minutes sets the due date relative to instantiation.
+// @Tags Templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body TemplateRequest true "Template Request"
+// @Success 200 {object} TodoResponse "Template created successfully"
+// @Failure 400 {object} TodoResponse "Invalid request"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 403 {object} TodoResponse "Workspace guests cannot manage templates"
+// @Failure 422 {object} TodoResponse "Validation error"
+// @Router /templates [post]
+func (h *TemplateHandler) CreateTemplate(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req TemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	template, err := h.templateService.CreateTemplate(c.GetUint("workspaceID"), userID.(uint), req.input())
+	if err != nil {
+		utils.ErrorResponse(c, templateErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Template created successfully", template)
+}
+
+// GetTemplate godoc
+// @Summary Get a template
+// @Tags Templates
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Template ID"
+// @Success 200 {object} TodoResponse "Template retrieved successfully"
+// @Failure 400 {object} TodoResponse "Invalid template ID"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 404 {object} TodoResponse "Template not found"
+// @Router /templates/{id} [get]
+func (h *TemplateHandler) GetTemplate(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	templateID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid template ID")
+		return
+	}
+
+	template, err := h.templateService.GetTemplate(uint(templateID), userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, templateErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Template retrieved successfully", template)
+}
+
+// UpdateTemplate godoc
+// @Summary Update a template
+// @Description Replace every field of a template
+// @Tags Templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Template ID"
+// @Param request body TemplateRequest true "Template Request"
+// @Success 200 {object} TodoResponse "Template updated successfully"
+// @Failure 400 {object} TodoResponse "Invalid request or template ID"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 403 {object} TodoResponse "Workspace guests cannot manage templates"
+// @Failure 404 {object} TodoResponse "Template not found"
+// @Failure 422 {object} TodoResponse "Validation error"
+// @Router /templates/{id} [put]
+func (h *TemplateHandler) UpdateTemplate(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	templateID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid template ID")
+		return
+	}
+
+	var req TemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	template, err := h.templateService.UpdateTemplate(uint(templateID), userID.(uint), req.input())
+	if err != nil {
+		utils.ErrorResponse(c, templateErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Template updated successfully", template)
+}
+
+// DeleteTemplate godoc
+// @Summary Delete a template
+// @Tags Templates
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Template ID"
+// @Success 200 {object} TodoResponse "Template deleted successfully"
+// @Failure 400 {object} TodoResponse "Invalid template ID"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 403 {object} TodoResponse "Workspace guests cannot manage templates"
+// @Failure 404 {object} TodoResponse "Template not found"
+// @Router /templates/{id} [delete]
+func (h *TemplateHandler) DeleteTemplate(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	templateID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid template ID")
+		return
+	}
+
+	if err := h.templateService.DeleteTemplate(uint(templateID), userID.(uint)); err != nil {
+		utils.ErrorResponse(c, templateErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Template deleted successfully", nil)
+}
+
+// InstantiateTemplate godoc
+// @Summary Create a todo from a template
+// @Description Create a todo with its subtasks from the template in one transaction. Every {{variable}} the template uses must be given. The due date is the template's offset added to from, which defaults to now. Whole days of the offset are calendar days in timezone, so the due time keeps its wall clock time across DST changes.
+// @Tags Templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Template ID"
+// @Param request body InstantiateTemplateRequest true "Instantiate Template Request"
+// @Success 200 {object} TodoResponse "Todo created successfully"
+// @Failure 400 {object} TodoResponse "Invalid request or template ID"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 403 {object} TodoResponse "Workspace guests cannot create todos"
+// @Failure 404 {object} TodoResponse "Template not found"
+// @Failure 422 {object} TodoResponse "Missing variables or unknown timezone"
+// @Router /templates/{id}/instantiate [post]
+func (h *TemplateHandler) InstantiateTemplate(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	templateID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid template ID")
+		return
+	}
+
+	var req InstantiateTemplateRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.ValidationErrorResponse(c, err.Error())
+			return
+		}
+	}
+
+	from := time.Now()
+	if req.From != "" {
+		from, err = time.Parse(time.RFC3339, req.From)
+		if err != nil {
+			utils.ValidationErrorResponse(c, "Invalid date format. Use RFC3339 (e.g., 2024-12-31T23:59:59Z)")
+			return
+		}
+	}
+
+	todo, err := h.templateService.InstantiateTemplate(uint(templateID), userID.(uint), req.Variables, from, req.Timezone)
+	if err != nil {
+		utils.ErrorResponse(c, templateErrorStatus(err), err.Error())
+		return
+	}
+
+	setETag(c, todo.Version)
+	utils.SuccessResponse(c, "Todo created successfully", todo)
+}
+
+func (r TemplateRequest) input() services.TemplateInput {
+	return services.TemplateInput{
+		Name:        r.Name,
+		Title:       r.Title,
+		Description: r.Description,
+		Priority:    r.Priority,
+		Category:    r.Category,
+		DueOffset:   r.DueOffset,
+		Estimate:    r.Estimate,
+		Subtasks:    r.Subtasks,
+	}
+}
+
+func templateErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrWorkspaceForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrInvalidValue):
+		return http.StatusUnprocessableEntity
+	case strings.HasSuffix(err.Error(), "not found"):
+		return http.StatusNotFound
+	case err.Error() == "database error":
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
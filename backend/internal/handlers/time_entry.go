@@ -0,0 +1,422 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"task-management/internal/services"
+	"task-management/internal/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TimeEntryHandler struct {
+	timeEntryService services.TimeEntryService
+}
+
+type StartTimerRequest struct {
+	Note string `json:"note" example:"Client call"`
+}
+
+type TimeEntryRequest struct {
+	StartedAt *time.Time `json:"started_at" example:"2024-12-31T09:00:00Z"`
+	EndedAt   *time.Time `json:"ended_at" example:"2024-12-31T10:30:00Z"`
+	Note      *string    `json:"note" example:"Drafted the proposal"`
+}
+
+func NewTimeEntryHandler(timeEntryService services.TimeEntryService) *TimeEntryHandler {
+	return &TimeEntryHandler{
+		timeEntryService: timeEntryService,
+	}
+}
+
+// StartTimer godoc
+// @Summary Start a timer on a todo
+// @Description Start tracking time on a todo. Only one timer can run per user; stop it before starting another.
+// @Tags Time tracking
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Param request body StartTimerRequest false "Start Timer Request"
+// @Success 200 {object} TodoResponse "Timer started successfully"
+// @Failure 400 {object} TodoResponse "Invalid request or todo ID"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 403 {object} TodoResponse "Workspace guests cannot change todos"
+// @Failure 404 {object} TodoResponse "Todo not found"
+// @Failure 409 {object} TodoResponse "A timer is already running"
+// @Router /todos/{id}/timer/start [post]
+func (h *TimeEntryHandler) StartTimer(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid todo ID")
+		return
+	}
+
+	var req StartTimerRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.ValidationErrorResponse(c, err.Error())
+			return
+		}
+	}
+
+	entry, err := h.timeEntryService.StartTimer(uint(todoID), userID.(uint), req.Note)
+	if err != nil {
+		utils.ErrorResponse(c, timeEntryErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Timer started successfully", entry)
+}
+
+// StopTimer godoc
+// @Summary Stop the running timer
+// @Description Stop the authenticated user's running timer and record the time entry
+// @Tags Time tracking
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} TodoResponse "Timer stopped successfully"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 404 {object} TodoResponse "No timer is running"
+// @Router /timer/stop [post]
+func (h *TimeEntryHandler) StopTimer(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	entry, err := h.timeEntryService.StopTimer(userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, timeEntryErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Timer stopped successfully", entry)
+}
+
+// GetRunningTimer godoc
+// @Summary Get the running timer
+// @Tags Time tracking
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} TodoResponse "Running timer retrieved successfully"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 404 {object} TodoResponse "No timer is running"
+// @Router /timer [get]
+func (h *TimeEntryHandler) GetRunningTimer(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	entry, err := h.timeEntryService.GetRunningTimer(userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, timeEntryErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Running timer retrieved successfully", entry)
+}
+
+// GetTimeEntries godoc
+// @Summary List time entries of a todo
+// @Tags Time tracking
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Success 200 {object} TodoResponse "Time entries retrieved successfully"
+// @Failure 400 {object} TodoResponse "Invalid todo ID"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 404 {object} TodoResponse "Todo not found"
+// @Router /todos/{id}/time-entries [get]
+func (h *TimeEntryHandler) GetTimeEntries(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid todo ID")
+		return
+	}
+
+	entries, err := h.timeEntryService.GetEntries(uint(todoID), userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, timeEntryErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Time entries retrieved successfully", entries)
+}
+
+// CreateTimeEntry godoc
+// @Summary Add a time entry manually
+// @Description Record time spent on a todo. started_at and ended_at are required and an entry may span at most 24 hours.
+// @Tags Time tracking
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Param request body TimeEntryRequest true "Time Entry Request"
+// @Success 200 {object} TodoResponse "Time entry created successfully"
+// @Failure 400 {object} TodoResponse "Invalid request or todo ID"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 403 {object} TodoResponse "Workspace guests cannot change todos"
+// @Failure 404 {object} TodoResponse "Todo not found"
+// @Failure 422 {object} TodoResponse "Invalid time range"
+// @Router /todos/{id}/time-entries [post]
+func (h *TimeEntryHandler) CreateTimeEntry(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid todo ID")
+		return
+	}
+
+	var req TimeEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	entry, err := h.timeEntryService.CreateEntry(uint(todoID), userID.(uint), req.input())
+	if err != nil {
+		utils.ErrorResponse(c, timeEntryErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Time entry created successfully", entry)
+}
+
+// UpdateTimeEntry godoc
+// @Summary Edit a time entry
+// @Description Change the start, end or note of a time entry. Omitted fields are left unchanged; setting ended_at on a running timer stops it.
+// @Tags Time tracking
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Param entryId path int true "Time entry ID"
+// @Param request body TimeEntryRequest true "Time Entry Request"
+// @Success 200 {object} TodoResponse "Time entry updated successfully"
+// @Failure 400 {object} TodoResponse "Invalid request"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 404 {object} TodoResponse "Todo or time entry not found"
+// @Failure 422 {object} TodoResponse "Invalid time range"
+// @Router /todos/{id}/time-entries/{entryId} [put]
+func (h *TimeEntryHandler) UpdateTimeEntry(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	todoID, entryID, ok := parseTimeEntryParams(c)
+	if !ok {
+		return
+	}
+
+	var req TimeEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	entry, err := h.timeEntryService.UpdateEntry(todoID, entryID, userID.(uint), req.input())
+	if err != nil {
+		utils.ErrorResponse(c, timeEntryErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Time entry updated successfully", entry)
+}
+
+// DeleteTimeEntry godoc
+// @Summary Delete a time entry
+// @Tags Time tracking
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Param entryId path int true "Time entry ID"
+// @Success 200 {object} TodoResponse "Time entry deleted successfully"
+// @Failure 400 {object} TodoResponse "Invalid request"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 404 {object} TodoResponse "Todo or time entry not found"
+// @Router /todos/{id}/time-entries/{entryId} [delete]
+func (h *TimeEntryHandler) DeleteTimeEntry(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	todoID, entryID, ok := parseTimeEntryParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.timeEntryService.DeleteEntry(todoID, entryID, userID.(uint)); err != nil {
+		utils.ErrorResponse(c, timeEntryErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Time entry deleted successfully", nil)
+}
+
+// GetTodoTime godoc
+// @Summary Get the time tracked on a todo
+// @Description Total tracked seconds of a todo compared with its estimate. remaining_seconds is negative once the estimate is exceeded. A running timer is reported but not counted.
+// @Tags Time tracking
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Success 200 {object} TodoResponse "Tracked time retrieved successfully"
+// @Failure 400 {object} TodoResponse "Invalid todo ID"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 404 {object} TodoResponse "Todo not found"
+// @Router /todos/{id}/time [get]
+func (h *TimeEntryHandler) GetTodoTime(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid todo ID")
+		return
+	}
+
+	total, err := h.timeEntryService.GetTodoTotal(uint(todoID), userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, timeEntryErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Tracked time retrieved successfully", total)
+}
+
+// GetCategoryTime godoc
+// @Summary Get tracked time per category
+// @Description Total tracked seconds of the authenticated user per todo category
+// @Tags Time tracking
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} TodoResponse "Tracked time retrieved successfully"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 500 {object} TodoResponse "Internal server error"
+// @Router /time/categories [get]
+func (h *TimeEntryHandler) GetCategoryTime(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	totals, err := h.timeEntryService.GetCategoryTotals(userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Tracked time retrieved successfully", totals)
+}
+
+// GetTimeReport godoc
+// @Summary Get a time report
+// @Description Tracked seconds per day or per week (starting Monday) over a date range. Entries count towards the day they started. The range defaults to the last 30 days and may span at most 366 days.
+// @Tags Time tracking
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First day of the report (YYYY-MM-DD)"
+// @Param to query string false "Last day of the report (YYYY-MM-DD)"
+// @Param tz query string false "IANA timezone used for days, e.g. Asia/Jakarta" default(UTC)
+// @Param group_by query string false "Grouping" Enums(day, week) default(day)
+// @Success 200 {object} TodoResponse "Time report retrieved successfully"
+// @Failure 400 {object} TodoResponse "Invalid range, timezone or grouping"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 500 {object} TodoResponse "Internal server error"
+// @Router /time/report [get]
+func (h *TimeEntryHandler) GetTimeReport(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	report, err := h.timeEntryService.GetReport(userID.(uint), services.TimeReportQuery{
+		StatsQuery: services.StatsQuery{
+			From:     c.Query("from"),
+			To:       c.Query("to"),
+			Timezone: c.Query("tz"),
+		},
+		GroupBy: services.ReportGrouping(c.Query("group_by")),
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidValue) {
+			utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Time report retrieved successfully", report)
+}
+
+func (r TimeEntryRequest) input() services.TimeEntryInput {
+	return services.TimeEntryInput{
+		StartedAt: r.StartedAt,
+		EndedAt:   r.EndedAt,
+		Note:      r.Note,
+	}
+}
+
+func parseTimeEntryParams(c *gin.Context) (uint, uint, bool) {
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid todo ID")
+		return 0, 0, false
+	}
+
+	entryID, err := strconv.ParseUint(c.Param("entryId"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid time entry ID")
+		return 0, 0, false
+	}
+
+	return uint(todoID), uint(entryID), true
+}
+
+func timeEntryErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrTimerRunning):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrInvalidValue):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, services.ErrWorkspaceForbidden):
+		return http.StatusForbidden
+	case strings.HasSuffix(err.Error(), "not found"), err.Error() == "no timer is running":
+		return http.StatusNotFound
+	}
+	return http.StatusBadRequest
+}
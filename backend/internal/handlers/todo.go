@@ -1,6 +1,10 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
 	"time"
 	"net/http"
 	"strconv"
@@ -11,8 +15,16 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	// importMaxFileSize limits the size of files accepted by ImportTodos.
+	importMaxFileSize = 10 << 20
+	// patchMaxSize limits the size of PatchTodo request bodies.
+	patchMaxSize = 1 << 20
+)
+
 type TodoHandler struct {
-	todoService services.TodoService
+	todoService    services.TodoService
+	requireIfMatch bool
 }
 
 type CreateTodoRequest struct {
@@ -21,6 +33,12 @@ type CreateTodoRequest struct {
 	Priority    models.Priority `json:"priority" enums:"low,medium,high" example:"medium"`
 	Category    models.Category `json:"category" enums:"personal,work,shopping,health,other" example:"personal"`
 	DueDate     string          `json:"due_date" example:"2024-12-31T23:59:59Z"`
+	Estimate    *int            `json:"estimate_minutes" example:"90"`
+}
+
+type QuickAddRequest struct {
+	Text     string `json:"text" binding:"required" example:"Pay rent tomorrow 9am !high #personal"`
+	Timezone string `json:"timezone" example:"Asia/Jakarta"`
 }
 
 type UpdateTodoRequest struct {
@@ -28,8 +46,41 @@ type UpdateTodoRequest struct {
 	Description string          `json:"description" example:"Need to buy milk, eggs, and bread"`
 	Priority    models.Priority `json:"priority" enums:"low,medium,high" example:"high"`
 	Category    models.Category `json:"category" enums:"personal,work,shopping,health,other" example:"work"`
-	Status      models.Status   `json:"status" enums:"pending,in_progress,completed" example:"completed"`
+	Status      models.Status   `json:"status" enums:"todo,inprogress,done" example:"done"`
 	DueDate     string          `json:"due_date" example:"2024-12-31T23:59:59Z"`
+	Estimate    *int            `json:"estimate_minutes" example:"120"`
+}
+
+type UpdateSubtaskRequest struct {
+	Title       string                  `json:"title" example:"Buy milk"`
+	IsCompleted models.CompletionStatus `json:"is_completed" enums:"yes,no" example:"yes"`
+}
+
+type SubtaskRulesRequest struct {
+	AutoComplete *bool `json:"auto_complete" example:"true"`
+	AutoReopen   *bool `json:"auto_reopen" example:"true"`
+}
+
+type MoveTodoRequest struct {
+	Status   models.Status `json:"status" binding:"required" enums:"todo,inprogress,done" example:"inprogress"`
+	AfterID  *uint         `json:"after_id" example:"12"`
+	BeforeID *uint         `json:"before_id" example:"15"`
+}
+
+type AddBlockerRequest struct {
+	BlockerID uint `json:"blocker_id" binding:"required" example:"12"`
+}
+
+type BulkTodoFilter struct {
+	Status   string `json:"status" enums:"todo,inprogress,done" example:"done"`
+	Category string `json:"category" enums:"personal,work,shopping,health,other" example:"work"`
+}
+
+type BulkTodoRequest struct {
+	IDs    []uint          `json:"ids" example:"1,2,3"`
+	Filter *BulkTodoFilter `json:"filter"`
+	Action string          `json:"action" binding:"required" enums:"set_status,set_priority,set_category,set_due_date,delete,restore" example:"set_status"`
+	Value  *string         `json:"value" example:"done"`
 }
 
 type TodoResponse struct {
@@ -38,9 +89,10 @@ type TodoResponse struct {
 	Data    interface{} `json:"data,omitempty"`
 }
 
-func NewTodoHandler(todoService services.TodoService) *TodoHandler {
+func NewTodoHandler(todoService services.TodoService, requireIfMatch bool) *TodoHandler {
 	return &TodoHandler{
-		todoService: todoService,
+		todoService:    todoService,
+		requireIfMatch: requireIfMatch,
 	}
 }
 
@@ -55,6 +107,7 @@ func NewTodoHandler(todoService services.TodoService) *TodoHandler {
 // @Success 200 {object} TodoResponse "Todo created successfully"
 // @Failure 400 {object} TodoResponse "Invalid request"
 // @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 403 {object} TodoResponse "Workspace guests cannot change todos"
 // @Failure 422 {object} TodoResponse "Validation error"
 // @Router /todos [post]
 func (h *TodoHandler) CreateTodo(c *gin.Context) {
@@ -90,32 +143,85 @@ func (h *TodoHandler) CreateTodo(c *gin.Context) {
 	}
 
 	todo, err := h.todoService.CreateTodo(
+		c.GetUint("workspaceID"),
 		userID.(uint),
 		req.Title,
 		req.Description,
 		req.Priority,
 		req.Category,
 		dueDatePtr, // ✅ sudah *time.Time
+		req.Estimate,
 	)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		utils.ErrorResponse(c, todoErrorStatus(err), err.Error())
 		return
 	}
 
+	setETag(c, todo.Version)
 	utils.SuccessResponse(c, "Todo created successfully", todo)
 }
 
+// QuickAddTodo godoc
+// @Summary Quick-add a todo from one line of text
+// @Description Parse a line such as "Pay rent tomorrow 9am !high #personal" or "Bayar listrik besok jam 7 malam !tinggi #pribadi" into a todo. Dates and times in English or Indonesian set the due date in the given time zone (default UTC), !high/!medium/!low (or !1-!3, !tinggi/!sedang/!rendah) the priority, and #category or @category the category; the remaining words form the title. The response lists every recognised token. With dry_run=true nothing is created.
+// @Tags Todos
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body QuickAddRequest true "Quick Add Request"
+// @Param dry_run query bool false "Only parse the text" default(false)
+// @Success 200 {object} TodoResponse "Parse breakdown and the created todo"
+// @Failure 400 {object} TodoResponse "Invalid request"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 403 {object} TodoResponse "Workspace guests cannot change todos"
+// @Failure 422 {object} TodoResponse "No title or unknown time zone"
+// @Router /todos/quick [post]
+func (h *TodoHandler) QuickAddTodo(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req QuickAddRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid dry_run value")
+		return
+	}
+
+	result, err := h.todoService.QuickAdd(c.GetUint("workspaceID"), userID.(uint), req.Text, req.Timezone, dryRun)
+	if err != nil {
+		utils.ErrorResponse(c, todoErrorStatus(err), err.Error())
+		return
+	}
+
+	if dryRun {
+		utils.SuccessResponse(c, "Text parsed successfully", result)
+		return
+	}
+	setETag(c, result.Todo.Version)
+	utils.SuccessResponse(c, "Todo created successfully", result)
+}
+
 
 // GetTodos godoc
 // @Summary Get all todos
-// @Description Get all todos for the authenticated user with optional filters
+// @Description Get all todos of the current workspace with optional filters. assignee=me lists the todos assigned to the authenticated user, whoever created them.
 // @Tags Todos
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param status query string false "Filter by status" Enums(pending, in_progress, completed)
+// @Param status query string false "Filter by status" Enums(todo, inprogress, done)
 // @Param category query string false "Filter by category" Enums(personal, work, shopping, health, other)
+// @Param assignee query string false "Filter by assignee: me or a user ID"
 // @Success 200 {object} TodoResponse "Todos retrieved successfully"
+// @Failure 400 {object} TodoResponse "Invalid assignee"
 // @Failure 401 {object} TodoResponse "Unauthorized"
 // @Failure 500 {object} TodoResponse "Internal server error"
 // @Router /todos [get]
@@ -129,7 +235,21 @@ func (h *TodoHandler) GetTodos(c *gin.Context) {
 	status := c.Query("status")
 	category := c.Query("category")
 
-	todos, err := h.todoService.GetTodos(userID.(uint), status, category)
+	var assigneeID uint
+	switch assignee := c.Query("assignee"); assignee {
+	case "":
+	case "me":
+		assigneeID = userID.(uint)
+	default:
+		parsed, err := strconv.ParseUint(assignee, 10, 32)
+		if err != nil || parsed == 0 {
+			utils.ValidationErrorResponse(c, "Invalid assignee. Use me or a user ID")
+			return
+		}
+		assigneeID = uint(parsed)
+	}
+
+	todos, err := h.todoService.GetTodos(c.GetUint("workspaceID"), userID.(uint), status, category, assigneeID)
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
@@ -138,6 +258,169 @@ func (h *TodoHandler) GetTodos(c *gin.Context) {
 	utils.SuccessResponse(c, "Todos retrieved successfully", todos)
 }
 
+// GetTodo godoc
+// @Summary Get a todo
+// @Description Get a single todo of the authenticated user. The ETag header carries the todo version for use with If-Match.
+// @Tags Todos
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Param If-None-Match header string false "ETag of a cached version"
+// @Success 200 {object} TodoResponse "Todo retrieved successfully"
+// @Success 304 "Not modified"
+// @Failure 400 {object} TodoResponse "Invalid todo ID"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 404 {object} TodoResponse "Todo not found"
+// @Router /todos/{id} [get]
+func (h *TodoHandler) GetTodo(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid todo ID")
+		return
+	}
+
+	todo, err := h.todoService.GetTodoByID(uint(todoID), userID.(uint))
+	if err != nil {
+		if err.Error() == "todo not found" {
+			utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	setETag(c, todo.Version)
+	if etagMatches(c.GetHeader("If-None-Match"), todo.Version) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	utils.SuccessResponse(c, "Todo retrieved successfully", todo)
+}
+
+// ExportTodos godoc
+// @Summary Export todos
+// @Description Download every todo of the authenticated user, including subtasks, as JSON or CSV. Accepts the same filters as GET /todos. The response is streamed.
+// @Tags Todos
+// @Produce json
+// @Produce text/csv
+// @Security BearerAuth
+// @Param format query string false "Export format" Enums(json, csv) default(json)
+// @Param status query string false "Filter by status" Enums(todo, inprogress, done)
+// @Param category query string false "Filter by category" Enums(personal, work, shopping, health, other)
+// @Success 200 {file} file "Exported todos"
+// @Failure 400 {object} TodoResponse "Invalid format"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Router /todos/export [get]
+func (h *TodoHandler) ExportTodos(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	format := services.ExportFormat(c.DefaultQuery("format", string(services.ExportJSON)))
+	if !format.IsValid() {
+		utils.ValidationErrorResponse(c, "Invalid format. Use json or csv")
+		return
+	}
+
+	status := c.Query("status")
+	category := c.Query("category")
+
+	filename := fmt.Sprintf("todos-%s.%s", time.Now().UTC().Format("20060102"), format)
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Status(http.StatusOK)
+
+	// Headers are already sent, so a failure can only be logged.
+	if err := h.todoService.ExportTodos(c.GetUint("workspaceID"), userID.(uint), status, category, format, c.Writer); err != nil {
+		log.Printf("export: failed to export todos for user %d: %v", userID, err)
+	}
+}
+
+// ImportTodos godoc
+// @Summary Import todos
+// @Description Import todos from a file in our own JSON or CSV export format, a Todoist CSV export or a Trello board JSON export. With dry_run=true the parsed rows and their validation errors are returned without importing anything. Otherwise every row must be valid and all todos are created at once.
+// @Tags Todos
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "File to import"
+// @Param format query string true "Import format" Enums(json, csv, todoist, trello)
+// @Param dry_run query bool false "Only preview the import" default(false)
+// @Success 200 {object} TodoResponse "Import preview or result"
+// @Failure 400 {object} TodoResponse "Invalid request or file"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 403 {object} TodoResponse "Workspace guests cannot change todos"
+// @Failure 422 {object} TodoResponse "Some rows are invalid; nothing was imported"
+// @Router /todos/import [post]
+func (h *TodoHandler) ImportTodos(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	format := services.ImportFormat(c.Query("format"))
+	if !format.IsValid() {
+		utils.ValidationErrorResponse(c, "Invalid format. Use json, csv, todoist or trello")
+		return
+	}
+
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid dry_run value")
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, importMaxFileSize+multipartOverhead)
+	fileHeader, err := c.FormFile("file")
+	if err != nil || fileHeader.Size > importMaxFileSize {
+		utils.ValidationErrorResponse(c, "A file is required and must not exceed the maximum size")
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Failed to read uploaded file")
+		return
+	}
+	defer file.Close()
+
+	result, err := h.todoService.ImportTodos(c.GetUint("workspaceID"), userID.(uint), format, file, dryRun)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrImportInvalid):
+			c.JSON(http.StatusUnprocessableEntity, utils.Response{
+				Status:  "error",
+				Message: err.Error(),
+				Data:    result,
+			})
+		case errors.Is(err, services.ErrWorkspaceForbidden):
+			utils.ErrorResponse(c, http.StatusForbidden, err.Error())
+		case err.Error() == "failed to import todos":
+			utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		default:
+			utils.ValidationErrorResponse(c, err.Error())
+		}
+		return
+	}
+
+	if dryRun {
+		utils.SuccessResponse(c, "Import preview generated successfully", result)
+		return
+	}
+	utils.SuccessResponse(c, "Todos imported successfully", result)
+}
+
 // UpdateTodo godoc
 // @Summary Update a todo
 // @Description Update a specific todo by ID for the authenticated user
@@ -145,12 +428,17 @@ func (h *TodoHandler) GetTodos(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param If-Match header string false "ETag of the version being modified"
 // @Param id path int true "Todo ID"
 // @Param request body UpdateTodoRequest true "Update Todo Request"
 // @Success 200 {object} TodoResponse "Todo updated successfully"
 // @Failure 400 {object} TodoResponse "Invalid request or todo ID"
 // @Failure 401 {object} TodoResponse "Unauthorized"
-// @Failure 422 {object} TodoResponse "Validation error"
+// @Failure 403 {object} TodoResponse "Workspace guests cannot change todos"
+// @Failure 409 {object} TodoResponse "Todo is blocked by open todos"
+// @Failure 422 {object} TodoResponse "Invalid enum value or status transition not allowed"
+// @Failure 412 {object} TodoResponse "Modified since it was read; data holds the current version"
+// @Failure 428 {object} TodoResponse "If-Match header is required"
 // @Router /todos/{id} [put]
 func (h *TodoHandler) UpdateTodo(c *gin.Context) {
 	userID, exists := c.Get("userID")
@@ -188,13 +476,97 @@ func (h *TodoHandler) UpdateTodo(c *gin.Context) {
 	if req.Status != "" {
 		updates["status"] = req.Status
 	}
+	if req.DueDate != "" {
+		parsed, err := time.Parse(time.RFC3339, req.DueDate)
+		if err != nil {
+			utils.ValidationErrorResponse(c, "Invalid date format. Use RFC3339 (e.g., 2024-12-31T23:59:59Z)")
+			return
+		}
+		updates["due_date"] = &parsed
+	}
+	if req.Estimate != nil {
+		updates["estimate_minutes"] = req.Estimate
+	}
+
+	ifMatch, ok := parseIfMatch(c, h.requireIfMatch)
+	if !ok {
+		return
+	}
 
-	todo, err := h.todoService.UpdateTodo(uint(todoID), userID.(uint), updates)
+	todo, err := h.todoService.UpdateTodo(uint(todoID), userID.(uint), updates, ifMatch)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		if respondConflict(c, err) {
+			return
+		}
+		utils.ErrorResponse(c, todoErrorStatus(err), err.Error())
+		return
+	}
+
+	setETag(c, todo.Version)
+	utils.SuccessResponse(c, "Todo updated successfully", todo)
+}
+
+// PatchTodo godoc
+// @Summary Partially update a todo
+// @Description Patch the title, description, priority, category, status, due_date or estimate_minutes of a todo with a JSON Merge Patch (RFC 7396, application/merge-patch+json) or a JSON Patch (RFC 6902, application/json-patch+json). Setting description, due_date or estimate_minutes to null clears it.
+// @Tags Todos
+// @Accept application/merge-patch+json
+// @Accept application/json-patch+json
+// @Produce json
+// @Security BearerAuth
+// @Param If-Match header string false "ETag of the version being modified"
+// @Param id path int true "Todo ID"
+// @Param request body object true "Merge patch object or JSON patch operations"
+// @Success 200 {object} TodoResponse "Todo updated successfully"
+// @Failure 400 {object} TodoResponse "Invalid todo ID or malformed patch"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 403 {object} TodoResponse "Workspace guests cannot change todos"
+// @Failure 409 {object} TodoResponse "Test operation failed or todo is blocked"
+// @Failure 415 {object} TodoResponse "Unsupported patch format"
+// @Failure 422 {object} TodoResponse "Invalid field value or status transition not allowed"
+// @Failure 412 {object} TodoResponse "Modified since it was read; data holds the current version"
+// @Failure 428 {object} TodoResponse "If-Match header is required"
+// @Router /todos/{id} [patch]
+func (h *TodoHandler) PatchTodo(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid todo ID")
+		return
+	}
+
+	format := services.PatchFormat(c.ContentType())
+	if !format.IsValid() {
+		utils.ErrorResponse(c, http.StatusUnsupportedMediaType, "Use application/merge-patch+json or application/json-patch+json")
+		return
+	}
+
+	patch, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, patchMaxSize))
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Failed to read patch")
+		return
+	}
+
+	ifMatch, ok := parseIfMatch(c, h.requireIfMatch)
+	if !ok {
+		return
+	}
+
+	todo, err := h.todoService.PatchTodo(uint(todoID), userID.(uint), format, patch, ifMatch)
+	if err != nil {
+		if respondConflict(c, err) {
+			return
+		}
+		utils.ErrorResponse(c, todoErrorStatus(err), err.Error())
 		return
 	}
 
+	setETag(c, todo.Version)
 	utils.SuccessResponse(c, "Todo updated successfully", todo)
 }
 
@@ -205,10 +577,14 @@ func (h *TodoHandler) UpdateTodo(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param If-Match header string false "ETag of the version being modified"
 // @Param id path int true "Todo ID"
 // @Success 200 {object} TodoResponse "Todo deleted successfully"
 // @Failure 400 {object} TodoResponse "Invalid request or todo ID"
 // @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 403 {object} TodoResponse "Workspace guests cannot change todos"
+// @Failure 412 {object} TodoResponse "Modified since it was read; data holds the current version"
+// @Failure 428 {object} TodoResponse "If-Match header is required"
 // @Router /todos/{id} [delete]
 func (h *TodoHandler) DeleteTodo(c *gin.Context) {
 	userID, exists := c.Get("userID")
@@ -223,37 +599,618 @@ func (h *TodoHandler) DeleteTodo(c *gin.Context) {
 		return
 	}
 
-	if err := h.todoService.DeleteTodo(uint(todoID), userID.(uint)); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	ifMatch, ok := parseIfMatch(c, h.requireIfMatch)
+	if !ok {
+		return
+	}
+
+	if err := h.todoService.DeleteTodo(uint(todoID), userID.(uint), ifMatch); err != nil {
+		if respondConflict(c, err) {
+			return
+		}
+		utils.ErrorResponse(c, todoErrorStatus(err), err.Error())
 		return
 	}
 
 	utils.SuccessResponse(c, "Todo deleted successfully", nil)
 }
 
-// GetByPublicID godoc
-// @Summary Get todo by public ID
-// @Description Get detail of a todo (and subtasks) using its public ID
-// @Tags todos
-// @Param id path int true "Public ID of the Todo"
+// GetTrash godoc
+// @Summary List deleted todos
+// @Description List the authenticated user's soft-deleted todos, most recently deleted first
+// @Tags Todos
 // @Produce json
-// @Success 200 {object} utils.Response
-// @Failure 400 {object} utils.Response
-// @Failure 404 {object} utils.Response
-// @Router /todos/public/{id} [get]
-func (h *TodoHandler) GetByPublicID(c *gin.Context) {
-	idParam := c.Param("id")
-	id, err := strconv.ParseUint(idParam, 10, 64)
+// @Security BearerAuth
+// @Success 200 {object} TodoResponse "Trash retrieved successfully"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 500 {object} TodoResponse "Internal server error"
+// @Router /todos/trash [get]
+func (h *TodoHandler) GetTrash(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	todos, err := h.todoService.GetTrash(c.GetUint("workspaceID"), userID.(uint))
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid todo ID format")
+		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	todo, err := h.todoService.GetByIDPublic(uint(id))
+	utils.SuccessResponse(c, "Trash retrieved successfully", todos)
+}
+
+// RestoreTodo godoc
+// @Summary Restore a deleted todo
+// @Description Move a todo and the subtasks deleted with it out of the trash
+// @Tags Todos
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Success 200 {object} TodoResponse "Todo restored successfully"
+// @Failure 400 {object} TodoResponse "Invalid request or todo ID"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 403 {object} TodoResponse "Workspace guests cannot change todos"
+// @Router /todos/{id}/restore [post]
+func (h *TodoHandler) RestoreTodo(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusNotFound, "Todo not found")
+		utils.ValidationErrorResponse(c, "Invalid todo ID")
 		return
 	}
 
-	utils.SuccessResponse(c, "Todo retrieved successfully", todo)
+	todo, err := h.todoService.RestoreTodo(uint(todoID), userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, todoErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Todo restored successfully", todo)
+}
+
+// PurgeTodo godoc
+// @Summary Permanently delete a todo
+// @Description Permanently remove a todo that is already in the trash, together with its subtasks
+// @Tags Todos
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Success 200 {object} TodoResponse "Todo purged successfully"
+// @Failure 400 {object} TodoResponse "Invalid request or todo ID"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 403 {object} TodoResponse "Workspace guests cannot change todos"
+// @Router /todos/{id}/purge [delete]
+func (h *TodoHandler) PurgeTodo(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid todo ID")
+		return
+	}
+
+	if err := h.todoService.PurgeTodo(uint(todoID), userID.(uint)); err != nil {
+		utils.ErrorResponse(c, todoErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Todo purged successfully", nil)
+}
+
+// GetTodoHistory godoc
+// @Summary Get todo revision history
+// @Description List every recorded change of a todo, newest first, with the old and new value of each changed field
+// @Tags Todos
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Success 200 {object} TodoResponse "History retrieved successfully"
+// @Failure 400 {object} TodoResponse "Invalid request or todo ID"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Router /todos/{id}/history [get]
+func (h *TodoHandler) GetTodoHistory(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid todo ID")
+		return
+	}
+
+	revisions, err := h.todoService.GetHistory(uint(todoID), userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "History retrieved successfully", revisions)
+}
+
+// RevertTodo godoc
+// @Summary Revert a todo to a previous revision
+// @Description Restore the fields captured by a revision. The revert is recorded as a new revision.
+// @Tags Todos
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Param revision path int true "Revision number"
+// @Success 200 {object} TodoResponse "Todo reverted successfully"
+// @Failure 400 {object} TodoResponse "Invalid request, todo ID or revision"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 403 {object} TodoResponse "Workspace guests cannot change todos"
+// @Failure 409 {object} TodoResponse "Todo is blocked by open todos"
+// @Failure 422 {object} TodoResponse "Status transition not allowed"
+// @Router /todos/{id}/revert/{revision} [post]
+func (h *TodoHandler) RevertTodo(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid todo ID")
+		return
+	}
+
+	revision, err := strconv.Atoi(c.Param("revision"))
+	if err != nil || revision < 1 {
+		utils.ValidationErrorResponse(c, "Invalid revision")
+		return
+	}
+
+	todo, err := h.todoService.RevertTodo(uint(todoID), userID.(uint), revision)
+	if err != nil {
+		if respondConflict(c, err) {
+			return
+		}
+		utils.ErrorResponse(c, todoErrorStatus(err), err.Error())
+		return
+	}
+
+	setETag(c, todo.Version)
+	utils.SuccessResponse(c, "Todo reverted successfully", todo)
+}
+
+// BulkTodos godoc
+// @Summary Apply an action to many todos
+// @Description Select todos by IDs or by filter and set status, priority, category or due date, delete or restore them in a single transaction. Returns a result per todo.
+// @Tags Todos
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body BulkTodoRequest true "Bulk Todo Request"
+// @Success 200 {object} TodoResponse "Bulk operation completed"
+// @Failure 400 {object} TodoResponse "Invalid request or batch too large"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 403 {object} TodoResponse "Workspace guests cannot change todos"
+// @Router /todos/bulk [post]
+func (h *TodoHandler) BulkTodos(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req BulkTodoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	op := services.BulkOperation{
+		IDs:    req.IDs,
+		Action: services.BulkAction(req.Action),
+		Value:  req.Value,
+	}
+	if req.Filter != nil {
+		op.Status = req.Filter.Status
+		op.Category = req.Filter.Category
+	}
+
+	results, err := h.todoService.BulkUpdate(c.GetUint("workspaceID"), userID.(uint), op)
+	if err != nil {
+		utils.ErrorResponse(c, todoErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Bulk operation completed", results)
+}
+
+// UpdateSubtask godoc
+// @Summary Update a subtask
+// @Description Rename a subtask or mark it as completed
+// @Tags Todos
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param If-Match header string false "ETag of the version being modified"
+// @Param id path int true "Todo ID"
+// @Param subtaskId path int true "Subtask ID"
+// @Param request body UpdateSubtaskRequest true "Update Subtask Request"
+// @Success 200 {object} TodoResponse "Subtask updated successfully"
+// @Failure 400 {object} TodoResponse "Invalid request, todo ID or subtask ID"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 403 {object} TodoResponse "Workspace guests cannot change todos"
+// @Failure 412 {object} TodoResponse "Modified since it was read; data holds the current version"
+// @Failure 428 {object} TodoResponse "If-Match header is required"
+// @Router /todos/{id}/subtasks/{subtaskId} [put]
+func (h *TodoHandler) UpdateSubtask(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid todo ID")
+		return
+	}
+
+	subtaskID, err := strconv.ParseUint(c.Param("subtaskId"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid subtask ID")
+		return
+	}
+
+	var req UpdateSubtaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.Title != "" {
+		updates["title"] = req.Title
+	}
+	if req.IsCompleted != "" {
+		updates["is_completed"] = req.IsCompleted
+	}
+
+	ifMatch, ok := parseIfMatch(c, h.requireIfMatch)
+	if !ok {
+		return
+	}
+
+	subtask, err := h.todoService.UpdateSubtask(uint(todoID), uint(subtaskID), userID.(uint), updates, ifMatch)
+	if err != nil {
+		if respondConflict(c, err) {
+			return
+		}
+		utils.ErrorResponse(c, todoErrorStatus(err), err.Error())
+		return
+	}
+
+	setETag(c, subtask.Version)
+	utils.SuccessResponse(c, "Subtask updated successfully", subtask)
+}
+
+// GetSubtaskRules godoc
+// @Summary Get subtask rules
+// @Description Get the authenticated user's rules for completing a todo when all of its subtasks are done and reopening it when one is unchecked
+// @Tags Todos
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} TodoResponse "Subtask rules retrieved successfully"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 500 {object} TodoResponse "Internal server error"
+// @Router /todos/subtask-rules [get]
+func (h *TodoHandler) GetSubtaskRules(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	rules, err := h.todoService.GetSubtaskRules(userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Subtask rules retrieved successfully", rules)
+}
+
+// UpdateSubtaskRules godoc
+// @Summary Update subtask rules
+// @Description Turn auto-completion and auto-reopening of todos on or off. Omitted fields keep their current value. The rules apply to subtasks the user checks or unchecks.
+// @Tags Todos
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body SubtaskRulesRequest true "Subtask Rules Request"
+// @Success 200 {object} TodoResponse "Subtask rules updated successfully"
+// @Failure 400 {object} TodoResponse "Invalid request"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 500 {object} TodoResponse "Internal server error"
+// @Router /todos/subtask-rules [put]
+func (h *TodoHandler) UpdateSubtaskRules(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req SubtaskRulesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	current, err := h.todoService.GetSubtaskRules(userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	autoComplete, autoReopen := current.AutoComplete, current.AutoReopen
+	if req.AutoComplete != nil {
+		autoComplete = *req.AutoComplete
+	}
+	if req.AutoReopen != nil {
+		autoReopen = *req.AutoReopen
+	}
+
+	rules, err := h.todoService.UpdateSubtaskRules(userID.(uint), autoComplete, autoReopen)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Subtask rules updated successfully", rules)
+}
+
+// GetByPublicID godoc
+// @Summary Get todo by public ID
+// @Description Get detail of a todo (and subtasks) using its public ID
+// @Tags todos
+// @Param id path int true "Public ID of the Todo"
+// @Produce json
+// @Success 200 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /todos/public/{id} [get]
+func (h *TodoHandler) GetByPublicID(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid todo ID format")
+		return
+	}
+
+	todo, err := h.todoService.GetByIDPublic(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Todo not found")
+		return
+	}
+
+	utils.SuccessResponse(c, "Todo retrieved successfully", todo)
+}
+
+// GetBlockers godoc
+// @Summary List blockers of a todo
+// @Description List the todos that must be done before this todo can be completed
+// @Tags Todos
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Success 200 {object} TodoResponse "Blockers retrieved successfully"
+// @Failure 400 {object} TodoResponse "Invalid todo ID"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Router /todos/{id}/blockers [get]
+func (h *TodoHandler) GetBlockers(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid todo ID")
+		return
+	}
+
+	blockers, err := h.todoService.GetBlockers(uint(todoID), userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Blockers retrieved successfully", blockers)
+}
+
+// AddBlocker godoc
+// @Summary Add a blocker to a todo
+// @Description Mark another todo as blocking this one. Dependencies that would create a cycle are rejected.
+// @Tags Todos
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Param request body AddBlockerRequest true "Add Blocker Request"
+// @Success 200 {object} TodoResponse "Blocker added successfully"
+// @Failure 400 {object} TodoResponse "Invalid request or todo ID"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 403 {object} TodoResponse "Workspace guests cannot change todos"
+// @Failure 409 {object} TodoResponse "Dependency already exists or would create a cycle"
+// @Router /todos/{id}/blockers [post]
+func (h *TodoHandler) AddBlocker(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid todo ID")
+		return
+	}
+
+	var req AddBlockerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	dependency, err := h.todoService.AddBlocker(uint(todoID), req.BlockerID, userID.(uint))
+	if err != nil {
+		switch err.Error() {
+		case "dependency would create a cycle", "dependency already exists":
+			utils.ErrorResponse(c, http.StatusConflict, err.Error())
+		default:
+			utils.ErrorResponse(c, todoErrorStatus(err), err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, "Blocker added successfully", dependency)
+}
+
+// RemoveBlocker godoc
+// @Summary Remove a blocker from a todo
+// @Tags Todos
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Param blockerId path int true "Blocking todo ID"
+// @Success 200 {object} TodoResponse "Blocker removed successfully"
+// @Failure 400 {object} TodoResponse "Invalid request or todo ID"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 403 {object} TodoResponse "Workspace guests cannot change todos"
+// @Router /todos/{id}/blockers/{blockerId} [delete]
+func (h *TodoHandler) RemoveBlocker(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid todo ID")
+		return
+	}
+
+	blockerID, err := strconv.ParseUint(c.Param("blockerId"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid blocker ID")
+		return
+	}
+
+	if err := h.todoService.RemoveBlocker(uint(todoID), uint(blockerID), userID.(uint)); err != nil {
+		utils.ErrorResponse(c, todoErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Blocker removed successfully", nil)
+}
+
+// GetBoard godoc
+// @Summary Get the Kanban board
+// @Description Get the authenticated user's todos grouped into todo, inprogress and done columns, each in manual order
+// @Tags Todos
+// @Produce json
+// @Security BearerAuth
+// @Param category query string false "Filter by category" Enums(personal, work, shopping, health, other)
+// @Success 200 {object} TodoResponse "Board retrieved successfully"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 500 {object} TodoResponse "Internal server error"
+// @Router /board [get]
+func (h *TodoHandler) GetBoard(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	columns, err := h.todoService.GetBoard(c.GetUint("workspaceID"), userID.(uint), c.Query("category"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Board retrieved successfully", columns)
+}
+
+// MoveTodo godoc
+// @Summary Move a todo on the Kanban board
+// @Description Move a todo to a status column and place it between two neighbours. after_id is the todo directly above and before_id the todo directly below; with neither the todo goes to the end of the column.
+// @Tags Todos
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Param request body MoveTodoRequest true "Move Todo Request"
+// @Success 200 {object} TodoResponse "Todo moved successfully"
+// @Failure 400 {object} TodoResponse "Invalid request, todo ID or neighbour"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 403 {object} TodoResponse "Workspace guests cannot change todos"
+// @Failure 409 {object} TodoResponse "Todo is blocked by open todos"
+// @Failure 422 {object} TodoResponse "Invalid status, transition not allowed or neighbours out of order"
+// @Router /todos/{id}/move [post]
+func (h *TodoHandler) MoveTodo(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid todo ID")
+		return
+	}
+
+	var req MoveTodoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	todo, err := h.todoService.MoveTodo(uint(todoID), userID.(uint), services.MoveOperation{
+		Status:   req.Status,
+		AfterID:  req.AfterID,
+		BeforeID: req.BeforeID,
+	})
+	if err != nil {
+		if respondConflict(c, err) {
+			return
+		}
+		utils.ErrorResponse(c, todoErrorStatus(err), err.Error())
+		return
+	}
+
+	setETag(c, todo.Version)
+	utils.SuccessResponse(c, "Todo moved successfully", todo)
+}
+
+// todoErrorStatus maps errors of todo changes onto HTTP status codes.
+func todoErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrInvalidValue), errors.Is(err, services.ErrInvalidTransition):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, services.ErrTodoBlocked), errors.Is(err, services.ErrPatchTestFailed):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrWorkspaceForbidden):
+		return http.StatusForbidden
+	}
+	return http.StatusBadRequest
 }
@@ -0,0 +1,266 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"task-management/internal/models"
+	"task-management/internal/services"
+	"task-management/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler struct {
+	webhookService services.WebhookService
+}
+
+type CreateWebhookRequest struct {
+	URL    string             `json:"url" binding:"required" example:"https://example.com/hooks/todos"`
+	Events []models.EventType `json:"events" binding:"required" example:"todo.created,todo.completed"`
+}
+
+type UpdateWebhookRequest struct {
+	URL      string             `json:"url" example:"https://example.com/hooks/todos"`
+	Events   []models.EventType `json:"events" example:"todo.updated"`
+	IsActive *bool              `json:"is_active" example:"true"`
+}
+
+func NewWebhookHandler(webhookService services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// CreateWebhook godoc
+// @Summary Register a webhook
+// @Description Register an endpoint that receives signed events for the todos of every workspace the user belongs to. The signing secret is only returned once.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateWebhookRequest true "Create Webhook Request"
+// @Success 200 {object} TodoResponse "Webhook created successfully"
+// @Failure 400 {object} TodoResponse "Invalid request"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Router /webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	webhook, err := h.webhookService.CreateWebhook(userID.(uint), req.URL, req.Events)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Webhook created successfully", gin.H{
+		"webhook": webhook,
+		"secret":  webhook.Secret,
+	})
+}
+
+// GetWebhooks godoc
+// @Summary List webhooks
+// @Description List the webhooks registered by the authenticated user
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} TodoResponse "Webhooks retrieved successfully"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Router /webhooks [get]
+func (h *WebhookHandler) GetWebhooks(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	webhooks, err := h.webhookService.GetWebhooks(userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Webhooks retrieved successfully", webhooks)
+}
+
+// GetWebhook godoc
+// @Summary Get a webhook
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} TodoResponse "Webhook retrieved successfully"
+// @Failure 404 {object} TodoResponse "Webhook not found"
+// @Router /webhooks/{id} [get]
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	webhookID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid webhook ID")
+		return
+	}
+
+	webhook, err := h.webhookService.GetWebhookByID(uint(webhookID), userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Webhook retrieved successfully", webhook)
+}
+
+// UpdateWebhook godoc
+// @Summary Update a webhook
+// @Description Change the url, subscribed events or active flag of a webhook
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Webhook ID"
+// @Param request body UpdateWebhookRequest true "Update Webhook Request"
+// @Success 200 {object} TodoResponse "Webhook updated successfully"
+// @Failure 400 {object} TodoResponse "Invalid request or webhook ID"
+// @Router /webhooks/{id} [put]
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	webhookID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid webhook ID")
+		return
+	}
+
+	var req UpdateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	webhook, err := h.webhookService.UpdateWebhook(uint(webhookID), userID.(uint), req.URL, req.Events, req.IsActive)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Webhook updated successfully", webhook)
+}
+
+// DeleteWebhook godoc
+// @Summary Delete a webhook
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} TodoResponse "Webhook deleted successfully"
+// @Failure 400 {object} TodoResponse "Invalid request or webhook ID"
+// @Router /webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	webhookID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid webhook ID")
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhook(uint(webhookID), userID.(uint)); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Webhook deleted successfully", nil)
+}
+
+// GetDeliveries godoc
+// @Summary List webhook deliveries
+// @Description List the most recent delivery attempts of a webhook
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} TodoResponse "Deliveries retrieved successfully"
+// @Failure 404 {object} TodoResponse "Webhook not found"
+// @Router /webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) GetDeliveries(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	webhookID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid webhook ID")
+		return
+	}
+
+	deliveries, err := h.webhookService.GetDeliveries(uint(webhookID), userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Deliveries retrieved successfully", deliveries)
+}
+
+// Redeliver godoc
+// @Summary Redeliver a webhook event
+// @Description Send the payload of a previous delivery again, with a fresh retry schedule
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Webhook ID"
+// @Param deliveryId path int true "Delivery ID"
+// @Success 200 {object} TodoResponse "Redelivery scheduled"
+// @Failure 400 {object} TodoResponse "Invalid request"
+// @Failure 404 {object} TodoResponse "Webhook or delivery not found"
+// @Router /webhooks/{id}/deliveries/{deliveryId}/redeliver [post]
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	webhookID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid webhook ID")
+		return
+	}
+
+	deliveryID, err := strconv.ParseUint(c.Param("deliveryId"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid delivery ID")
+		return
+	}
+
+	if err := h.webhookService.Redeliver(uint(webhookID), uint(deliveryID), userID.(uint)); err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Redelivery scheduled", nil)
+}
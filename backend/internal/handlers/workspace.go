@@ -0,0 +1,637 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"task-management/internal/models"
+	"task-management/internal/services"
+	"task-management/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WorkspaceHeader selects the workspace a request acts on. Without it the
+// workspace the user last switched to is used, or their personal one.
+const WorkspaceHeader = "X-Workspace-ID"
+
+type WorkspaceHandler struct {
+	workspaceService services.WorkspaceService
+}
+
+func NewWorkspaceHandler(workspaceService services.WorkspaceService) *WorkspaceHandler {
+	return &WorkspaceHandler{
+		workspaceService: workspaceService,
+	}
+}
+
+type WorkspaceRequest struct {
+	Name string `json:"name" binding:"required" example:"Marketing"`
+}
+
+type UpdateMemberRequest struct {
+	Role models.WorkspaceRole `json:"role" binding:"required" example:"member"`
+}
+
+type InviteRequest struct {
+	Username string               `json:"username" example:"jane"`
+	Email    string               `json:"email" example:"jane@example.com"`
+	Role     models.WorkspaceRole `json:"role" example:"member"`
+}
+
+// ResolveWorkspace is a middleware that stores the ID and role of the
+// workspace the request acts on in the context as workspaceID and
+// workspaceRole. It must run after the auth middleware.
+func (h *WorkspaceHandler) ResolveWorkspace(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		c.Abort()
+		return
+	}
+
+	var requested uint64
+	if header := c.GetHeader(WorkspaceHeader); header != "" {
+		var err error
+		requested, err = strconv.ParseUint(header, 10, 32)
+		if err != nil || requested == 0 {
+			utils.ValidationErrorResponse(c, "Invalid "+WorkspaceHeader+" header")
+			c.Abort()
+			return
+		}
+	}
+
+	workspace, err := h.workspaceService.ResolveWorkspace(userID.(uint), uint(requested))
+	if err != nil {
+		utils.ErrorResponse(c, workspaceErrorStatus(err), err.Error())
+		c.Abort()
+		return
+	}
+
+	c.Set("workspaceID", workspace.ID)
+	c.Set("workspaceRole", workspace.Role)
+	c.Header(WorkspaceHeader, strconv.FormatUint(uint64(workspace.ID), 10))
+	c.Next()
+}
+
+// GetWorkspaces godoc
+// @Summary List workspaces
+// @Description List the workspaces the authenticated user belongs to with their role in each, personal workspace first
+// @Tags Workspaces
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} TodoResponse "Workspaces retrieved successfully"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 500 {object} TodoResponse "Internal server error"
+// @Router /workspaces [get]
+func (h *WorkspaceHandler) GetWorkspaces(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	workspaces, err := h.workspaceService.GetWorkspaces(userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Workspaces retrieved successfully", workspaces)
+}
+
+// CreateWorkspace godoc
+// @Summary Create a workspace
+// @Description Create a shared workspace owned by the authenticated user
+// @Tags Workspaces
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body WorkspaceRequest true "Workspace Request"
+// @Success 200 {object} TodoResponse "Workspace created successfully"
+// @Failure 400 {object} TodoResponse "Invalid request"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 422 {object} TodoResponse "Validation error"
+// @Router /workspaces [post]
+func (h *WorkspaceHandler) CreateWorkspace(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req WorkspaceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	workspace, err := h.workspaceService.CreateWorkspace(userID.(uint), req.Name)
+	if err != nil {
+		utils.ErrorResponse(c, workspaceErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Workspace created successfully", workspace)
+}
+
+// GetWorkspace godoc
+// @Summary Get a workspace
+// @Tags Workspaces
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Workspace ID"
+// @Success 200 {object} TodoResponse "Workspace retrieved successfully"
+// @Failure 400 {object} TodoResponse "Invalid workspace ID"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 404 {object} TodoResponse "Workspace not found"
+// @Router /workspaces/{id} [get]
+func (h *WorkspaceHandler) GetWorkspace(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	workspaceID, ok := parseWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	workspace, err := h.workspaceService.GetWorkspace(workspaceID, userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, workspaceErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Workspace retrieved successfully", workspace)
+}
+
+// UpdateWorkspace godoc
+// @Summary Rename a workspace
+// @Description Rename a workspace. Requires the admin role.
+// @Tags Workspaces
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Workspace ID"
+// @Param request body WorkspaceRequest true "Workspace Request"
+// @Success 200 {object} TodoResponse "Workspace updated successfully"
+// @Failure 400 {object} TodoResponse "Invalid request or workspace ID"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 403 {object} TodoResponse "Insufficient workspace role"
+// @Failure 404 {object} TodoResponse "Workspace not found"
+// @Failure 422 {object} TodoResponse "Validation error"
+// @Router /workspaces/{id} [put]
+func (h *WorkspaceHandler) UpdateWorkspace(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	workspaceID, ok := parseWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	var req WorkspaceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	workspace, err := h.workspaceService.UpdateWorkspace(workspaceID, userID.(uint), req.Name)
+	if err != nil {
+		utils.ErrorResponse(c, workspaceErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Workspace updated successfully", workspace)
+}
+
+// DeleteWorkspace godoc
+// @Summary Delete a workspace
+// @Description Delete a shared workspace together with its todos. Only the owner can delete it; the personal workspace cannot be deleted.
+// @Tags Workspaces
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Workspace ID"
+// @Success 200 {object} TodoResponse "Workspace deleted successfully"
+// @Failure 400 {object} TodoResponse "Invalid request or workspace ID"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 403 {object} TodoResponse "Insufficient workspace role"
+// @Failure 404 {object} TodoResponse "Workspace not found"
+// @Router /workspaces/{id} [delete]
+func (h *WorkspaceHandler) DeleteWorkspace(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	workspaceID, ok := parseWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.workspaceService.DeleteWorkspace(workspaceID, userID.(uint)); err != nil {
+		utils.ErrorResponse(c, workspaceErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Workspace deleted successfully", nil)
+}
+
+// SwitchWorkspace godoc
+// @Summary Switch the current workspace
+// @Description Make a workspace the default for requests without an X-Workspace-ID header
+// @Tags Workspaces
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Workspace ID"
+// @Success 200 {object} TodoResponse "Workspace switched successfully"
+// @Failure 400 {object} TodoResponse "Invalid workspace ID"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 404 {object} TodoResponse "Workspace not found"
+// @Router /workspaces/{id}/switch [post]
+func (h *WorkspaceHandler) SwitchWorkspace(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	workspaceID, ok := parseWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	workspace, err := h.workspaceService.SwitchWorkspace(workspaceID, userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, workspaceErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Workspace switched successfully", workspace)
+}
+
+// GetMembers godoc
+// @Summary List workspace members
+// @Tags Workspaces
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Workspace ID"
+// @Success 200 {object} TodoResponse "Members retrieved successfully"
+// @Failure 400 {object} TodoResponse "Invalid workspace ID"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 404 {object} TodoResponse "Workspace not found"
+// @Router /workspaces/{id}/members [get]
+func (h *WorkspaceHandler) GetMembers(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	workspaceID, ok := parseWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	members, err := h.workspaceService.GetMembers(workspaceID, userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, workspaceErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Members retrieved successfully", members)
+}
+
+// UpdateMember godoc
+// @Summary Change a member's role
+// @Description Admins can change the role of members and guests. Only the owner can appoint or demote admins; giving someone the owner role transfers ownership and makes the previous owner an admin.
+// @Tags Workspaces
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Workspace ID"
+// @Param userId path int true "User ID of the member"
+// @Param request body UpdateMemberRequest true "Update Member Request"
+// @Success 200 {object} TodoResponse "Member updated successfully"
+// @Failure 400 {object} TodoResponse "Invalid request, workspace or user ID"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 403 {object} TodoResponse "Insufficient workspace role"
+// @Failure 404 {object} TodoResponse "Workspace or member not found"
+// @Failure 422 {object} TodoResponse "Invalid role"
+// @Router /workspaces/{id}/members/{userId} [put]
+func (h *WorkspaceHandler) UpdateMember(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	workspaceID, memberID, ok := parseMemberParams(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	if err := h.workspaceService.UpdateMemberRole(workspaceID, userID.(uint), memberID, req.Role); err != nil {
+		utils.ErrorResponse(c, workspaceErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Member updated successfully", nil)
+}
+
+// RemoveMember godoc
+// @Summary Remove a member or leave a workspace
+// @Description Remove a member from the workspace. Anyone but the owner can remove themselves; removing others requires the admin role, and only the owner can remove admins.
+// @Tags Workspaces
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Workspace ID"
+// @Param userId path int true "User ID of the member"
+// @Success 200 {object} TodoResponse "Member removed successfully"
+// @Failure 400 {object} TodoResponse "Invalid request, workspace or user ID"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 403 {object} TodoResponse "Insufficient workspace role"
+// @Failure 404 {object} TodoResponse "Workspace or member not found"
+// @Router /workspaces/{id}/members/{userId} [delete]
+func (h *WorkspaceHandler) RemoveMember(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	workspaceID, memberID, ok := parseMemberParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.workspaceService.RemoveMember(workspaceID, userID.(uint), memberID); err != nil {
+		utils.ErrorResponse(c, workspaceErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Member removed successfully", nil)
+}
+
+// GetInvitations godoc
+// @Summary List pending invitations of a workspace
+// @Description Requires the admin role
+// @Tags Workspaces
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Workspace ID"
+// @Success 200 {object} TodoResponse "Invitations retrieved successfully"
+// @Failure 400 {object} TodoResponse "Invalid workspace ID"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 403 {object} TodoResponse "Insufficient workspace role"
+// @Failure 404 {object} TodoResponse "Workspace not found"
+// @Router /workspaces/{id}/invitations [get]
+func (h *WorkspaceHandler) GetInvitations(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	workspaceID, ok := parseWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	invitations, err := h.workspaceService.GetInvitations(workspaceID, userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, workspaceErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Invitations retrieved successfully", invitations)
+}
+
+// InviteMember godoc
+// @Summary Invite someone to a workspace
+// @Description Invite a user by username or an email address, which may belong to someone without an account yet. Requires the admin role; only the owner can invite admins. The role defaults to member.
+// @Tags Workspaces
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Workspace ID"
+// @Param request body InviteRequest true "Invite Request"
+// @Success 200 {object} TodoResponse "Invitation sent successfully"
+// @Failure 400 {object} TodoResponse "Invalid request or already a member"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 403 {object} TodoResponse "Insufficient workspace role"
+// @Failure 404 {object} TodoResponse "Workspace or user not found"
+// @Failure 422 {object} TodoResponse "Validation error"
+// @Router /workspaces/{id}/invitations [post]
+func (h *WorkspaceHandler) InviteMember(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	workspaceID, ok := parseWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	var req InviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	invitation, err := h.workspaceService.Invite(workspaceID, userID.(uint), services.InviteInput{
+		Username: req.Username,
+		Email:    req.Email,
+		Role:     req.Role,
+	})
+	if err != nil {
+		utils.ErrorResponse(c, workspaceErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Invitation sent successfully", invitation)
+}
+
+// RevokeInvitation godoc
+// @Summary Revoke an invitation
+// @Description Requires the admin role
+// @Tags Workspaces
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Workspace ID"
+// @Param invitationId path int true "Invitation ID"
+// @Success 200 {object} TodoResponse "Invitation revoked successfully"
+// @Failure 400 {object} TodoResponse "Invalid workspace or invitation ID"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 403 {object} TodoResponse "Insufficient workspace role"
+// @Failure 404 {object} TodoResponse "Workspace or invitation not found"
+// @Router /workspaces/{id}/invitations/{invitationId} [delete]
+func (h *WorkspaceHandler) RevokeInvitation(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	workspaceID, ok := parseWorkspaceID(c)
+	if !ok {
+		return
+	}
+
+	invitationID, err := strconv.ParseUint(c.Param("invitationId"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid invitation ID")
+		return
+	}
+
+	if err := h.workspaceService.RevokeInvitation(workspaceID, uint(invitationID), userID.(uint)); err != nil {
+		utils.ErrorResponse(c, workspaceErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Invitation revoked successfully", nil)
+}
+
+// GetMyInvitations godoc
+// @Summary List invitations for the authenticated user
+// @Description List pending workspace invitations addressed to the authenticated user's account or email address
+// @Tags Workspaces
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} TodoResponse "Invitations retrieved successfully"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 500 {object} TodoResponse "Internal server error"
+// @Router /invitations [get]
+func (h *WorkspaceHandler) GetMyInvitations(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	invitations, err := h.workspaceService.GetMyInvitations(userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Invitations retrieved successfully", invitations)
+}
+
+// AcceptInvitation godoc
+// @Summary Accept an invitation
+// @Description Join the workspace with the role given in the invitation
+// @Tags Workspaces
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Invitation ID"
+// @Success 200 {object} TodoResponse "Invitation accepted successfully"
+// @Failure 400 {object} TodoResponse "Invalid invitation ID"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 404 {object} TodoResponse "Invitation not found"
+// @Router /invitations/{id}/accept [post]
+func (h *WorkspaceHandler) AcceptInvitation(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	invitationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid invitation ID")
+		return
+	}
+
+	workspace, err := h.workspaceService.AcceptInvitation(uint(invitationID), userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, workspaceErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Invitation accepted successfully", workspace)
+}
+
+// DeclineInvitation godoc
+// @Summary Decline an invitation
+// @Tags Workspaces
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Invitation ID"
+// @Success 200 {object} TodoResponse "Invitation declined successfully"
+// @Failure 400 {object} TodoResponse "Invalid invitation ID"
+// @Failure 401 {object} TodoResponse "Unauthorized"
+// @Failure 404 {object} TodoResponse "Invitation not found"
+// @Router /invitations/{id}/decline [post]
+func (h *WorkspaceHandler) DeclineInvitation(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	invitationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid invitation ID")
+		return
+	}
+
+	if err := h.workspaceService.DeclineInvitation(uint(invitationID), userID.(uint)); err != nil {
+		utils.ErrorResponse(c, workspaceErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Invitation declined successfully", nil)
+}
+
+func parseWorkspaceID(c *gin.Context) (uint, bool) {
+	workspaceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid workspace ID")
+		return 0, false
+	}
+	return uint(workspaceID), true
+}
+
+func parseMemberParams(c *gin.Context) (uint, uint, bool) {
+	workspaceID, ok := parseWorkspaceID(c)
+	if !ok {
+		return 0, 0, false
+	}
+
+	memberID, err := strconv.ParseUint(c.Param("userId"), 10, 32)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid user ID")
+		return 0, 0, false
+	}
+	return workspaceID, uint(memberID), true
+}
+
+func workspaceErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrWorkspaceForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrInvalidValue):
+		return http.StatusUnprocessableEntity
+	case strings.HasSuffix(err.Error(), "not found"):
+		return http.StatusNotFound
+	case err.Error() == "database error":
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
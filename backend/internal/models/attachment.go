@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+)
+
+type Attachment struct {
+	ID          uint      `json:"id" gorm:"primaryKey;column:attachment_id"`
+	TodoID      uint      `json:"todo_id" gorm:"not null;index"`
+	UserID      uint      `json:"user_id" gorm:"not null;index"`
+	FileName    string    `json:"file_name" gorm:"not null"`
+	ContentType string    `json:"content_type" gorm:"type:varchar(255)"`
+	Size        int64     `json:"size"`
+	Checksum    string    `json:"checksum" gorm:"type:varchar(64)"`
+	StorageKey  string    `json:"-" gorm:"not null;uniqueIndex"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (Attachment) TableName() string {
+	return "attachments"
+}
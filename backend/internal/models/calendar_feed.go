@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+)
+
+// CalendarFeed holds the secret token of a user's iCalendar subscription.
+// Only the SHA-256 hash of the token is stored.
+type CalendarFeed struct {
+	ID        uint      `json:"id" gorm:"primaryKey;column:calendar_feed_id"`
+	UserID    uint      `json:"user_id" gorm:"not null;uniqueIndex"`
+	TokenHash string    `json:"-" gorm:"type:varchar(64);not null;uniqueIndex"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (CalendarFeed) TableName() string {
+	return "calendar_feeds"
+}
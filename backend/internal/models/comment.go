@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Comment struct {
+	ID        uint           `json:"id" gorm:"primaryKey;column:comment_id"`
+	TodoID    uint           `json:"todo_id" gorm:"not null;index"`
+	UserID    uint           `json:"user_id" gorm:"not null"`
+	Body      string         `json:"body" gorm:"type:text;not null"`
+	Mentions  []string       `json:"mentions" gorm:"type:text;serializer:json"`
+	EditedAt  *time.Time     `json:"edited_at"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+func (Comment) TableName() string {
+	return "comments"
+}
+
+// CommentRevision keeps the body a comment had before an edit.
+type CommentRevision struct {
+	ID        uint      `json:"id" gorm:"primaryKey;column:comment_revision_id"`
+	CommentID uint      `json:"comment_id" gorm:"not null;index"`
+	Body      string    `json:"body" gorm:"type:text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (CommentRevision) TableName() string {
+	return "comment_revisions"
+}
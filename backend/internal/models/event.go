@@ -0,0 +1,31 @@
+package models
+
+type EventType string
+
+const (
+	EventTodoCreated      EventType = "todo.created"
+	EventTodoUpdated      EventType = "todo.updated"
+	EventTodoCompleted    EventType = "todo.completed"
+	EventTodoDeleted      EventType = "todo.deleted"
+	EventTodoRestored     EventType = "todo.restored"
+	EventSubtaskCompleted EventType = "subtask.completed"
+)
+
+// EventTypes lists every event a todo mutation can emit.
+var EventTypes = []EventType{
+	EventTodoCreated,
+	EventTodoUpdated,
+	EventTodoCompleted,
+	EventTodoDeleted,
+	EventTodoRestored,
+	EventSubtaskCompleted,
+}
+
+func (e EventType) IsValid() bool {
+	for _, t := range EventTypes {
+		if t == e {
+			return true
+		}
+	}
+	return false
+}
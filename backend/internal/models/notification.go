@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+type NotificationType string
+
+const (
+	NotificationMention         NotificationType = "mention"
+	NotificationWorkspaceInvite NotificationType = "workspace_invitation"
+	NotificationTodoAssigned    NotificationType = "todo_assigned"
+	NotificationTodoUnassigned  NotificationType = "todo_unassigned"
+)
+
+type Notification struct {
+	ID        uint             `json:"id" gorm:"primaryKey;column:notification_id"`
+	UserID    uint             `json:"user_id" gorm:"not null;index"`
+	ActorID   uint             `json:"actor_id"`
+	Type      NotificationType `json:"type" gorm:"type:varchar(50);not null"`
+	TodoID    *uint            `json:"todo_id"`
+	CommentID *uint            `json:"comment_id"`
+	Message   string           `json:"message"`
+	ReadAt    *time.Time       `json:"read_at"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+func (Notification) TableName() string {
+	return "notifications"
+}
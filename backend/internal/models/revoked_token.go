@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// RevokedToken blocks a single access token by its ID until the token would
+// have expired anyway.
+type RevokedToken struct {
+	TokenID   string    `json:"token_id" gorm:"primaryKey;column:token_id;size:64"`
+	UserID    uint      `json:"user_id" gorm:"not null;index"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"not null;index"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (RevokedToken) TableName() string {
+	return "revoked_tokens"
+}
@@ -2,6 +2,7 @@ package models
 
 import (
     "time"
+    "gorm.io/gorm"
 )
 
 type CompletionStatus string
@@ -17,8 +18,10 @@ type Subtask struct {
     Title       string           `json:"title" gorm:"not null"`
     IsCompleted CompletionStatus `json:"is_completed" gorm:"type:varchar(10);default:no"`
     CompletedAt *time.Time       `json:"completed_at"`
+    Version     uint             `json:"version" gorm:"not null;default:1"`
     CreatedAt   time.Time        `json:"created_at"`
     UpdatedAt   time.Time        `json:"updated_at"`
+    DeletedAt   gorm.DeletedAt   `json:"-" gorm:"index"`
     
     // Relations
     Todo Todo `json:"todo,omitempty" gorm:"foreignKey:TodoID"`
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+)
+
+// SubtaskRules are a user's automations for parent todos, applied when the
+// user checks or unchecks a subtask. Both are off by default.
+type SubtaskRules struct {
+	UserID uint `json:"-" gorm:"primaryKey;autoIncrement:false"`
+	// AutoComplete moves the parent to done once every subtask is completed.
+	AutoComplete bool `json:"auto_complete" gorm:"not null;default:false"`
+	// AutoReopen moves a done parent back to in progress when one of its
+	// subtasks is unchecked.
+	AutoReopen bool      `json:"auto_reopen" gorm:"not null;default:false"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func (SubtaskRules) TableName() string {
+	return "subtask_rules"
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TodoTemplate is a reusable todo with its subtasks, such as a release or
+// onboarding checklist. Title, description and subtask titles may contain
+// {{variables}} that are filled in when the template is instantiated, and
+// the due date is an offset from the moment of instantiation. Whole days of
+// the offset are calendar days, not 24 hours.
+type TodoTemplate struct {
+	ID          uint           `json:"id" gorm:"primaryKey;column:todo_template_id"`
+	WorkspaceID uint           `json:"workspace_id" gorm:"not null;index"`
+	UserID      uint           `json:"user_id" gorm:"not null"`
+	Name        string         `json:"name" gorm:"not null"`
+	Title       string         `json:"title" gorm:"not null"`
+	Description string         `json:"description"`
+	Priority    Priority       `json:"priority" gorm:"type:varchar(50);default:medium"`
+	Category    Category       `json:"category" gorm:"type:varchar(50);default:personal"`
+	DueOffset   *int           `json:"due_offset_minutes"`
+	Estimate    *int           `json:"estimate_minutes"`
+	Subtasks    []string       `json:"subtasks" gorm:"type:text;serializer:json"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Variables lists the distinct {{variables}} the template uses. It is
+	// computed on read and never stored.
+	Variables []string `json:"variables" gorm:"-"`
+}
+
+func (TodoTemplate) TableName() string {
+	return "todo_templates"
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+)
+
+// TimeEntry is a span of time a user spent on a todo. A running timer is an
+// entry without EndedAt; a user can have at most one. Duration is stored in
+// whole seconds once the entry has ended, so totals are plain sums.
+type TimeEntry struct {
+	ID        uint       `json:"id" gorm:"primaryKey;column:time_entry_id"`
+	TodoID    uint       `json:"todo_id" gorm:"not null;index"`
+	UserID    uint       `json:"user_id" gorm:"not null;index;uniqueIndex:idx_time_entries_running,where:ended_at IS NULL"`
+	Note      string     `json:"note" gorm:"type:text"`
+	StartedAt time.Time  `json:"started_at" gorm:"not null;index"`
+	EndedAt   *time.Time `json:"ended_at"`
+	Duration  int64      `json:"duration_seconds" gorm:"not null;default:0"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+func (TimeEntry) TableName() string {
+	return "time_entries"
+}
+
+// Running reports whether the entry is a timer that has not been stopped.
+func (e *TimeEntry) Running() bool {
+	return e.EndedAt == nil
+}
+
+// Stop ends the entry at end and records its duration.
+func (e *TimeEntry) Stop(end time.Time) {
+	e.EndedAt = &end
+	e.Duration = int64(end.Sub(e.StartedAt) / time.Second)
+}
@@ -32,23 +32,65 @@ const (
 type Todo struct {
     ID          uint       `json:"id" gorm:"primaryKey;column:todo_id"`
     PublicID    uint       `json:"public_id" gorm:"uniqueIndex;autoIncrement"`
-    UserID      uint       `json:"user_id" gorm:"not null"`
+    UserID      uint       `json:"user_id" gorm:"not null;index"`
+    WorkspaceID uint       `json:"workspace_id" gorm:"index;index:idx_todos_workspace_board,priority:1"`
     CategoryID  *uint      `json:"category_id"`
     Title       string     `json:"title" gorm:"not null"`
     Description string     `json:"description"`
     Priority    Priority   `json:"priority" gorm:"type:varchar(50);default:medium"`
     Category    Category   `json:"category" gorm:"type:varchar(50);default:personal"`
-    Status      Status     `json:"status" gorm:"type:varchar(50);default:todo"`
+    Status      Status     `json:"status" gorm:"type:varchar(50);default:todo;index:idx_todos_workspace_board,priority:2"`
     DueDate     *time.Time `json:"due_date"`
+    Estimate    *int       `json:"estimate_minutes"`
+    StartedAt   *time.Time `json:"started_at"`
+    CompletedAt *time.Time `json:"completed_at"`
+    Rank        string     `json:"rank" gorm:"type:varchar(255);not null;default:'';index:idx_todos_workspace_board,priority:3"`
+    Version     uint       `json:"version" gorm:"not null;default:1"`
     CreatedAt   time.Time  `json:"created_at"`
     UpdatedAt   time.Time  `json:"updated_at"`
     DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
     
+    // Blocked is set when the todo has at least one open blocker. It is
+    // computed on read and never stored.
+    Blocked     bool       `json:"blocked" gorm:"-"`
+
+    // SubtaskTotal and SubtaskDone count the todo's subtasks and Progress is
+    // the completed share in percent. They are computed by the queries that
+    // list todos and never stored.
+    SubtaskTotal int `json:"subtask_total" gorm:"->;-:migration"`
+    SubtaskDone  int `json:"subtask_done" gorm:"->;-:migration"`
+    Progress     int `json:"progress" gorm:"->;-:migration"`
+    
     // Relations
-    User     User      `json:"user,omitempty" gorm:"foreignKey:UserID"`
-    Subtasks []Subtask `json:"subtasks,omitempty" gorm:"foreignKey:TodoID"`
+    User      User           `json:"user,omitempty" gorm:"foreignKey:UserID"`
+    Subtasks  []Subtask      `json:"subtasks,omitempty" gorm:"foreignKey:TodoID"`
+    Assignees []TodoAssignee `json:"assignees,omitempty" gorm:"foreignKey:TodoID"`
 }
 
 func (Todo) TableName() string {
     return "todos"
-}
\ No newline at end of file
+}
+
+func (p Priority) IsValid() bool {
+    switch p {
+    case PriorityLow, PriorityMedium, PriorityHigh:
+        return true
+    }
+    return false
+}
+
+func (c Category) IsValid() bool {
+    switch c {
+    case CategoryWork, CategoryPersonal, CategoryShopping, CategoryHealth, CategoryOther:
+        return true
+    }
+    return false
+}
+
+func (s Status) IsValid() bool {
+    switch s {
+    case StatusTodo, StatusInProgress, StatusDone:
+        return true
+    }
+    return false
+}
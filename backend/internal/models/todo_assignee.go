@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+)
+
+// TodoAssignee assigns a todo to a member of the todo's workspace. A todo
+// can have several assignees, independent of who created it.
+type TodoAssignee struct {
+	TodoID     uint      `json:"todo_id" gorm:"primaryKey;autoIncrement:false"`
+	UserID     uint      `json:"user_id" gorm:"primaryKey;autoIncrement:false;index"`
+	AssignedBy uint      `json:"assigned_by" gorm:"not null"`
+	CreatedAt  time.Time `json:"created_at"`
+
+	// Relations
+	User *UserSummary `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+func (TodoAssignee) TableName() string {
+	return "todo_assignees"
+}
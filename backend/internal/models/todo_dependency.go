@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+)
+
+// TodoDependency records that TodoID cannot be finished before BlockerID.
+type TodoDependency struct {
+	TodoID    uint      `json:"todo_id" gorm:"primaryKey;autoIncrement:false"`
+	BlockerID uint      `json:"blocker_id" gorm:"primaryKey;autoIncrement:false;index"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Relations
+	Todo    Todo `json:"-" gorm:"foreignKey:TodoID"`
+	Blocker Todo `json:"-" gorm:"foreignKey:BlockerID"`
+}
+
+func (TodoDependency) TableName() string {
+	return "todo_dependencies"
+}
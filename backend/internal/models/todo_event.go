@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+)
+
+// TodoEvent is a persisted change notification. The auto incrementing ID is
+// used as the SSE event id so clients can resume with Last-Event-ID. It is
+// delivered to every member of WorkspaceID; UserID made the change. TodoID
+// is the todo the event is about, so purging the todo removes its events.
+type TodoEvent struct {
+	ID          uint64    `json:"id" gorm:"primaryKey;column:event_id"`
+	WorkspaceID uint      `json:"workspace_id" gorm:"not null;index"`
+	TodoID      *uint     `json:"todo_id" gorm:"index"`
+	UserID      uint      `json:"user_id" gorm:"not null;index"`
+	Type        EventType `json:"type" gorm:"type:varchar(50);not null"`
+	Payload     string    `json:"payload" gorm:"type:text"`
+	CreatedAt   time.Time `json:"created_at" gorm:"index"`
+}
+
+func (TodoEvent) TableName() string {
+	return "todo_events"
+}
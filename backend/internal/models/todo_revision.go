@@ -0,0 +1,130 @@
+package models
+
+import (
+	"time"
+)
+
+type RevisionAction string
+
+const (
+	RevisionCreated  RevisionAction = "created"
+	RevisionUpdated  RevisionAction = "updated"
+	RevisionDeleted  RevisionAction = "deleted"
+	RevisionRestored RevisionAction = "restored"
+	RevisionReverted RevisionAction = "reverted"
+)
+
+// FieldChange holds the previous and the new value of a single field.
+type FieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// TodoSnapshot is the editable state of a todo captured by a revision.
+type TodoSnapshot struct {
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Priority    Priority   `json:"priority"`
+	Category    Category   `json:"category"`
+	CategoryID  *uint      `json:"category_id"`
+	Status      Status     `json:"status"`
+	DueDate     *time.Time `json:"due_date"`
+	Estimate    *int       `json:"estimate_minutes"`
+}
+
+type TodoRevision struct {
+	ID           uint                   `json:"id" gorm:"primaryKey;column:revision_id"`
+	TodoID       uint                   `json:"todo_id" gorm:"not null;uniqueIndex:idx_todo_revision"`
+	Revision     int                    `json:"revision" gorm:"not null;uniqueIndex:idx_todo_revision"`
+	UserID       uint                   `json:"user_id" gorm:"not null"`
+	Action       RevisionAction         `json:"action" gorm:"type:varchar(20);not null"`
+	Changes      map[string]FieldChange `json:"changes" gorm:"type:text;serializer:json"`
+	Snapshot     TodoSnapshot           `json:"snapshot" gorm:"type:text;serializer:json"`
+	RevertedFrom *int                   `json:"reverted_from,omitempty"`
+	CreatedAt    time.Time              `json:"created_at"`
+}
+
+func (TodoRevision) TableName() string {
+	return "todo_revisions"
+}
+
+func (t *Todo) Snapshot() TodoSnapshot {
+	return TodoSnapshot{
+		Title:       t.Title,
+		Description: t.Description,
+		Priority:    t.Priority,
+		Category:    t.Category,
+		CategoryID:  t.CategoryID,
+		Status:      t.Status,
+		DueDate:     t.DueDate,
+		Estimate:    t.Estimate,
+	}
+}
+
+func (t *Todo) ApplySnapshot(s TodoSnapshot) {
+	t.Title = s.Title
+	t.Description = s.Description
+	t.Priority = s.Priority
+	t.Category = s.Category
+	t.CategoryID = s.CategoryID
+	t.Status = s.Status
+	t.DueDate = s.DueDate
+	t.Estimate = s.Estimate
+}
+
+// DiffSnapshots returns the fields that differ between before and after,
+// keyed by their JSON name. A nil before reports every set field of after.
+func DiffSnapshots(before *TodoSnapshot, after TodoSnapshot) map[string]FieldChange {
+	var old TodoSnapshot
+	if before != nil {
+		old = *before
+	}
+
+	changes := make(map[string]FieldChange)
+	if old.Title != after.Title {
+		changes["title"] = FieldChange{Old: old.Title, New: after.Title}
+	}
+	if old.Description != after.Description {
+		changes["description"] = FieldChange{Old: old.Description, New: after.Description}
+	}
+	if old.Priority != after.Priority {
+		changes["priority"] = FieldChange{Old: old.Priority, New: after.Priority}
+	}
+	if old.Category != after.Category {
+		changes["category"] = FieldChange{Old: old.Category, New: after.Category}
+	}
+	if !equalUintPtr(old.CategoryID, after.CategoryID) {
+		changes["category_id"] = FieldChange{Old: old.CategoryID, New: after.CategoryID}
+	}
+	if old.Status != after.Status {
+		changes["status"] = FieldChange{Old: old.Status, New: after.Status}
+	}
+	if !equalTimePtr(old.DueDate, after.DueDate) {
+		changes["due_date"] = FieldChange{Old: old.DueDate, New: after.DueDate}
+	}
+	if !equalIntPtr(old.Estimate, after.Estimate) {
+		changes["estimate_minutes"] = FieldChange{Old: old.Estimate, New: after.Estimate}
+	}
+	return changes
+}
+
+func equalUintPtr(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func equalIntPtr(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func equalTimePtr(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// StatusWorkflow lists, for every status, the statuses a todo may move to.
+type StatusWorkflow map[Status][]Status
+
+// DefaultStatusTransitions is the workflow used unless configured otherwise:
+// todo → inprogress → done, with a done todo allowed to be reopened.
+const DefaultStatusTransitions = "todo:inprogress;inprogress:todo,done;done:todo"
+
+// ParseStatusWorkflow reads a workflow written as
+// "from:to,to;from:to", e.g. DefaultStatusTransitions.
+func ParseStatusWorkflow(spec string) (StatusWorkflow, error) {
+	workflow := StatusWorkflow{}
+	for _, rule := range strings.Split(spec, ";") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		from, targets, ok := strings.Cut(rule, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid transition rule %q", rule)
+		}
+		source := Status(strings.TrimSpace(from))
+		if !source.IsValid() {
+			return nil, fmt.Errorf("invalid status %q in transition rule", source)
+		}
+
+		for _, to := range strings.Split(targets, ",") {
+			target := Status(strings.TrimSpace(to))
+			if target == "" {
+				continue
+			}
+			if !target.IsValid() {
+				return nil, fmt.Errorf("invalid status %q in transition rule", target)
+			}
+			workflow[source] = append(workflow[source], target)
+		}
+	}
+	return workflow, nil
+}
+
+// Allows reports whether a todo may move from one status to another.
+// Staying in the same status is always allowed.
+func (w StatusWorkflow) Allows(from, to Status) bool {
+	if from == to {
+		return true
+	}
+	for _, allowed := range w[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// StampStatus updates StartedAt and CompletedAt after the todo moved from
+// previous to its current status. StartedAt keeps the first start of work
+// and is only cleared when the todo goes back to todo.
+func (t *Todo) StampStatus(previous Status, now time.Time) {
+	if t.Status == previous {
+		return
+	}
+
+	switch t.Status {
+	case StatusTodo:
+		t.StartedAt = nil
+		t.CompletedAt = nil
+	case StatusInProgress:
+		if t.StartedAt == nil {
+			t.StartedAt = &now
+		}
+		t.CompletedAt = nil
+	case StatusDone:
+		t.CompletedAt = &now
+	}
+}
@@ -9,15 +9,35 @@ type User struct {
 	Username     string    `json:"username" gorm:"uniqueIndex;not null"`
 	Email        string    `json:"email" gorm:"uniqueIndex;not null"`
 	PasswordHash string    `json:"-" gorm:"not null"`
-	Password     string    `json:"password" gorm:"not null"`
 	FullName     string    `json:"full_name"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
 	IsActive     bool      `json:"is_active" gorm:"default:true"`
 
+	// CurrentWorkspaceID is the workspace used when a request does not name
+	// one. Nil means the personal workspace.
+	CurrentWorkspaceID *uint `json:"current_workspace_id"`
+
+	// TokensRevokedAt invalidates every token issued up to this moment, set
+	// when the password is reset, the account is deactivated or an admin
+	// revokes the user's sessions.
+	TokensRevokedAt *time.Time `json:"-"`
+
 	Todos []Todo `json:"todos,omitempty" gorm:"foreignKey:UserID"`
 }
 
 func (User) TableName() string {
 	return "users"
 }
+
+// UserSummary is the part of a user other users may see. Relations shown to
+// anyone but the user themselves load it instead of User.
+type UserSummary struct {
+	ID       uint   `json:"id" gorm:"primaryKey;column:user_id"`
+	Username string `json:"username"`
+	FullName string `json:"full_name"`
+}
+
+func (UserSummary) TableName() string {
+	return "users"
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+)
+
+type Webhook struct {
+	ID        uint        `json:"id" gorm:"primaryKey;column:webhook_id"`
+	UserID    uint        `json:"user_id" gorm:"not null;index"`
+	URL       string      `json:"url" gorm:"not null"`
+	Secret    string      `json:"-" gorm:"not null"`
+	Events    []EventType `json:"events" gorm:"type:text;serializer:json"`
+	IsActive  bool        `json:"is_active" gorm:"default:true"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+
+	// Relations
+	User User `json:"-" gorm:"foreignKey:UserID"`
+}
+
+func (Webhook) TableName() string {
+	return "webhooks"
+}
+
+// Subscribes reports whether the webhook wants to receive the given event.
+func (w *Webhook) Subscribes(event EventType) bool {
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery records a single HTTP attempt to deliver an event.
+// Attempts belonging to the same event share a DeliveryID.
+type WebhookDelivery struct {
+	ID           uint      `json:"id" gorm:"primaryKey;column:delivery_id"`
+	WebhookID    uint      `json:"webhook_id" gorm:"not null;index"`
+	DeliveryID   string    `json:"delivery_uuid" gorm:"column:delivery_uuid;type:varchar(64);index"`
+	Event        EventType `json:"event" gorm:"type:varchar(50)"`
+	Payload      string    `json:"payload" gorm:"type:text"`
+	Attempt      int       `json:"attempt"`
+	StatusCode   int       `json:"status_code"`
+	ResponseBody string    `json:"response_body" gorm:"type:text"`
+	Error        string    `json:"error"`
+	Success      bool      `json:"success"`
+	DurationMs   int64     `json:"duration_ms"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type WorkspaceRole string
+
+const (
+	RoleOwner  WorkspaceRole = "owner"
+	RoleAdmin  WorkspaceRole = "admin"
+	RoleMember WorkspaceRole = "member"
+	RoleGuest  WorkspaceRole = "guest"
+)
+
+// Workspace owns todos and is shared by its members. Every user has exactly
+// one personal workspace, which cannot be shared or deleted.
+type Workspace struct {
+	ID        uint           `json:"id" gorm:"primaryKey;column:workspace_id"`
+	Name      string         `json:"name" gorm:"not null"`
+	Personal  bool           `json:"personal" gorm:"not null;default:false"`
+	OwnerID   uint           `json:"owner_id" gorm:"not null;index;uniqueIndex:idx_workspaces_personal,where:personal = true"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Role is the role of the user the workspace was loaded for.
+	Role WorkspaceRole `json:"role,omitempty" gorm:"->;-:migration"`
+}
+
+func (Workspace) TableName() string {
+	return "workspaces"
+}
+
+type WorkspaceMember struct {
+	WorkspaceID uint          `json:"workspace_id" gorm:"primaryKey;autoIncrement:false"`
+	UserID      uint          `json:"user_id" gorm:"primaryKey;autoIncrement:false;index"`
+	Role        WorkspaceRole `json:"role" gorm:"type:varchar(20);not null"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+}
+
+func (WorkspaceMember) TableName() string {
+	return "workspace_members"
+}
+
+// Member is a workspace member as listed to the other members.
+type Member struct {
+	ID       uint          `json:"id"`
+	Username string        `json:"username"`
+	FullName string        `json:"full_name"`
+	Role     WorkspaceRole `json:"role"`
+}
+
+// WorkspaceInvitation invites a user, or an email address that has no
+// account yet, to join a workspace with the given role.
+type WorkspaceInvitation struct {
+	ID          uint          `json:"id" gorm:"primaryKey;column:workspace_invitation_id"`
+	WorkspaceID uint          `json:"workspace_id" gorm:"not null;index"`
+	InviterID   uint          `json:"inviter_id" gorm:"not null"`
+	InviteeID   *uint         `json:"invitee_id" gorm:"index"`
+	Email       string        `json:"email" gorm:"index"`
+	Role        WorkspaceRole `json:"role" gorm:"type:varchar(20);not null"`
+	CreatedAt   time.Time     `json:"created_at"`
+
+	// Relations
+	Workspace Workspace `json:"workspace,omitempty" gorm:"foreignKey:WorkspaceID"`
+}
+
+func (WorkspaceInvitation) TableName() string {
+	return "workspace_invitations"
+}
+
+func (r WorkspaceRole) IsValid() bool {
+	switch r {
+	case RoleOwner, RoleAdmin, RoleMember, RoleGuest:
+		return true
+	}
+	return false
+}
+
+var roleRanks = map[WorkspaceRole]int{
+	RoleGuest:  1,
+	RoleMember: 2,
+	RoleAdmin:  3,
+	RoleOwner:  4,
+}
+
+// AtLeast reports whether r grants every permission of min.
+func (r WorkspaceRole) AtLeast(min WorkspaceRole) bool {
+	return roleRanks[r] >= roleRanks[min]
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"errors"
+
+	"task-management/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AttachmentRepository interface {
+	Create(attachment *models.Attachment, quota int64) error
+	GetByTodoID(todoID uint) ([]models.Attachment, error)
+	GetByID(id, todoID uint) (*models.Attachment, error)
+	Delete(id uint) error
+	TotalSizeByUserID(userID uint) (int64, error)
+	GetOrphaned(limit int) ([]models.Attachment, error)
+}
+
+// ErrQuotaExceeded is returned by Create when the attachment would take the
+// user's stored attachments over their quota.
+var ErrQuotaExceeded = errors.New("attachment quota exceeded")
+
+type attachmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAttachmentRepository(db *gorm.DB) AttachmentRepository {
+	return &attachmentRepository{db: db}
+}
+
+// Create inserts the attachment if the user's attachments, including this
+// one, stay within quota bytes. The user's row is locked while checking, so
+// concurrent uploads by the same user cannot both pass the check.
+func (r *attachmentRepository) Create(attachment *models.Attachment, quota int64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("user_id").
+			Where("user_id = ?", attachment.UserID).
+			First(&user).Error; err != nil {
+			return err
+		}
+
+		var used int64
+		if err := tx.Model(&models.Attachment{}).
+			Where("user_id = ?", attachment.UserID).
+			Select("COALESCE(SUM(size), 0)").
+			Scan(&used).Error; err != nil {
+			return err
+		}
+		if used+attachment.Size > quota {
+			return ErrQuotaExceeded
+		}
+
+		return tx.Create(attachment).Error
+	})
+}
+
+func (r *attachmentRepository) GetByTodoID(todoID uint) ([]models.Attachment, error) {
+	var attachments []models.Attachment
+	err := r.db.Where("todo_id = ?", todoID).Order("created_at ASC").Find(&attachments).Error
+	return attachments, err
+}
+
+func (r *attachmentRepository) GetByID(id, todoID uint) (*models.Attachment, error) {
+	var attachment models.Attachment
+	err := r.db.Where("attachment_id = ? AND todo_id = ?", id, todoID).First(&attachment).Error
+	return &attachment, err
+}
+
+func (r *attachmentRepository) Delete(id uint) error {
+	return r.db.Where("attachment_id = ?", id).Delete(&models.Attachment{}).Error
+}
+
+func (r *attachmentRepository) TotalSizeByUserID(userID uint) (int64, error) {
+	var total int64
+	err := r.db.Model(&models.Attachment{}).
+		Where("user_id = ?", userID).
+		Select("COALESCE(SUM(size), 0)").
+		Scan(&total).Error
+	return total, err
+}
+
+// GetOrphaned returns attachments whose todo has been permanently removed.
+func (r *attachmentRepository) GetOrphaned(limit int) ([]models.Attachment, error) {
+	var attachments []models.Attachment
+	err := r.db.Where("NOT EXISTS (SELECT 1 FROM todos WHERE todos.todo_id = attachments.todo_id)").
+		Limit(limit).
+		Find(&attachments).Error
+	return attachments, err
+}
@@ -0,0 +1,48 @@
+package repository_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"task-management/internal/database/dbtest"
+	"task-management/internal/models"
+	"task-management/internal/repository"
+)
+
+func TestAttachmentCreateChecksQuota(t *testing.T) {
+	db := dbtest.New(t)
+	repo := repository.NewAttachmentRepository(db)
+	userID, workspaceID := newWorkspace(t, db, "alice")
+	otherID, otherWorkspaceID := newWorkspace(t, db, "bob")
+	todos := repository.NewTodoRepository(db)
+	todo := newTodo(t, todos, &models.Todo{UserID: userID, WorkspaceID: workspaceID, Title: "Report"})
+	other := newTodo(t, todos, &models.Todo{UserID: otherID, WorkspaceID: otherWorkspaceID, Title: "Slides"})
+
+	create := func(userID, todoID uint, size int64) error {
+		key := fmt.Sprintf("attachments/%d/%d/%d", userID, todoID, size)
+		return repo.Create(&models.Attachment{TodoID: todoID, UserID: userID, FileName: "a", Size: size, StorageKey: key}, 100)
+	}
+
+	if err := create(userID, todo.ID, 60); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := create(userID, todo.ID, 41); !errors.Is(err, repository.ErrQuotaExceeded) {
+		t.Fatalf("Create over the quota: got %v, want ErrQuotaExceeded", err)
+	}
+	if err := create(userID, todo.ID, 40); err != nil {
+		t.Fatalf("Create up to the quota: %v", err)
+	}
+	// Other users' attachments do not count.
+	if err := create(otherID, other.ID, 100); err != nil {
+		t.Fatalf("Create for another user: %v", err)
+	}
+
+	used, err := repo.TotalSizeByUserID(userID)
+	if err != nil {
+		t.Fatalf("TotalSizeByUserID: %v", err)
+	}
+	if used != 100 {
+		t.Errorf("TotalSizeByUserID = %d, want 100", used)
+	}
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"task-management/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CalendarFeedRepository interface {
+	GetByUserID(userID uint) (*models.CalendarFeed, error)
+	GetByTokenHash(tokenHash string) (*models.CalendarFeed, error)
+	Upsert(feed *models.CalendarFeed) error
+	DeleteByUserID(userID uint) (int64, error)
+}
+
+type calendarFeedRepository struct {
+	db *gorm.DB
+}
+
+func NewCalendarFeedRepository(db *gorm.DB) CalendarFeedRepository {
+	return &calendarFeedRepository{db: db}
+}
+
+func (r *calendarFeedRepository) GetByUserID(userID uint) (*models.CalendarFeed, error) {
+	var feed models.CalendarFeed
+	err := r.db.Where("user_id = ?", userID).First(&feed).Error
+	return &feed, err
+}
+
+// GetByTokenHash finds the feed of an active user. Feeds of deactivated
+// users are reported as not found.
+func (r *calendarFeedRepository) GetByTokenHash(tokenHash string) (*models.CalendarFeed, error) {
+	var feed models.CalendarFeed
+	err := r.db.Joins("JOIN users ON users.user_id = calendar_feeds.user_id").
+		Where("calendar_feeds.token_hash = ? AND users.is_active = ?", tokenHash, true).
+		First(&feed).Error
+	return &feed, err
+}
+
+// Upsert stores the feed, replacing the token of an existing feed of the
+// same user.
+func (r *calendarFeedRepository) Upsert(feed *models.CalendarFeed) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"token_hash", "updated_at"}),
+	}).Create(feed).Error
+}
+
+func (r *calendarFeedRepository) DeleteByUserID(userID uint) (int64, error) {
+	result := r.db.Where("user_id = ?", userID).Delete(&models.CalendarFeed{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,34 @@
+package repository_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"task-management/internal/database/dbtest"
+	"task-management/internal/models"
+	"task-management/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+func TestCalendarFeedOfDeactivatedUser(t *testing.T) {
+	db := dbtest.New(t)
+	feeds := repository.NewCalendarFeedRepository(db)
+	userID, _ := newWorkspace(t, db, "alice")
+
+	if err := feeds.Upsert(&models.CalendarFeed{UserID: userID, TokenHash: "hash"}); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	feed, err := feeds.GetByTokenHash("hash")
+	if err != nil || feed.UserID != userID {
+		t.Fatalf("GetByTokenHash = %+v, %v, want the feed of user %d", feed, err, userID)
+	}
+
+	if err := repository.NewUserRepository(db).Deactivate(userID, time.Now()); err != nil {
+		t.Fatalf("Deactivate: %v", err)
+	}
+	if _, err := feeds.GetByTokenHash("hash"); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("GetByTokenHash after Deactivate: got %v, want ErrRecordNotFound", err)
+	}
+}
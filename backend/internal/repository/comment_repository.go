@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"task-management/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type CommentRepository interface {
+	Create(comment *models.Comment) error
+	GetByTodoID(todoID uint) ([]models.Comment, error)
+	GetByID(id, todoID uint) (*models.Comment, error)
+	Update(comment *models.Comment, previousBody string) error
+	Delete(id uint) error
+	GetRevisions(commentID uint) ([]models.CommentRevision, error)
+}
+
+type commentRepository struct {
+	db *gorm.DB
+}
+
+func NewCommentRepository(db *gorm.DB) CommentRepository {
+	return &commentRepository{db: db}
+}
+
+func (r *commentRepository) Create(comment *models.Comment) error {
+	return r.db.Create(comment).Error
+}
+
+func (r *commentRepository) GetByTodoID(todoID uint) ([]models.Comment, error) {
+	var comments []models.Comment
+	err := r.db.Where("todo_id = ?", todoID).Order("created_at ASC").Find(&comments).Error
+	return comments, err
+}
+
+func (r *commentRepository) GetByID(id, todoID uint) (*models.Comment, error) {
+	var comment models.Comment
+	err := r.db.Where("comment_id = ? AND todo_id = ?", id, todoID).First(&comment).Error
+	return &comment, err
+}
+
+// Update saves the comment and archives the body it had before the edit.
+func (r *commentRepository) Update(comment *models.Comment, previousBody string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		revision := &models.CommentRevision{
+			CommentID: comment.ID,
+			Body:      previousBody,
+		}
+		if err := tx.Create(revision).Error; err != nil {
+			return err
+		}
+		return tx.Save(comment).Error
+	})
+}
+
+func (r *commentRepository) Delete(id uint) error {
+	return r.db.Where("comment_id = ?", id).Delete(&models.Comment{}).Error
+}
+
+func (r *commentRepository) GetRevisions(commentID uint) ([]models.CommentRevision, error) {
+	var revisions []models.CommentRevision
+	err := r.db.Where("comment_id = ?", commentID).Order("created_at DESC").Find(&revisions).Error
+	return revisions, err
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrNotifyUnsupported is returned by Notify on SQLite, which can neither
+// send nor receive notifications. A SQLite database serves one instance, so
+// delivering events within the process is enough.
+var ErrNotifyUnsupported = errors.New("notifications are not supported by this database")
+
+// isSQLite reports whether queries must avoid Postgres-only SQL.
+func isSQLite(db *gorm.DB) bool {
+	return db.Dialector.Name() == "sqlite"
+}
+
+// sumByLocalDay adds up weights per calendar day of the matching time in
+// timezone, keyed as YYYY-MM-DD. SQLite has no time zone support, so the
+// grouping Postgres does in SQL is done here.
+func sumByLocalDay(times []time.Time, weights []int64, timezone string) (map[string]int64, error) {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	sums := make(map[string]int64)
+	for i, t := range times {
+		sums[t.In(loc).Format("2006-01-02")] += weights[i]
+	}
+	return sums, nil
+}
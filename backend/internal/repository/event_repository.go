@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"time"
+
+	"task-management/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type EventRepository interface {
+	Create(event *models.TodoEvent) error
+	GetByID(id uint64) (*models.TodoEvent, error)
+	GetSince(userID uint, afterID uint64, limit int) ([]models.TodoEvent, error)
+	DeleteOlderThan(t time.Time) error
+	Notify(channel, payload string) error
+}
+
+type eventRepository struct {
+	db *gorm.DB
+}
+
+func NewEventRepository(db *gorm.DB) EventRepository {
+	return &eventRepository{db: db}
+}
+
+func (r *eventRepository) Create(event *models.TodoEvent) error {
+	return r.db.Create(event).Error
+}
+
+func (r *eventRepository) GetByID(id uint64) (*models.TodoEvent, error) {
+	var event models.TodoEvent
+	err := r.db.Where("event_id = ?", id).First(&event).Error
+	return &event, err
+}
+
+// GetSince returns the events after afterID in the workspaces userID is a
+// member of.
+func (r *eventRepository) GetSince(userID uint, afterID uint64, limit int) ([]models.TodoEvent, error) {
+	var events []models.TodoEvent
+	err := r.db.Where("workspace_id IN (SELECT workspace_id FROM workspace_members WHERE user_id = ?)", userID).
+		Where("event_id > ?", afterID).
+		Order("event_id ASC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
+
+func (r *eventRepository) DeleteOlderThan(t time.Time) error {
+	return r.db.Where("created_at < ?", t).Delete(&models.TodoEvent{}).Error
+}
+
+// Notify sends a Postgres NOTIFY on the given channel.
+func (r *eventRepository) Notify(channel, payload string) error {
+	if isSQLite(r.db) {
+		return ErrNotifyUnsupported
+	}
+	return r.db.Exec("SELECT pg_notify(?, ?)", channel, payload).Error
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"time"
+
+	"task-management/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type NotificationRepository interface {
+	Create(notification *models.Notification) error
+	GetByUserID(userID uint, unreadOnly bool, limit int) ([]models.Notification, error)
+	MarkRead(id, userID uint) (int64, error)
+	MarkAllRead(userID uint) error
+}
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+func (r *notificationRepository) Create(notification *models.Notification) error {
+	return r.db.Create(notification).Error
+}
+
+func (r *notificationRepository) GetByUserID(userID uint, unreadOnly bool, limit int) ([]models.Notification, error) {
+	var notifications []models.Notification
+	query := r.db.Where("user_id = ?", userID)
+
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	err := query.Order("created_at DESC").Limit(limit).Find(&notifications).Error
+	return notifications, err
+}
+
+func (r *notificationRepository) MarkRead(id, userID uint) (int64, error) {
+	result := r.db.Model(&models.Notification{}).
+		Where("notification_id = ? AND user_id = ?", id, userID).
+		Update("read_at", time.Now())
+	return result.RowsAffected, result.Error
+}
+
+func (r *notificationRepository) MarkAllRead(userID uint) error {
+	return r.db.Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", time.Now()).Error
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"task-management/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type StatsRepository interface {
+	CountBy(scope TodoScope, field string) (map[string]int64, error)
+	CountOverdue(scope TodoScope, now time.Time) (int64, error)
+	CountDueBetween(scope TodoScope, from, to time.Time) (int64, error)
+	AverageCycleTime(scope TodoScope) (*float64, error)
+	DailyCounts(scope TodoScope, field string, from, to time.Time, timezone string) (map[string]int64, error)
+}
+
+type statsRepository struct {
+	db *gorm.DB
+}
+
+func NewStatsRepository(db *gorm.DB) StatsRepository {
+	return &statsRepository{db: db}
+}
+
+// groupableFields and datedFields whitelist the columns that may be
+// interpolated into the aggregate queries.
+var (
+	groupableFields = map[string]bool{"status": true, "priority": true, "category": true}
+	datedFields     = map[string]bool{"created_at": true, "completed_at": true}
+)
+
+type fieldCount struct {
+	Key   string
+	Count int64
+}
+
+// CountBy counts the todos in scope grouped by status, priority or category.
+func (r *statsRepository) CountBy(scope TodoScope, field string) (map[string]int64, error) {
+	if !groupableFields[field] {
+		return nil, fmt.Errorf("cannot group todos by %q", field)
+	}
+
+	var rows []fieldCount
+	err := scope.apply(r.db.Model(&models.Todo{})).
+		Select(field + " AS key, COUNT(*) AS count").
+		Group(field).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Key] = row.Count
+	}
+	return counts, nil
+}
+
+// CountOverdue counts open todos whose due date has passed.
+func (r *statsRepository) CountOverdue(scope TodoScope, now time.Time) (int64, error) {
+	var count int64
+	err := scope.apply(r.db.Model(&models.Todo{})).
+		Where("status <> ? AND due_date < ?", models.StatusDone, now).
+		Count(&count).Error
+	return count, err
+}
+
+// CountDueBetween counts open todos due in [from, to).
+func (r *statsRepository) CountDueBetween(scope TodoScope, from, to time.Time) (int64, error) {
+	var count int64
+	err := scope.apply(r.db.Model(&models.Todo{})).
+		Where("status <> ? AND due_date >= ? AND due_date < ?", models.StatusDone, from, to).
+		Count(&count).Error
+	return count, err
+}
+
+// AverageCycleTime returns the mean number of seconds between creating and
+// completing a todo, or nil when nothing was completed yet.
+func (r *statsRepository) AverageCycleTime(scope TodoScope) (*float64, error) {
+	seconds := "EXTRACT(EPOCH FROM (completed_at - created_at))"
+	if isSQLite(r.db) {
+		seconds = "(julianday(completed_at) - julianday(created_at)) * 86400"
+	}
+
+	var avg sql.NullFloat64
+	err := scope.apply(r.db.Model(&models.Todo{})).
+		Select("AVG(" + seconds + ")").
+		Where("status = ? AND completed_at IS NOT NULL", models.StatusDone).
+		Scan(&avg).Error
+	if err != nil || !avg.Valid {
+		return nil, err
+	}
+	return &avg.Float64, nil
+}
+
+type dayCount struct {
+	Day   string
+	Count int64
+}
+
+// DailyCounts counts the todos in scope per local calendar day of field
+// (created_at or completed_at) within [from, to). Days are keyed as
+// YYYY-MM-DD in timezone.
+func (r *statsRepository) DailyCounts(scope TodoScope, field string, from, to time.Time, timezone string) (map[string]int64, error) {
+	if !datedFields[field] {
+		return nil, fmt.Errorf("cannot count todos by %q", field)
+	}
+
+	if isSQLite(r.db) {
+		var times []time.Time
+		err := scope.apply(r.db.Model(&models.Todo{})).
+			Where(field+" >= ? AND "+field+" < ?", from, to).
+			Pluck(field, &times).Error
+		if err != nil {
+			return nil, err
+		}
+		ones := make([]int64, len(times))
+		for i := range ones {
+			ones[i] = 1
+		}
+		return sumByLocalDay(times, ones, timezone)
+	}
+
+	day := fmt.Sprintf("to_char(%s AT TIME ZONE ?, 'YYYY-MM-DD')", field)
+
+	var rows []dayCount
+	err := scope.apply(r.db.Model(&models.Todo{})).
+		Select(day+" AS day, COUNT(*) AS count", timezone).
+		Where(field+" >= ? AND "+field+" < ?", from, to).
+		Group("day").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Day] = row.Count
+	}
+	return counts, nil
+}
@@ -0,0 +1,144 @@
+package repository_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"task-management/internal/database/dbtest"
+	"task-management/internal/models"
+	"task-management/internal/repository"
+)
+
+func TestStatsAggregates(t *testing.T) {
+	db := dbtest.New(t)
+	todos := repository.NewTodoRepository(db)
+	stats := repository.NewStatsRepository(db)
+	userID, workspaceID := newWorkspace(t, db, "alice")
+	otherID, otherWorkspaceID := newWorkspace(t, db, "bob")
+
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		v := now.Add(d)
+		return &v
+	}
+	day := 24 * time.Hour
+
+	fixtures := []models.Todo{
+		// Overdue.
+		{Title: "a", Status: models.StatusTodo, Priority: models.PriorityHigh, DueDate: at(-day), CreatedAt: now.Add(-3 * day)},
+		// Due within the next two days.
+		{Title: "b", Status: models.StatusInProgress, Priority: models.PriorityHigh, DueDate: at(day), CreatedAt: now.Add(-2 * day)},
+		{Title: "c", Status: models.StatusTodo, Priority: models.PriorityLow, DueDate: at(5 * day), CreatedAt: now.Add(-2 * day)},
+		// Done todos are neither overdue nor due.
+		{Title: "d", Status: models.StatusDone, Priority: models.PriorityLow, DueDate: at(-day), CreatedAt: now.Add(-2 * day), CompletedAt: at(-day)},
+		{Title: "e", Status: models.StatusDone, Priority: models.PriorityMedium, CreatedAt: now.Add(-3 * day), CompletedAt: at(0)},
+	}
+	for i := range fixtures {
+		fixtures[i].UserID = userID
+		fixtures[i].WorkspaceID = workspaceID
+		newTodo(t, todos, &fixtures[i])
+	}
+	deleted := newTodo(t, todos, &models.Todo{UserID: userID, WorkspaceID: workspaceID, Title: "deleted", DueDate: at(-day), CreatedAt: now.Add(-day)})
+	if err := todos.Delete(deleted.ID, userID, deleted.Version); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	newTodo(t, todos, &models.Todo{UserID: otherID, WorkspaceID: otherWorkspaceID, Title: "other", DueDate: at(-day), CreatedAt: now.Add(-day)})
+
+	scope := repository.WorkspaceScope(workspaceID, userID)
+
+	t.Run("CountBy", func(t *testing.T) {
+		tests := []struct {
+			field string
+			want  map[string]int64
+		}{
+			{"status", map[string]int64{"todo": 2, "inprogress": 1, "done": 2}},
+			{"priority", map[string]int64{"high": 2, "medium": 1, "low": 2}},
+			{"category", map[string]int64{"personal": 5}},
+		}
+		for _, tt := range tests {
+			got, err := stats.CountBy(scope, tt.field)
+			if err != nil {
+				t.Fatalf("CountBy(%q): %v", tt.field, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CountBy(%q) = %v, want %v", tt.field, got, tt.want)
+			}
+		}
+
+		if _, err := stats.CountBy(scope, "title"); err == nil {
+			t.Error("CountBy(\"title\") succeeded, want an error")
+		}
+	})
+
+	t.Run("CountOverdue", func(t *testing.T) {
+		got, err := stats.CountOverdue(scope, now)
+		if err != nil {
+			t.Fatalf("CountOverdue: %v", err)
+		}
+		if got != 1 {
+			t.Errorf("CountOverdue = %d, want 1", got)
+		}
+	})
+
+	t.Run("CountDueBetween", func(t *testing.T) {
+		got, err := stats.CountDueBetween(scope, now, now.Add(2*day))
+		if err != nil {
+			t.Fatalf("CountDueBetween: %v", err)
+		}
+		if got != 1 {
+			t.Errorf("CountDueBetween = %d, want 1", got)
+		}
+	})
+
+	t.Run("AverageCycleTime", func(t *testing.T) {
+		got, err := stats.AverageCycleTime(scope)
+		if err != nil {
+			t.Fatalf("AverageCycleTime: %v", err)
+		}
+		// One day for d, three days for e.
+		want := (2 * day).Seconds()
+		if got == nil || *got < want-1 || *got > want+1 {
+			t.Errorf("AverageCycleTime = %v, want %v", got, want)
+		}
+
+		none, err := stats.AverageCycleTime(repository.WorkspaceScope(otherWorkspaceID, otherID))
+		if err != nil {
+			t.Fatalf("AverageCycleTime: %v", err)
+		}
+		if none != nil {
+			t.Errorf("AverageCycleTime without completed todos = %v, want nil", *none)
+		}
+	})
+
+	t.Run("DailyCounts", func(t *testing.T) {
+		got, err := stats.DailyCounts(scope, "created_at", now.Add(-7*day), now, "UTC")
+		if err != nil {
+			t.Fatalf("DailyCounts: %v", err)
+		}
+		want := map[string]int64{"2024-03-07": 2, "2024-03-08": 3}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("DailyCounts(created_at) = %v, want %v", got, want)
+		}
+
+		// 12:00 UTC is already the next day in Auckland.
+		got, err = stats.DailyCounts(scope, "completed_at", now.Add(-7*day), now.Add(day), "Pacific/Auckland")
+		if err != nil {
+			t.Fatalf("DailyCounts: %v", err)
+		}
+		want = map[string]int64{"2024-03-10": 1, "2024-03-11": 1}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("DailyCounts(completed_at) = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("scope", func(t *testing.T) {
+		got, err := stats.CountOverdue(repository.WorkspaceScope(workspaceID, otherID), now)
+		if err != nil {
+			t.Fatalf("CountOverdue: %v", err)
+		}
+		if got != 0 {
+			t.Errorf("CountOverdue for a non-member = %d, want 0", got)
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"task-management/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type TemplateRepository interface {
+	Create(template *models.TodoTemplate) error
+	GetByID(id uint) (*models.TodoTemplate, error)
+	GetByWorkspaceID(workspaceID uint) ([]models.TodoTemplate, error)
+	Update(template *models.TodoTemplate) error
+	Delete(id uint) error
+}
+
+type templateRepository struct {
+	db *gorm.DB
+}
+
+func NewTemplateRepository(db *gorm.DB) TemplateRepository {
+	return &templateRepository{db: db}
+}
+
+func (r *templateRepository) Create(template *models.TodoTemplate) error {
+	return r.db.Create(template).Error
+}
+
+func (r *templateRepository) GetByID(id uint) (*models.TodoTemplate, error) {
+	var template models.TodoTemplate
+	err := r.db.Where("todo_template_id = ?", id).First(&template).Error
+	return &template, err
+}
+
+func (r *templateRepository) GetByWorkspaceID(workspaceID uint) ([]models.TodoTemplate, error) {
+	var templates []models.TodoTemplate
+	err := r.db.Where("workspace_id = ?", workspaceID).Order("name ASC, todo_template_id ASC").Find(&templates).Error
+	return templates, err
+}
+
+func (r *templateRepository) Update(template *models.TodoTemplate) error {
+	return r.db.Save(template).Error
+}
+
+func (r *templateRepository) Delete(id uint) error {
+	return r.db.Where("todo_template_id = ?", id).Delete(&models.TodoTemplate{}).Error
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"time"
+
+	"task-management/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type TimeEntryRepository interface {
+	Create(entry *models.TimeEntry) error
+	GetByID(id, todoID, userID uint) (*models.TimeEntry, error)
+	GetRunning(userID uint) (*models.TimeEntry, error)
+	GetByTodoID(todoID, userID uint) ([]models.TimeEntry, error)
+	Update(entry *models.TimeEntry) error
+	Delete(id uint) error
+	TotalByTodo(todoID, userID uint) (int64, error)
+	TotalsByCategory(userID uint) (map[string]int64, error)
+	DailyTotals(userID uint, from, to time.Time, timezone string) (map[string]int64, error)
+}
+
+type timeEntryRepository struct {
+	db *gorm.DB
+}
+
+func NewTimeEntryRepository(db *gorm.DB) TimeEntryRepository {
+	return &timeEntryRepository{db: db}
+}
+
+func (r *timeEntryRepository) Create(entry *models.TimeEntry) error {
+	return r.db.Create(entry).Error
+}
+
+func (r *timeEntryRepository) GetByID(id, todoID, userID uint) (*models.TimeEntry, error) {
+	var entry models.TimeEntry
+	err := r.db.Where("time_entry_id = ? AND todo_id = ? AND user_id = ?", id, todoID, userID).First(&entry).Error
+	return &entry, err
+}
+
+func (r *timeEntryRepository) GetRunning(userID uint) (*models.TimeEntry, error) {
+	var entry models.TimeEntry
+	err := r.db.Where("user_id = ? AND ended_at IS NULL", userID).First(&entry).Error
+	return &entry, err
+}
+
+func (r *timeEntryRepository) GetByTodoID(todoID, userID uint) ([]models.TimeEntry, error) {
+	var entries []models.TimeEntry
+	err := r.db.Where("todo_id = ? AND user_id = ?", todoID, userID).Order("started_at DESC").Find(&entries).Error
+	return entries, err
+}
+
+func (r *timeEntryRepository) Update(entry *models.TimeEntry) error {
+	return r.db.Save(entry).Error
+}
+
+func (r *timeEntryRepository) Delete(id uint) error {
+	return r.db.Where("time_entry_id = ?", id).Delete(&models.TimeEntry{}).Error
+}
+
+// TotalByTodo sums the seconds the user tracked on a todo. Running timers
+// are not included.
+func (r *timeEntryRepository) TotalByTodo(todoID, userID uint) (int64, error) {
+	var total int64
+	err := r.db.Model(&models.TimeEntry{}).
+		Select("COALESCE(SUM(duration), 0)").
+		Where("todo_id = ? AND user_id = ?", todoID, userID).
+		Scan(&total).Error
+	return total, err
+}
+
+type categoryTotal struct {
+	Category string
+	Total    int64
+}
+
+// TotalsByCategory sums the user's tracked seconds per todo category,
+// ignoring todos in the trash.
+func (r *timeEntryRepository) TotalsByCategory(userID uint) (map[string]int64, error) {
+	var rows []categoryTotal
+	err := r.db.Model(&models.TimeEntry{}).
+		Select("todos.category AS category, SUM(time_entries.duration) AS total").
+		Joins("JOIN todos ON todos.todo_id = time_entries.todo_id AND todos.deleted_at IS NULL").
+		Where("time_entries.user_id = ?", userID).
+		Group("todos.category").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		totals[row.Category] = row.Total
+	}
+	return totals, nil
+}
+
+type dayTotal struct {
+	Day   string
+	Total int64
+}
+
+// DailyTotals sums the user's tracked seconds per local calendar day in
+// [from, to). An entry counts towards the day it started on.
+func (r *timeEntryRepository) DailyTotals(userID uint, from, to time.Time, timezone string) (map[string]int64, error) {
+	if isSQLite(r.db) {
+		var entries []models.TimeEntry
+		err := r.db.Select("started_at, duration").
+			Where("user_id = ? AND ended_at IS NOT NULL AND started_at >= ? AND started_at < ?", userID, from, to).
+			Find(&entries).Error
+		if err != nil {
+			return nil, err
+		}
+		times := make([]time.Time, len(entries))
+		durations := make([]int64, len(entries))
+		for i, entry := range entries {
+			times[i], durations[i] = entry.StartedAt, entry.Duration
+		}
+		return sumByLocalDay(times, durations, timezone)
+	}
+
+	var rows []dayTotal
+	err := r.db.Model(&models.TimeEntry{}).
+		Select("to_char(started_at AT TIME ZONE ?, 'YYYY-MM-DD') AS day, SUM(duration) AS total", timezone).
+		Where("user_id = ? AND ended_at IS NOT NULL AND started_at >= ? AND started_at < ?", userID, from, to).
+		Group("day").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		totals[row.Day] = row.Total
+	}
+	return totals, nil
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"task-management/internal/models"
+
+	"gorm.io/gorm/clause"
+)
+
+// AddAssignee inserts the assignment unless it already exists. It returns
+// the number of rows inserted.
+func (r *todoRepository) AddAssignee(assignee *models.TodoAssignee) (int64, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(assignee)
+	return result.RowsAffected, result.Error
+}
+
+func (r *todoRepository) RemoveAssignee(todoID, userID uint) (int64, error) {
+	result := r.db.Where("todo_id = ? AND user_id = ?", todoID, userID).Delete(&models.TodoAssignee{})
+	return result.RowsAffected, result.Error
+}
+
+// GetAssignees returns the assignees of todoID with their users, in the
+// order they were assigned.
+func (r *todoRepository) GetAssignees(todoID uint) ([]models.TodoAssignee, error) {
+	var assignees []models.TodoAssignee
+	err := r.db.Where("todo_id = ?", todoID).
+		Preload("User").
+		Order("created_at ASC").
+		Find(&assignees).Error
+	return assignees, err
+}
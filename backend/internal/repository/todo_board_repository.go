@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"errors"
+
+	"task-management/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// GetBoard returns the todos in scope ordered by status column and rank.
+func (r *todoRepository) GetBoard(scope TodoScope, category string) ([]models.Todo, error) {
+	var todos []models.Todo
+	query := withSubtaskProgress(scope.apply(r.db)).Preload("Assignees")
+
+	if category != "" {
+		query = query.Where("category = ?", category)
+	}
+
+	err := query.Order("rank ASC, todo_id ASC").Find(&todos).Error
+	return todos, err
+}
+
+// GetColumn returns the todos of one status column in rank order.
+func (r *todoRepository) GetColumn(workspaceID uint, status models.Status) ([]models.Todo, error) {
+	var todos []models.Todo
+	err := r.db.Where("workspace_id = ? AND status = ?", workspaceID, status).
+		Order("rank ASC, todo_id ASC").
+		Find(&todos).Error
+	return todos, err
+}
+
+// LastRank returns the highest rank in the column, or "" if it is empty.
+func (r *todoRepository) LastRank(workspaceID uint, status models.Status) (string, error) {
+	var todo models.Todo
+	err := r.db.Select("rank").
+		Where("workspace_id = ? AND status = ?", workspaceID, status).
+		Order("rank DESC").
+		First(&todo).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	return todo.Rank, err
+}
+
+// AdjacentRank returns the rank directly after (or before) rank in the
+// column, ignoring excludeID. It returns "" at the end of the column.
+func (r *todoRepository) AdjacentRank(workspaceID uint, status models.Status, rank string, after bool, excludeID uint) (string, error) {
+	var todo models.Todo
+	query := r.db.Select("rank").Where("workspace_id = ? AND status = ? AND todo_id <> ?", workspaceID, status, excludeID)
+
+	if after {
+		query = query.Where("rank > ?", rank).Order("rank ASC")
+	} else {
+		query = query.Where("rank < ?", rank).Order("rank DESC")
+	}
+
+	err := query.First(&todo).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	return todo.Rank, err
+}
+
+// UpdateRank changes the rank and, as the rank is part of the todo's
+// representation, bumps the version. updated_at is left untouched.
+func (r *todoRepository) UpdateRank(id uint, rank string) error {
+	return r.db.Model(&models.Todo{}).Where("todo_id = ?", id).UpdateColumns(map[string]interface{}{
+		"rank":    rank,
+		"version": gorm.Expr("version + 1"),
+	}).Error
+}
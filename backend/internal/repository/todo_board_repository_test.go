@@ -0,0 +1,35 @@
+package repository_test
+
+import (
+	"errors"
+	"testing"
+
+	"task-management/internal/database/dbtest"
+	"task-management/internal/models"
+	"task-management/internal/repository"
+)
+
+func TestUpdateRankBumpsVersion(t *testing.T) {
+	db := dbtest.New(t)
+	repo := repository.NewTodoRepository(db)
+	userID, workspaceID := newWorkspace(t, db, "alice")
+
+	todo := newTodo(t, repo, &models.Todo{UserID: userID, WorkspaceID: workspaceID, Title: "Card", Rank: "i"})
+
+	if err := repo.UpdateRank(todo.ID, "m"); err != nil {
+		t.Fatalf("UpdateRank: %v", err)
+	}
+	got, err := repo.GetByID(todo.ID, userID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.Rank != "m" || got.Version != todo.Version+1 {
+		t.Fatalf("after UpdateRank: rank %q, version %d, want \"m\" and %d", got.Rank, got.Version, todo.Version+1)
+	}
+
+	// A save based on the version read before the rank changed is stale.
+	todo.Title = "Renamed"
+	if err := repo.Update(todo); !errors.Is(err, repository.ErrStaleVersion) {
+		t.Fatalf("Update at the old version: got %v, want ErrStaleVersion", err)
+	}
+}
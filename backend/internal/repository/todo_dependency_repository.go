@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"task-management/internal/models"
+
+	"gorm.io/gorm/clause"
+)
+
+// AddDependency inserts the dependency unless it already exists. It returns
+// the number of rows inserted.
+func (r *todoRepository) AddDependency(dependency *models.TodoDependency) (int64, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(dependency)
+	return result.RowsAffected, result.Error
+}
+
+func (r *todoRepository) RemoveDependency(todoID, blockerID uint) (int64, error) {
+	result := r.db.Where("todo_id = ? AND blocker_id = ?", todoID, blockerID).Delete(&models.TodoDependency{})
+	return result.RowsAffected, result.Error
+}
+
+// GetBlockers returns the todos that block todoID. Blockers in the trash are
+// left out.
+func (r *todoRepository) GetBlockers(todoID uint) ([]models.Todo, error) {
+	var todos []models.Todo
+	err := r.db.
+		Joins("JOIN todo_dependencies ON todo_dependencies.blocker_id = todos.todo_id").
+		Where("todo_dependencies.todo_id = ?", todoID).
+		Order("todos.todo_id").
+		Find(&todos).Error
+	return todos, err
+}
+
+// DependsOn reports whether todoID is blocked by blockerID, directly or
+// through a chain of other dependencies.
+func (r *todoRepository) DependsOn(todoID, blockerID uint) (bool, error) {
+	var found bool
+	err := r.db.Raw(`
+		WITH RECURSIVE chain(blocker_id) AS (
+			SELECT blocker_id FROM todo_dependencies WHERE todo_id = ?
+			UNION
+			SELECT d.blocker_id FROM todo_dependencies d JOIN chain c ON d.todo_id = c.blocker_id
+		)
+		SELECT EXISTS (SELECT 1 FROM chain WHERE blocker_id = ?)`, todoID, blockerID).
+		Scan(&found).Error
+	return found, err
+}
+
+// CountOpenBlockers counts the blockers of todoID that are neither done nor
+// in the trash.
+func (r *todoRepository) CountOpenBlockers(todoID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Todo{}).
+		Joins("JOIN todo_dependencies ON todo_dependencies.blocker_id = todos.todo_id").
+		Where("todo_dependencies.todo_id = ? AND todos.status <> ?", todoID, models.StatusDone).
+		Count(&count).Error
+	return count, err
+}
+
+// GetBlockedIDs returns which of todoIDs have at least one open blocker.
+func (r *todoRepository) GetBlockedIDs(todoIDs []uint) ([]uint, error) {
+	var ids []uint
+	if len(todoIDs) == 0 {
+		return ids, nil
+	}
+
+	err := r.db.Model(&models.TodoDependency{}).
+		Distinct("todo_dependencies.todo_id").
+		Joins("JOIN todos ON todos.todo_id = todo_dependencies.blocker_id AND todos.deleted_at IS NULL").
+		Where("todo_dependencies.todo_id IN ? AND todos.status <> ?", todoIDs, models.StatusDone).
+		Pluck("todo_dependencies.todo_id", &ids).Error
+	return ids, err
+}
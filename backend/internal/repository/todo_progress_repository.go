@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"errors"
+
+	"task-management/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// subtaskProgressColumns computes Todo.SubtaskTotal, SubtaskDone and
+// Progress in the query itself, so listing todos needs no subtask preload.
+const subtaskProgressColumns = `todos.*,
+	(SELECT COUNT(*) FROM subtasks s
+		WHERE s.todo_id = todos.todo_id AND s.deleted_at IS NULL) AS subtask_total,
+	(SELECT COUNT(*) FROM subtasks s
+		WHERE s.todo_id = todos.todo_id AND s.deleted_at IS NULL AND s.is_completed = 'yes') AS subtask_done,
+	(SELECT COALESCE(100 * SUM(CASE WHEN s.is_completed = 'yes' THEN 1 ELSE 0 END) / NULLIF(COUNT(*), 0), 0) FROM subtasks s
+		WHERE s.todo_id = todos.todo_id AND s.deleted_at IS NULL) AS progress`
+
+func withSubtaskProgress(db *gorm.DB) *gorm.DB {
+	return db.Select(subtaskProgressColumns)
+}
+
+// CountSubtasks returns how many subtasks the todo has and how many of them
+// are completed.
+func (r *todoRepository) CountSubtasks(todoID uint) (int64, int64, error) {
+	var counts struct {
+		Total int64
+		Done  int64
+	}
+	err := r.db.Model(&models.Subtask{}).
+		Select("COUNT(*) AS total, COALESCE(SUM(CASE WHEN is_completed = ? THEN 1 ELSE 0 END), 0) AS done", models.CompletionYes).
+		Where("todo_id = ?", todoID).
+		Scan(&counts).Error
+	return counts.Total, counts.Done, err
+}
+
+// GetSubtaskRules returns the user's rules, all off if none were saved.
+func (r *todoRepository) GetSubtaskRules(userID uint) (*models.SubtaskRules, error) {
+	rules := models.SubtaskRules{UserID: userID}
+	err := r.db.Where("user_id = ?", userID).First(&rules).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &rules, nil
+	}
+	return &rules, err
+}
+
+func (r *todoRepository) SaveSubtaskRules(rules *models.SubtaskRules) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"auto_complete", "auto_reopen", "updated_at"}),
+	}).Create(rules).Error
+}
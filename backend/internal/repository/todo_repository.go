@@ -1,6 +1,9 @@
 package repository
 
 import (
+    "errors"
+    "time"
+
     "task-management/internal/models"
     
     "gorm.io/gorm"
@@ -8,13 +11,48 @@ import (
 
 type TodoRepository interface {
     Create(todo *models.Todo) error
-    GetByUserID(userID uint, status, category string) ([]models.Todo, error)
+    Find(scope TodoScope, status, category string, assigneeID uint) ([]models.Todo, error)
+    FindInBatches(scope TodoScope, status, category string, batchSize int, fn func(todos []models.Todo) error) error
     GetByIDPublic(id uint) (*models.Todo, error)
     GetByID(id, userID uint) (*models.Todo, error)
     Update(todo *models.Todo) error
-    Delete(id, userID uint) error
+    Delete(id, userID, version uint) error
+    GetSubtaskByID(id, todoID uint) (*models.Subtask, error)
+    UpdateSubtask(subtask *models.Subtask) error
+    GetIDsByFilter(scope TodoScope, status, category string, deleted bool, limit int) ([]uint, error)
+    GetDeletedByID(id, userID uint) (*models.Todo, error)
+    Restore(id, userID uint) error
+    GetDeleted(scope TodoScope) ([]models.Todo, error)
+    Purge(id, userID uint) error
+    PurgeDeletedBefore(t time.Time) (int64, error)
+    CreateRevision(revision *models.TodoRevision) error
+    GetRevisions(todoID uint) ([]models.TodoRevision, error)
+    GetRevision(todoID uint, revision int) (*models.TodoRevision, error)
+    NextRevisionNumber(todoID uint) (int, error)
+    AddDependency(dependency *models.TodoDependency) (int64, error)
+    RemoveDependency(todoID, blockerID uint) (int64, error)
+    GetBlockers(todoID uint) ([]models.Todo, error)
+    DependsOn(todoID, blockerID uint) (bool, error)
+    CountOpenBlockers(todoID uint) (int64, error)
+    GetBlockedIDs(todoIDs []uint) ([]uint, error)
+    AddAssignee(assignee *models.TodoAssignee) (int64, error)
+    RemoveAssignee(todoID, userID uint) (int64, error)
+    GetAssignees(todoID uint) ([]models.TodoAssignee, error)
+    CountSubtasks(todoID uint) (int64, int64, error)
+    GetSubtaskRules(userID uint) (*models.SubtaskRules, error)
+    SaveSubtaskRules(rules *models.SubtaskRules) error
+    GetBoard(scope TodoScope, category string) ([]models.Todo, error)
+    GetColumn(workspaceID uint, status models.Status) ([]models.Todo, error)
+    LastRank(workspaceID uint, status models.Status) (string, error)
+    AdjacentRank(workspaceID uint, status models.Status, rank string, after bool, excludeID uint) (string, error)
+    UpdateRank(id uint, rank string) error
+    Transaction(fn func(repo TodoRepository) error) error
 }
 
+// ErrStaleVersion is returned by conditional updates when the row was
+// changed since it was read.
+var ErrStaleVersion = errors.New("stale version")
+
 type todoRepository struct {
     db *gorm.DB
 }
@@ -24,12 +62,21 @@ func NewTodoRepository(db *gorm.DB) TodoRepository {
 }
 
 func (r *todoRepository) Create(todo *models.Todo) error {
-    return r.db.Create(todo).Error
+    if err := r.db.Create(todo).Error; err != nil {
+        return err
+    }
+    // On SQLite a trigger assigns public_id after the insert returned.
+    if todo.PublicID == 0 && isSQLite(r.db) {
+        return r.db.Model(&models.Todo{}).Where("todo_id = ?", todo.ID).Pluck("public_id", &todo.PublicID).Error
+    }
+    return nil
 }
 
-func (r *todoRepository) GetByUserID(userID uint, status, category string) ([]models.Todo, error) {
+// Find returns the todos in scope, newest first. A non-zero assigneeID keeps
+// only the todos assigned to that user.
+func (r *todoRepository) Find(scope TodoScope, status, category string, assigneeID uint) ([]models.Todo, error) {
     var todos []models.Todo
-    query := r.db.Where("user_id = ?", userID)
+    query := withSubtaskProgress(scope.apply(r.db)).Preload("Assignees")
     
     if status != "" {
         query = query.Where("status = ?", status)
@@ -38,26 +85,98 @@ func (r *todoRepository) GetByUserID(userID uint, status, category string) ([]mo
     if category != "" {
         query = query.Where("category = ?", category)
     }
+
+    if assigneeID != 0 {
+        query = query.Where("todo_id IN (SELECT todo_id FROM todo_assignees WHERE user_id = ?)", assigneeID)
+    }
     
     err := query.Order("created_at DESC").Find(&todos).Error
     return todos, err
 }
 
+// FindInBatches walks the todos in scope (with subtasks) in primary key
+// order, handing them to fn batchSize at a time so callers never hold the
+// whole list in memory.
+func (r *todoRepository) FindInBatches(scope TodoScope, status, category string, batchSize int, fn func(todos []models.Todo) error) error {
+    var todos []models.Todo
+    query := scope.apply(r.db).Preload("Subtasks")
+    
+    if status != "" {
+        query = query.Where("status = ?", status)
+    }
+    
+    if category != "" {
+        query = query.Where("category = ?", category)
+    }
+    
+    return query.FindInBatches(&todos, batchSize, func(tx *gorm.DB, batch int) error {
+        return fn(todos)
+    }).Error
+}
+
 func (r *todoRepository) GetByID(id, userID uint) (*models.Todo, error) {
     var todo models.Todo
-    err := r.db.Where("todo_id = ? AND user_id = ?", id, userID).
+    err := withSubtaskProgress(MemberScope(userID).apply(r.db)).
+        Where("todo_id = ?", id).
         Preload("Subtasks").
+        Preload("Assignees").
         First(&todo).Error
     return &todo, err
 }
 
+// Update saves the todo only if the stored version still equals
+// todo.Version, and increments the version. It returns ErrStaleVersion when
+// the todo was changed in the meantime.
 func (r *todoRepository) Update(todo *models.Todo) error {
-    return r.db.Save(todo).Error
+    version := todo.Version
+    todo.Version++
+    
+    result := r.db.Model(todo).
+        Where("version = ?", version).
+        Select("*").
+        Omit("User", "Subtasks", "Assignees").
+        Updates(todo)
+    if result.Error == nil && result.RowsAffected == 0 {
+        result.Error = ErrStaleVersion
+    }
+    if result.Error != nil {
+        todo.Version = version
+    }
+    return result.Error
 }
 
-func (r *todoRepository) Delete(id, userID uint) error {
-    return r.db.Where("todo_id = ? AND user_id = ?", id, userID).
-        Delete(&models.Todo{}).Error
+// Delete soft-deletes the todo together with its subtasks, provided it is
+// still at version. Both get the same deleted_at so Restore can bring back
+// exactly the subtasks removed with it. It returns ErrStaleVersion when the
+// todo was changed in the meantime and gorm.ErrRecordNotFound when it is
+// gone or not in the user's workspaces.
+func (r *todoRepository) Delete(id, userID, version uint) error {
+    return r.db.Transaction(func(tx *gorm.DB) error {
+        now := time.Now()
+        owned := MemberScope(userID).apply(tx.Model(&models.Todo{})).Select("todo_id").Where("todo_id = ? AND version = ?", id, version)
+
+        if err := tx.Model(&models.Subtask{}).
+            Where("todo_id IN (?)", owned).
+            Update("deleted_at", now).Error; err != nil {
+            return err
+        }
+
+        result := tx.Model(&models.Todo{}).
+            Where("todo_id IN (?)", owned).
+            Update("deleted_at", now)
+        if result.Error != nil || result.RowsAffected > 0 {
+            return result.Error
+        }
+
+        var exists int64
+        if err := MemberScope(userID).apply(tx.Model(&models.Todo{})).Where("todo_id = ?", id).Count(&exists).Error; err != nil {
+            return err
+        }
+        if exists > 0 {
+            return ErrStaleVersion
+        }
+        return gorm.ErrRecordNotFound
+    })
 }
 
 func (r *todoRepository) GetByIDPublic(id uint) (*models.Todo, error) {
@@ -67,3 +186,194 @@ func (r *todoRepository) GetByIDPublic(id uint) (*models.Todo, error) {
 		First(&todo).Error
 	return &todo, err
 }
+
+func (r *todoRepository) GetSubtaskByID(id, todoID uint) (*models.Subtask, error) {
+	var subtask models.Subtask
+	err := r.db.Where("subtask_id = ? AND todo_id = ?", id, todoID).First(&subtask).Error
+	return &subtask, err
+}
+
+// UpdateSubtask is the subtask counterpart of Update.
+func (r *todoRepository) UpdateSubtask(subtask *models.Subtask) error {
+	version := subtask.Version
+	subtask.Version++
+
+	result := r.db.Model(subtask).
+		Where("version = ?", version).
+		Select("*").
+		Omit("Todo").
+		Updates(subtask)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = ErrStaleVersion
+	}
+	if result.Error != nil {
+		subtask.Version = version
+	}
+	return result.Error
+}
+
+func (r *todoRepository) GetIDsByFilter(scope TodoScope, status, category string, deleted bool, limit int) ([]uint, error) {
+	var ids []uint
+	query := scope.apply(r.db.Model(&models.Todo{}))
+
+	if deleted {
+		query = query.Unscoped().Where("deleted_at IS NOT NULL")
+	}
+
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if category != "" {
+		query = query.Where("category = ?", category)
+	}
+
+	err := query.Order("todo_id").Limit(limit).Pluck("todo_id", &ids).Error
+	return ids, err
+}
+
+func (r *todoRepository) GetDeletedByID(id, userID uint) (*models.Todo, error) {
+	var todo models.Todo
+	err := MemberScope(userID).apply(r.db.Unscoped()).
+		Where("todo_id = ? AND deleted_at IS NOT NULL", id).
+		First(&todo).Error
+	return &todo, err
+}
+
+func (r *todoRepository) Restore(id, userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		todo, err := (&todoRepository{db: tx}).GetDeletedByID(id, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Unscoped().Model(&models.Subtask{}).
+			Where("todo_id = ? AND deleted_at = ?", id, todo.DeletedAt.Time).
+			Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Model(&models.Todo{}).
+			Where("todo_id = ?", todo.ID).
+			Update("deleted_at", nil).Error
+	})
+}
+
+func (r *todoRepository) GetDeleted(scope TodoScope) ([]models.Todo, error) {
+	var todos []models.Todo
+	err := scope.apply(r.db.Unscoped()).
+		Where("deleted_at IS NOT NULL").
+		Preload("Subtasks", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Order("deleted_at DESC").
+		Find(&todos).Error
+	return todos, err
+}
+
+// Purge permanently removes a todo that is already in the trash, along with
+// everything that belongs to it.
+func (r *todoRepository) Purge(id, userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		trashed := MemberScope(userID).apply(tx.Unscoped().Model(&models.Todo{})).Select("todo_id").
+			Where("todo_id = ? AND deleted_at IS NOT NULL", id)
+
+		if err := purgeTodoData(tx, trashed); err != nil {
+			return err
+		}
+
+		return MemberScope(userID).apply(tx.Unscoped()).
+			Where("todo_id = ? AND deleted_at IS NOT NULL", id).
+			Delete(&models.Todo{}).Error
+	})
+}
+
+// PurgeDeletedBefore permanently removes every todo (and its subtasks) that
+// was soft-deleted before t. It returns the number of todos removed.
+func (r *todoRepository) PurgeDeletedBefore(t time.Time) (int64, error) {
+	var purged int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		expired := tx.Unscoped().Model(&models.Todo{}).Select("todo_id").Where("deleted_at < ?", t)
+
+		if err := purgeTodoData(tx, expired); err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Where("deleted_at < ?", t).Delete(&models.Todo{})
+		purged = result.RowsAffected
+		return result.Error
+	})
+	return purged, err
+}
+
+// purgeTodoData deletes the rows that belong to the todos selected by the
+// todos subquery: subtasks, revisions, comments, dependencies, time
+// entries, assignees, notifications and stream events. Attachments are left
+// to the orphan sweep, which also removes their stored files.
+func purgeTodoData(tx *gorm.DB, todos *gorm.DB) error {
+	if err := tx.Unscoped().Where("todo_id IN (?)", todos).Delete(&models.Subtask{}).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("todo_id IN (?)", todos).Delete(&models.TodoRevision{}).Error; err != nil {
+		return err
+	}
+
+	comments := tx.Unscoped().Model(&models.Comment{}).Select("comment_id").Where("todo_id IN (?)", todos)
+	if err := tx.Where("comment_id IN (?)", comments).Delete(&models.CommentRevision{}).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Unscoped().Where("todo_id IN (?)", todos).Delete(&models.Comment{}).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("todo_id IN (?) OR blocker_id IN (?)", todos, todos).Delete(&models.TodoDependency{}).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("todo_id IN (?)", todos).Delete(&models.TimeEntry{}).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("todo_id IN (?)", todos).Delete(&models.TodoAssignee{}).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("todo_id IN (?)", todos).Delete(&models.Notification{}).Error; err != nil {
+		return err
+	}
+
+	return tx.Where("todo_id IN (?)", todos).Delete(&models.TodoEvent{}).Error
+}
+
+func (r *todoRepository) CreateRevision(revision *models.TodoRevision) error {
+	return r.db.Create(revision).Error
+}
+
+func (r *todoRepository) GetRevisions(todoID uint) ([]models.TodoRevision, error) {
+	var revisions []models.TodoRevision
+	err := r.db.Where("todo_id = ?", todoID).Order("revision DESC").Find(&revisions).Error
+	return revisions, err
+}
+
+func (r *todoRepository) GetRevision(todoID uint, revision int) (*models.TodoRevision, error) {
+	var rev models.TodoRevision
+	err := r.db.Where("todo_id = ? AND revision = ?", todoID, revision).First(&rev).Error
+	return &rev, err
+}
+
+func (r *todoRepository) NextRevisionNumber(todoID uint) (int, error) {
+	var last int
+	err := r.db.Model(&models.TodoRevision{}).
+		Where("todo_id = ?", todoID).
+		Select("COALESCE(MAX(revision), 0)").
+		Scan(&last).Error
+	return last + 1, err
+}
+
+// Transaction runs fn with a repository bound to a single database
+// transaction. Returning an error from fn rolls everything back.
+func (r *todoRepository) Transaction(fn func(repo TodoRepository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&todoRepository{db: tx})
+	})
+}
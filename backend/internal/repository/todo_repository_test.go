@@ -0,0 +1,230 @@
+package repository_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"task-management/internal/database/dbtest"
+	"task-management/internal/models"
+	"task-management/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// newWorkspace creates a user with a workspace they own and returns both IDs.
+func newWorkspace(t *testing.T, db *gorm.DB, username string) (uint, uint) {
+	t.Helper()
+
+	user := &models.User{Username: username, Email: username + "@example.com", PasswordHash: "x"}
+	if err := repository.NewUserRepository(db).Create(user); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	workspace := &models.Workspace{Name: username, OwnerID: user.ID}
+	if err := repository.NewWorkspaceRepository(db).Create(workspace); err != nil {
+		t.Fatalf("create workspace: %v", err)
+	}
+	return user.ID, workspace.ID
+}
+
+func newTodo(t *testing.T, repo repository.TodoRepository, todo *models.Todo) *models.Todo {
+	t.Helper()
+
+	if err := repo.Create(todo); err != nil {
+		t.Fatalf("create todo: %v", err)
+	}
+	return todo
+}
+
+func countSubtasks(t *testing.T, db *gorm.DB, todoID uint) (live, all int64) {
+	t.Helper()
+
+	if err := db.Model(&models.Subtask{}).Where("todo_id = ?", todoID).Count(&live).Error; err != nil {
+		t.Fatalf("count subtasks: %v", err)
+	}
+	if err := db.Unscoped().Model(&models.Subtask{}).Where("todo_id = ?", todoID).Count(&all).Error; err != nil {
+		t.Fatalf("count subtasks: %v", err)
+	}
+	return live, all
+}
+
+func TestTodoDeleteRestoreWithSubtasks(t *testing.T) {
+	db := dbtest.New(t)
+	repo := repository.NewTodoRepository(db)
+	userID, workspaceID := newWorkspace(t, db, "alice")
+
+	todo := newTodo(t, repo, &models.Todo{
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+		Title:       "Release",
+		Subtasks: []models.Subtask{
+			{Title: "Changelog"},
+			{Title: "Tag"},
+			{Title: "Announce"},
+		},
+	})
+
+	// A subtask deleted on its own earlier must stay deleted after the
+	// restore.
+	if err := db.Delete(&todo.Subtasks[2]).Error; err != nil {
+		t.Fatalf("delete subtask: %v", err)
+	}
+
+	if err := repo.Delete(todo.ID, userID, todo.Version); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := repo.GetByID(todo.ID, userID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("GetByID after Delete: got %v, want ErrRecordNotFound", err)
+	}
+	if live, all := countSubtasks(t, db, todo.ID); live != 0 || all != 3 {
+		t.Fatalf("after Delete: %d live of %d subtasks, want 0 of 3", live, all)
+	}
+
+	deleted, err := repo.GetDeleted(repository.WorkspaceScope(workspaceID, userID))
+	if err != nil {
+		t.Fatalf("GetDeleted: %v", err)
+	}
+	if len(deleted) != 1 || deleted[0].ID != todo.ID || len(deleted[0].Subtasks) != 3 {
+		t.Fatalf("GetDeleted = %+v, want the todo with its 3 subtasks", deleted)
+	}
+
+	if err := repo.Restore(todo.ID, userID); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	restored, err := repo.GetByID(todo.ID, userID)
+	if err != nil {
+		t.Fatalf("GetByID after Restore: %v", err)
+	}
+	if len(restored.Subtasks) != 2 {
+		t.Fatalf("restored todo has %d subtasks, want 2", len(restored.Subtasks))
+	}
+	for _, subtask := range restored.Subtasks {
+		if subtask.ID == todo.Subtasks[2].ID {
+			t.Fatalf("subtask %d deleted before the todo was restored with it", subtask.ID)
+		}
+	}
+}
+
+func TestTodoDeleteRestoreRequiresMembership(t *testing.T) {
+	db := dbtest.New(t)
+	repo := repository.NewTodoRepository(db)
+	ownerID, workspaceID := newWorkspace(t, db, "alice")
+	otherID, _ := newWorkspace(t, db, "bob")
+
+	todo := newTodo(t, repo, &models.Todo{
+		UserID:      ownerID,
+		WorkspaceID: workspaceID,
+		Title:       "Private",
+		Subtasks:    []models.Subtask{{Title: "Step"}},
+	})
+
+	if err := repo.Delete(todo.ID, otherID, todo.Version); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("Delete by a non-member: got %v, want ErrRecordNotFound", err)
+	}
+	if _, err := repo.GetByID(todo.ID, ownerID); err != nil {
+		t.Fatalf("todo was deleted by a non-member: %v", err)
+	}
+	if live, _ := countSubtasks(t, db, todo.ID); live != 1 {
+		t.Fatalf("subtasks were deleted by a non-member: %d live, want 1", live)
+	}
+
+	if err := repo.Delete(todo.ID, ownerID, todo.Version); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := repo.Restore(todo.ID, otherID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("Restore by a non-member: got %v, want ErrRecordNotFound", err)
+	}
+}
+
+func TestTodoDeleteStaleVersion(t *testing.T) {
+	db := dbtest.New(t)
+	repo := repository.NewTodoRepository(db)
+	userID, workspaceID := newWorkspace(t, db, "alice")
+
+	todo := newTodo(t, repo, &models.Todo{
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+		Title:       "Draft",
+		Subtasks:    []models.Subtask{{Title: "Outline"}},
+	})
+	read := todo.Version
+
+	// Someone else saves the todo after it was read.
+	todo.Title = "Final"
+	if err := repo.Update(todo); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	if err := repo.Delete(todo.ID, userID, read); !errors.Is(err, repository.ErrStaleVersion) {
+		t.Fatalf("Delete at version %d: got %v, want ErrStaleVersion", read, err)
+	}
+	if _, err := repo.GetByID(todo.ID, userID); err != nil {
+		t.Fatalf("todo was deleted despite the stale version: %v", err)
+	}
+	if live, _ := countSubtasks(t, db, todo.ID); live != 1 {
+		t.Fatalf("subtasks were deleted despite the stale version: %d live, want 1", live)
+	}
+
+	if err := repo.Delete(todo.ID, userID, todo.Version); err != nil {
+		t.Fatalf("Delete at the current version: %v", err)
+	}
+}
+
+func TestTodoPurgeRemovesTodoData(t *testing.T) {
+	db := dbtest.New(t)
+	repo := repository.NewTodoRepository(db)
+	userID, workspaceID := newWorkspace(t, db, "alice")
+
+	newTodoWithData := func(title string) *models.Todo {
+		todo := newTodo(t, repo, &models.Todo{
+			UserID:      userID,
+			WorkspaceID: workspaceID,
+			Title:       title,
+			Subtasks:    []models.Subtask{{Title: "Step"}},
+		})
+		rows := []interface{}{
+			&models.Notification{UserID: userID, Type: models.NotificationMention, TodoID: &todo.ID},
+			&models.TodoEvent{WorkspaceID: workspaceID, TodoID: &todo.ID, UserID: userID, Type: models.EventTodoCreated, Payload: "{}"},
+		}
+		for _, row := range rows {
+			if err := db.Create(row).Error; err != nil {
+				t.Fatalf("create %T: %v", row, err)
+			}
+		}
+		return todo
+	}
+	count := func(model interface{}, todoID uint) int64 {
+		var n int64
+		if err := db.Unscoped().Model(model).Where("todo_id = ?", todoID).Count(&n).Error; err != nil {
+			t.Fatalf("count %T: %v", model, err)
+		}
+		return n
+	}
+
+	purged := newTodoWithData("Purged")
+	expired := newTodoWithData("Expired")
+	kept := newTodoWithData("Kept")
+
+	for _, todo := range []*models.Todo{purged, expired} {
+		if err := repo.Delete(todo.ID, userID, todo.Version); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+	}
+	if err := repo.Purge(purged.ID, userID); err != nil {
+		t.Fatalf("Purge: %v", err)
+	}
+	if n, err := repo.PurgeDeletedBefore(time.Now().Add(time.Minute)); err != nil || n != 1 {
+		t.Fatalf("PurgeDeletedBefore = %d, %v, want 1 todo", n, err)
+	}
+
+	for _, model := range []interface{}{&models.Todo{}, &models.Subtask{}, &models.Notification{}, &models.TodoEvent{}} {
+		for _, todo := range []*models.Todo{purged, expired} {
+			if n := count(model, todo.ID); n != 0 {
+				t.Errorf("%d %T rows of purged todo %q remain", n, model, todo.Title)
+			}
+		}
+		if n := count(model, kept.ID); n != 1 {
+			t.Errorf("%d %T rows of todo %q remain, want 1", n, model, kept.Title)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+)
+
+// TodoScope restricts todo queries to the workspaces UserID is a member of
+// and, when WorkspaceID is set, to that single workspace.
+type TodoScope struct {
+	UserID      uint
+	WorkspaceID uint
+}
+
+// MemberScope covers every workspace the user belongs to.
+func MemberScope(userID uint) TodoScope {
+	return TodoScope{UserID: userID}
+}
+
+// WorkspaceScope covers one workspace, provided the user is a member of it.
+func WorkspaceScope(workspaceID, userID uint) TodoScope {
+	return TodoScope{UserID: userID, WorkspaceID: workspaceID}
+}
+
+func (s TodoScope) apply(db *gorm.DB) *gorm.DB {
+	db = db.Where("workspace_id IN (SELECT workspace_id FROM workspace_members WHERE user_id = ?)", s.UserID)
+	if s.WorkspaceID != 0 {
+		db = db.Where("workspace_id = ?", s.WorkspaceID)
+	}
+	return db
+}
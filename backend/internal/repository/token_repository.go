@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"time"
+
+	"task-management/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TokenRepository interface {
+	Revoke(token *models.RevokedToken) error
+	IsRevoked(tokenID string, userID uint, issuedAt time.Time) (bool, error)
+	DeleteExpired(before time.Time) error
+}
+
+type tokenRepository struct {
+	db *gorm.DB
+}
+
+func NewTokenRepository(db *gorm.DB) TokenRepository {
+	return &tokenRepository{db: db}
+}
+
+// Revoke records the token. Revoking it twice is not an error.
+func (r *tokenRepository) Revoke(token *models.RevokedToken) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(token).Error
+}
+
+// IsRevoked reports whether the token was revoked on its own, or belongs to
+// a user who is inactive or whose tokens were revoked at or after issuedAt.
+// Token timestamps only have second precision, so a token issued in the
+// same second as a revocation counts as revoked.
+func (r *tokenRepository) IsRevoked(tokenID string, userID uint, issuedAt time.Time) (bool, error) {
+	var count int64
+	if tokenID != "" {
+		if err := r.db.Model(&models.RevokedToken{}).Where("token_id = ?", tokenID).Count(&count).Error; err != nil {
+			return false, err
+		}
+		if count > 0 {
+			return true, nil
+		}
+	}
+
+	err := r.db.Model(&models.User{}).
+		Where("user_id = ? AND (is_active = ? OR tokens_revoked_at >= ?)", userID, false, issuedAt).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *tokenRepository) DeleteExpired(before time.Time) error {
+	return r.db.Where("expires_at < ?", before).Delete(&models.RevokedToken{}).Error
+}
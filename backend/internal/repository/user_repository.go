@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"task-management/internal/models"
 
 	"gorm.io/gorm"
@@ -11,6 +13,10 @@ type UserRepository interface {
 	GetByUsername(username string) (*models.User, error)
 	GetByEmail(email string) (*models.User, error)
 	GetByID(id uint) (*models.User, error)
+	SetCurrentWorkspace(userID uint, workspaceID *uint) error
+	UpdatePassword(userID uint, passwordHash string, at time.Time) error
+	Deactivate(userID uint, at time.Time) error
+	RevokeTokens(userID uint, at time.Time) error
 	RawQuery(query string, dest interface{}) error
 }
 
@@ -46,6 +52,31 @@ func (r *userRepository) GetByID(id uint) (*models.User, error) {
 	return &user, err
 }
 
+func (r *userRepository) SetCurrentWorkspace(userID uint, workspaceID *uint) error {
+	return r.db.Model(&models.User{}).Where("user_id = ?", userID).Update("current_workspace_id", workspaceID).Error
+}
+
+// UpdatePassword stores the hash of the new password and revokes the
+// tokens issued with the old one.
+func (r *userRepository) UpdatePassword(userID uint, passwordHash string, at time.Time) error {
+	return r.db.Model(&models.User{}).Where("user_id = ?", userID).Updates(map[string]interface{}{
+		"password_hash":     passwordHash,
+		"tokens_revoked_at": at,
+	}).Error
+}
+
+// Deactivate blocks the user from logging in and revokes their tokens.
+func (r *userRepository) Deactivate(userID uint, at time.Time) error {
+	return r.db.Model(&models.User{}).Where("user_id = ?", userID).Updates(map[string]interface{}{
+		"is_active":         false,
+		"tokens_revoked_at": at,
+	}).Error
+}
+
+func (r *userRepository) RevokeTokens(userID uint, at time.Time) error {
+	return r.db.Model(&models.User{}).Where("user_id = ?", userID).Update("tokens_revoked_at", at).Error
+}
+
 func (r *userRepository) RawQuery(query string, dest interface{}) error {
 	return r.db.Raw(query).Scan(dest).Error
 }
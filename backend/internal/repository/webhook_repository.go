@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"task-management/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type WebhookRepository interface {
+	Create(webhook *models.Webhook) error
+	GetByUserID(userID uint) ([]models.Webhook, error)
+	GetByID(id, userID uint) (*models.Webhook, error)
+	GetActiveByWorkspace(workspaceID uint) ([]models.Webhook, error)
+	Update(webhook *models.Webhook) error
+	Delete(id, userID uint) error
+	CreateDelivery(delivery *models.WebhookDelivery) error
+	GetDeliveries(webhookID uint, limit int) ([]models.WebhookDelivery, error)
+	GetDeliveryByID(id, webhookID uint) (*models.WebhookDelivery, error)
+}
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+func (r *webhookRepository) Create(webhook *models.Webhook) error {
+	return r.db.Create(webhook).Error
+}
+
+func (r *webhookRepository) GetByUserID(userID uint) ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (r *webhookRepository) GetByID(id, userID uint) (*models.Webhook, error) {
+	var webhook models.Webhook
+	err := r.db.Where("webhook_id = ? AND user_id = ?", id, userID).First(&webhook).Error
+	return &webhook, err
+}
+
+// GetActiveByWorkspace returns the active webhooks of every member of the
+// workspace.
+func (r *webhookRepository) GetActiveByWorkspace(workspaceID uint) ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	err := r.db.Where("user_id IN (SELECT user_id FROM workspace_members WHERE workspace_id = ?)", workspaceID).
+		Where("is_active = ?", true).
+		Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (r *webhookRepository) Update(webhook *models.Webhook) error {
+	return r.db.Save(webhook).Error
+}
+
+func (r *webhookRepository) Delete(id, userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("webhook_id = ?", id).Delete(&models.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("webhook_id = ? AND user_id = ?", id, userID).Delete(&models.Webhook{}).Error
+	})
+}
+
+func (r *webhookRepository) CreateDelivery(delivery *models.WebhookDelivery) error {
+	return r.db.Create(delivery).Error
+}
+
+func (r *webhookRepository) GetDeliveries(webhookID uint, limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := r.db.Where("webhook_id = ?", webhookID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&deliveries).Error
+	return deliveries, err
+}
+
+func (r *webhookRepository) GetDeliveryByID(id, webhookID uint) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	err := r.db.Where("delivery_id = ? AND webhook_id = ?", id, webhookID).First(&delivery).Error
+	return &delivery, err
+}
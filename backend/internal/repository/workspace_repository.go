@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"time"
+
+	"task-management/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WorkspaceRepository interface {
+	Create(workspace *models.Workspace) error
+	GetByID(id uint) (*models.Workspace, error)
+	GetPersonal(userID uint) (*models.Workspace, error)
+	GetByMember(userID uint) ([]models.Workspace, error)
+	Update(workspace *models.Workspace) error
+	Delete(id uint) error
+	GetMember(workspaceID, userID uint) (*models.WorkspaceMember, error)
+	GetMembers(workspaceID uint) ([]models.Member, error)
+	GetMemberIDs(workspaceID uint) ([]uint, error)
+	UpdateMemberRole(workspaceID, userID uint, role models.WorkspaceRole) error
+	RemoveMember(workspaceID, userID uint) error
+	TransferOwnership(workspaceID, fromID, toID uint) error
+	CreateInvitation(invitation *models.WorkspaceInvitation) error
+	GetInvitation(id uint) (*models.WorkspaceInvitation, error)
+	GetInvitations(workspaceID uint) ([]models.WorkspaceInvitation, error)
+	GetInvitationsFor(userID uint, email string) ([]models.WorkspaceInvitation, error)
+	DeleteInvitation(id uint) error
+	AcceptInvitation(invitation *models.WorkspaceInvitation, userID uint) error
+}
+
+type workspaceRepository struct {
+	db *gorm.DB
+}
+
+func NewWorkspaceRepository(db *gorm.DB) WorkspaceRepository {
+	return &workspaceRepository{db: db}
+}
+
+// Create stores the workspace and makes its owner a member with the owner
+// role.
+func (r *workspaceRepository) Create(workspace *models.Workspace) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(workspace).Error; err != nil {
+			return err
+		}
+		workspace.Role = models.RoleOwner
+		return tx.Create(&models.WorkspaceMember{
+			WorkspaceID: workspace.ID,
+			UserID:      workspace.OwnerID,
+			Role:        models.RoleOwner,
+		}).Error
+	})
+}
+
+func (r *workspaceRepository) GetByID(id uint) (*models.Workspace, error) {
+	var workspace models.Workspace
+	err := r.db.Where("workspace_id = ?", id).First(&workspace).Error
+	return &workspace, err
+}
+
+func (r *workspaceRepository) GetPersonal(userID uint) (*models.Workspace, error) {
+	var workspace models.Workspace
+	err := r.db.Where("owner_id = ? AND personal = ?", userID, true).First(&workspace).Error
+	if err == nil {
+		workspace.Role = models.RoleOwner
+	}
+	return &workspace, err
+}
+
+// GetByMember lists the workspaces the user belongs to, personal first,
+// with Role set to the user's role in each.
+func (r *workspaceRepository) GetByMember(userID uint) ([]models.Workspace, error) {
+	var workspaces []models.Workspace
+	err := r.db.Model(&models.Workspace{}).
+		Select("workspaces.*, workspace_members.role AS role").
+		Joins("JOIN workspace_members ON workspace_members.workspace_id = workspaces.workspace_id").
+		Where("workspace_members.user_id = ?", userID).
+		Order("workspaces.personal DESC, workspaces.name ASC").
+		Scan(&workspaces).Error
+	return workspaces, err
+}
+
+func (r *workspaceRepository) Update(workspace *models.Workspace) error {
+	return r.db.Save(workspace).Error
+}
+
+// Delete soft-deletes the workspace and moves its todos to the trash, where
+// the retention job eventually purges them. Its templates are soft-deleted
+// and memberships and pending invitations are removed.
+func (r *workspaceRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		todos := tx.Model(&models.Todo{}).Select("todo_id").Where("workspace_id = ?", id)
+
+		if err := tx.Model(&models.Subtask{}).Where("todo_id IN (?)", todos).Update("deleted_at", now).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Todo{}).Where("workspace_id = ?", id).Update("deleted_at", now).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("workspace_id = ?", id).Delete(&models.TodoTemplate{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("workspace_id = ?", id).Delete(&models.WorkspaceInvitation{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("workspace_id = ?", id).Delete(&models.WorkspaceMember{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.User{}).Where("current_workspace_id = ?", id).Update("current_workspace_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Where("workspace_id = ?", id).Delete(&models.Workspace{}).Error
+	})
+}
+
+func (r *workspaceRepository) GetMember(workspaceID, userID uint) (*models.WorkspaceMember, error) {
+	var member models.WorkspaceMember
+	err := r.db.Where("workspace_id = ? AND user_id = ?", workspaceID, userID).First(&member).Error
+	return &member, err
+}
+
+// GetMembers lists the members of the workspace in the order they joined.
+func (r *workspaceRepository) GetMembers(workspaceID uint) ([]models.Member, error) {
+	var members []models.Member
+	err := r.db.Model(&models.WorkspaceMember{}).
+		Select("users.user_id AS id, users.username, users.full_name, workspace_members.role").
+		Joins("JOIN users ON users.user_id = workspace_members.user_id").
+		Where("workspace_members.workspace_id = ?", workspaceID).
+		Order("workspace_members.created_at ASC").
+		Scan(&members).Error
+	return members, err
+}
+
+func (r *workspaceRepository) GetMemberIDs(workspaceID uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&models.WorkspaceMember{}).
+		Where("workspace_id = ?", workspaceID).
+		Pluck("user_id", &ids).Error
+	return ids, err
+}
+
+func (r *workspaceRepository) UpdateMemberRole(workspaceID, userID uint, role models.WorkspaceRole) error {
+	return r.db.Model(&models.WorkspaceMember{}).
+		Where("workspace_id = ? AND user_id = ?", workspaceID, userID).
+		Update("role", role).Error
+}
+
+// RemoveMember drops the membership together with the user's assignments
+// in the workspace and, if the user had switched to the workspace, sends
+// them back to their personal workspace.
+func (r *workspaceRepository) RemoveMember(workspaceID, userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("workspace_id = ? AND user_id = ?", workspaceID, userID).Delete(&models.WorkspaceMember{}).Error; err != nil {
+			return err
+		}
+		todos := tx.Unscoped().Model(&models.Todo{}).Select("todo_id").Where("workspace_id = ?", workspaceID)
+		if err := tx.Where("user_id = ? AND todo_id IN (?)", userID, todos).Delete(&models.TodoAssignee{}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.User{}).
+			Where("user_id = ? AND current_workspace_id = ?", userID, workspaceID).
+			Update("current_workspace_id", nil).Error
+	})
+}
+
+// TransferOwnership makes toID the owner and demotes fromID to admin.
+func (r *workspaceRepository) TransferOwnership(workspaceID, fromID, toID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.WorkspaceMember{}).
+			Where("workspace_id = ? AND user_id = ?", workspaceID, fromID).
+			Update("role", models.RoleAdmin).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.WorkspaceMember{}).
+			Where("workspace_id = ? AND user_id = ?", workspaceID, toID).
+			Update("role", models.RoleOwner).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Workspace{}).
+			Where("workspace_id = ?", workspaceID).
+			Update("owner_id", toID).Error
+	})
+}
+
+func (r *workspaceRepository) CreateInvitation(invitation *models.WorkspaceInvitation) error {
+	return r.db.Create(invitation).Error
+}
+
+func (r *workspaceRepository) GetInvitation(id uint) (*models.WorkspaceInvitation, error) {
+	var invitation models.WorkspaceInvitation
+	err := r.db.Where("workspace_invitation_id = ?", id).Preload("Workspace").First(&invitation).Error
+	return &invitation, err
+}
+
+func (r *workspaceRepository) GetInvitations(workspaceID uint) ([]models.WorkspaceInvitation, error) {
+	var invitations []models.WorkspaceInvitation
+	err := r.db.Where("workspace_id = ?", workspaceID).Order("created_at DESC").Find(&invitations).Error
+	return invitations, err
+}
+
+// GetInvitationsFor lists the pending invitations addressed to the user,
+// either directly or by email address.
+func (r *workspaceRepository) GetInvitationsFor(userID uint, email string) ([]models.WorkspaceInvitation, error) {
+	var invitations []models.WorkspaceInvitation
+	err := r.db.Where("invitee_id = ? OR (invitee_id IS NULL AND LOWER(email) = LOWER(?))", userID, email).
+		Preload("Workspace").
+		Order("created_at DESC").
+		Find(&invitations).Error
+	return invitations, err
+}
+
+func (r *workspaceRepository) DeleteInvitation(id uint) error {
+	return r.db.Where("workspace_invitation_id = ?", id).Delete(&models.WorkspaceInvitation{}).Error
+}
+
+// AcceptInvitation adds the user to the workspace with the invited role and
+// removes the invitation. Accepting while already a member keeps the
+// existing role.
+func (r *workspaceRepository) AcceptInvitation(invitation *models.WorkspaceInvitation, userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		member := &models.WorkspaceMember{
+			WorkspaceID: invitation.WorkspaceID,
+			UserID:      userID,
+			Role:        invitation.Role,
+		}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(member).Error; err != nil {
+			return err
+		}
+		return tx.Where("workspace_invitation_id = ?", invitation.ID).Delete(&models.WorkspaceInvitation{}).Error
+	})
+}
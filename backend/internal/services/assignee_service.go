@@ -0,0 +1,118 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"task-management/internal/models"
+	"task-management/internal/repository"
+)
+
+var errAlreadyAssigned = errors.New("user is already assigned")
+
+type AssigneeService interface {
+	GetAssignees(todoID, userID uint) ([]models.TodoAssignee, error)
+	Assign(todoID, assigneeID, userID uint) (*models.TodoAssignee, error)
+	Unassign(todoID, assigneeID, userID uint) error
+}
+
+type assigneeService struct {
+	todoRepo            repository.TodoRepository
+	workspaceRepo       repository.WorkspaceRepository
+	todoService         TodoService
+	notificationService NotificationService
+}
+
+func NewAssigneeService(todoRepo repository.TodoRepository, workspaceRepo repository.WorkspaceRepository, todoService TodoService, notificationService NotificationService) AssigneeService {
+	return &assigneeService{
+		todoRepo:            todoRepo,
+		workspaceRepo:       workspaceRepo,
+		todoService:         todoService,
+		notificationService: notificationService,
+	}
+}
+
+func (s *assigneeService) GetAssignees(todoID, userID uint) ([]models.TodoAssignee, error) {
+	if _, err := s.todoService.GetTodoByID(todoID, userID); err != nil {
+		return nil, err
+	}
+
+	assignees, err := s.todoRepo.GetAssignees(todoID)
+	if err != nil {
+		return nil, errors.New("database error")
+	}
+	return assignees, nil
+}
+
+// Assign assigns the todo to a member of its workspace and notifies them,
+// unless they assigned themselves.
+func (s *assigneeService) Assign(todoID, assigneeID, userID uint) (*models.TodoAssignee, error) {
+	todo, err := s.todoService.GetEditableTodo(todoID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := workspaceRole(s.workspaceRepo, todo.WorkspaceID, assigneeID); err != nil {
+		if err.Error() == "workspace not found" {
+			return nil, fmt.Errorf("%w: the assignee must be a member of the todo's workspace", ErrInvalidValue)
+		}
+		return nil, err
+	}
+
+	assignee := &models.TodoAssignee{TodoID: todoID, UserID: assigneeID, AssignedBy: userID}
+	created, err := s.todoRepo.AddAssignee(assignee)
+	if err != nil {
+		return nil, errors.New("failed to assign todo")
+	}
+	if created == 0 {
+		return nil, errAlreadyAssigned
+	}
+
+	s.notify(todo, assigneeID, userID, models.NotificationTodoAssigned, "You were assigned to %q")
+	return assignee, nil
+}
+
+// Unassign removes an assignee. Anyone who can see the todo may unassign
+// themselves; unassigning others takes edit access.
+func (s *assigneeService) Unassign(todoID, assigneeID, userID uint) error {
+	var todo *models.Todo
+	var err error
+	if assigneeID == userID {
+		todo, err = s.todoService.GetTodoByID(todoID, userID)
+	} else {
+		todo, err = s.todoService.GetEditableTodo(todoID, userID)
+	}
+	if err != nil {
+		return err
+	}
+
+	removed, err := s.todoRepo.RemoveAssignee(todoID, assigneeID)
+	if err != nil {
+		return errors.New("failed to unassign todo")
+	}
+	if removed == 0 {
+		return errors.New("assignee not found")
+	}
+
+	s.notify(todo, assigneeID, userID, models.NotificationTodoUnassigned, "You were unassigned from %q")
+	return nil
+}
+
+// notify tells the assignee about a change someone else made. Failures are
+// logged rather than undoing the assignment.
+func (s *assigneeService) notify(todo *models.Todo, assigneeID, actorID uint, kind models.NotificationType, format string) {
+	if assigneeID == actorID {
+		return
+	}
+
+	notification := &models.Notification{
+		UserID:  assigneeID,
+		ActorID: actorID,
+		Type:    kind,
+		TodoID:  &todo.ID,
+		Message: fmt.Sprintf(format, todo.Title),
+	}
+	if err := s.notificationService.Notify(notification); err != nil {
+		log.Printf("assignee: failed to notify user %d about todo %d: %v", assigneeID, todo.ID, err)
+	}
+}
@@ -0,0 +1,255 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"task-management/internal/config"
+	"task-management/internal/models"
+	"task-management/internal/repository"
+	"task-management/internal/storage"
+	"task-management/internal/utils"
+
+	"github.com/gabriel-vasile/mimetype"
+	"gorm.io/gorm"
+)
+
+const (
+	attachmentSniffLength  = 3072
+	attachmentFileNameMax  = 255
+	attachmentCleanupBatch = 100
+)
+
+var errAttachmentQuota = errors.New("attachment storage quota exceeded")
+
+type AttachmentService interface {
+	GetAttachments(todoID, userID uint) ([]models.Attachment, error)
+	Upload(ctx context.Context, todoID, userID uint, fileName string, r io.Reader, size int64) (*models.Attachment, error)
+	Open(ctx context.Context, todoID, attachmentID, userID uint) (*models.Attachment, io.ReadCloser, error)
+	DeleteAttachment(ctx context.Context, todoID, attachmentID, userID uint) error
+	RunOrphanCleanup(ctx context.Context)
+}
+
+type attachmentService struct {
+	attachmentRepo repository.AttachmentRepository
+	todoService    TodoService
+	store          storage.BlobStore
+	config         *config.Config
+}
+
+func NewAttachmentService(attachmentRepo repository.AttachmentRepository, todoService TodoService, store storage.BlobStore, cfg *config.Config) AttachmentService {
+	return &attachmentService{
+		attachmentRepo: attachmentRepo,
+		todoService:    todoService,
+		store:          store,
+		config:         cfg,
+	}
+}
+
+func (s *attachmentService) GetAttachments(todoID, userID uint) ([]models.Attachment, error) {
+	if _, err := s.todoService.GetTodoByID(todoID, userID); err != nil {
+		return nil, err
+	}
+	return s.attachmentRepo.GetByTodoID(todoID)
+}
+
+// Upload stores the file under a random key. The content type is detected
+// from the leading bytes of the file; the client supplied name and type are
+// never trusted.
+func (s *attachmentService) Upload(ctx context.Context, todoID, userID uint, fileName string, r io.Reader, size int64) (*models.Attachment, error) {
+	if _, err := s.todoService.GetEditableTodo(todoID, userID); err != nil {
+		return nil, err
+	}
+
+	if size <= 0 {
+		return nil, errors.New("file is empty")
+	}
+	if size > s.config.Storage.MaxFileSize {
+		return nil, fmt.Errorf("file exceeds the maximum size of %d bytes", s.config.Storage.MaxFileSize)
+	}
+
+	// Fail early before storing the file; Create checks the quota again
+	// when the attachment is inserted.
+	used, err := s.attachmentRepo.TotalSizeByUserID(userID)
+	if err != nil {
+		return nil, errors.New("database error")
+	}
+	if used+size > s.config.Storage.UserQuota {
+		return nil, errAttachmentQuota
+	}
+
+	header := make([]byte, attachmentSniffLength)
+	n, err := io.ReadFull(r, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, errors.New("failed to read file")
+	}
+	header = header[:n]
+	contentType := mimetype.Detect(header).String()
+
+	token, err := utils.RandomToken(16)
+	if err != nil {
+		return nil, errors.New("failed to generate storage key")
+	}
+	key := fmt.Sprintf("attachments/%d/%s/%s", userID, token[:2], token)
+
+	hash := sha256.New()
+	content := &countingReader{r: io.MultiReader(bytes.NewReader(header), r)}
+	body := io.TeeReader(io.LimitReader(content, size), hash)
+	if err := s.store.Put(ctx, key, body, size, contentType); err != nil {
+		log.Printf("attachment: failed to store blob %s: %v", key, err)
+		return nil, errors.New("failed to store file")
+	}
+
+	// The declared size is only a claim. Reject the upload if the body was
+	// shorter, or if anything is left after the declared size.
+	if content.n == size {
+		io.CopyN(io.Discard, content, 1)
+	}
+	if content.n != size {
+		s.store.Delete(ctx, key)
+		return nil, errors.New("file size does not match the upload")
+	}
+
+	attachment := &models.Attachment{
+		TodoID:      todoID,
+		UserID:      userID,
+		FileName:    sanitizeFileName(fileName),
+		ContentType: contentType,
+		Size:        size,
+		Checksum:    hex.EncodeToString(hash.Sum(nil)),
+		StorageKey:  key,
+	}
+
+	if err := s.attachmentRepo.Create(attachment, s.config.Storage.UserQuota); err != nil {
+		s.store.Delete(ctx, key)
+		if errors.Is(err, repository.ErrQuotaExceeded) {
+			return nil, errAttachmentQuota
+		}
+		return nil, errors.New("failed to create attachment")
+	}
+
+	return attachment, nil
+}
+
+func (s *attachmentService) Open(ctx context.Context, todoID, attachmentID, userID uint) (*models.Attachment, io.ReadCloser, error) {
+	attachment, err := s.getAttachment(todoID, attachmentID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	blob, err := s.store.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil, errors.New("attachment content not found")
+		}
+		return nil, nil, errors.New("failed to read attachment")
+	}
+
+	return attachment, blob, nil
+}
+
+func (s *attachmentService) DeleteAttachment(ctx context.Context, todoID, attachmentID, userID uint) error {
+	if _, err := s.todoService.GetEditableTodo(todoID, userID); err != nil {
+		return err
+	}
+	attachment, err := s.getAttachment(todoID, attachmentID, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.attachmentRepo.Delete(attachment.ID); err != nil {
+		return errors.New("failed to delete attachment")
+	}
+
+	if err := s.store.Delete(ctx, attachment.StorageKey); err != nil {
+		log.Printf("attachment: failed to delete blob %s: %v", attachment.StorageKey, err)
+	}
+	return nil
+}
+
+// RunOrphanCleanup removes the files of attachments whose todo was purged,
+// checking once an hour until ctx is cancelled.
+func (s *attachmentService) RunOrphanCleanup(ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		orphans, err := s.attachmentRepo.GetOrphaned(attachmentCleanupBatch)
+		if err != nil {
+			log.Printf("attachment: failed to load orphaned attachments: %v", err)
+		}
+		for _, attachment := range orphans {
+			if err := s.store.Delete(ctx, attachment.StorageKey); err != nil {
+				log.Printf("attachment: failed to delete blob %s: %v", attachment.StorageKey, err)
+				continue
+			}
+			if err := s.attachmentRepo.Delete(attachment.ID); err != nil {
+				log.Printf("attachment: failed to delete attachment %d: %v", attachment.ID, err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *attachmentService) getAttachment(todoID, attachmentID, userID uint) (*models.Attachment, error) {
+	if _, err := s.todoService.GetTodoByID(todoID, userID); err != nil {
+		return nil, err
+	}
+
+	attachment, err := s.attachmentRepo.GetByID(attachmentID, todoID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("attachment not found")
+		}
+		return nil, errors.New("database error")
+	}
+	return attachment, nil
+}
+
+// sanitizeFileName keeps only the base name of the uploaded file and strips
+// control characters so it is safe to echo back in headers.
+func sanitizeFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+
+	if name == "" || name == "." || name == "/" {
+		name = "attachment"
+	}
+	if runes := []rune(name); len(runes) > attachmentFileNameMax {
+		name = string(runes[:attachmentFileNameMax])
+	}
+	return name
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"task-management/internal/config"
+	"task-management/internal/database/dbtest"
+	"task-management/internal/models"
+	"task-management/internal/repository"
+	"task-management/internal/storage"
+)
+
+func TestAttachmentUpload(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	root := t.TempDir()
+	store, err := storage.NewLocalStore(root)
+	if err != nil {
+		t.Fatalf("NewLocalStore: %v", err)
+	}
+
+	user := &models.User{Username: "alice", Email: "alice@example.com", PasswordHash: "x"}
+	if err := repository.NewUserRepository(db).Create(user); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	workspaceRepo := repository.NewWorkspaceRepository(db)
+	workspace := &models.Workspace{Name: "alice", OwnerID: user.ID}
+	if err := workspaceRepo.Create(workspace); err != nil {
+		t.Fatalf("create workspace: %v", err)
+	}
+	todoRepo := repository.NewTodoRepository(db)
+	todo := &models.Todo{UserID: user.ID, WorkspaceID: workspace.ID, Title: "Report"}
+	if err := todoRepo.Create(todo); err != nil {
+		t.Fatalf("create todo: %v", err)
+	}
+
+	cfg := &config.Config{Storage: config.StorageConfig{MaxFileSize: 64, UserQuota: 100}}
+	attachmentRepo := repository.NewAttachmentRepository(db)
+	service := NewAttachmentService(attachmentRepo, NewTodoService(todoRepo, workspaceRepo, nil, cfg), store, cfg)
+
+	upload := func(content string, size int64) (*models.Attachment, error) {
+		return service.Upload(ctx, todo.ID, user.ID, "notes.txt", strings.NewReader(content), size)
+	}
+
+	stored, err := upload(strings.Repeat("a", 60), 60)
+	if err != nil {
+		t.Fatalf("Upload: %v", err)
+	}
+	if stored.Size != 60 {
+		t.Errorf("Size = %d, want 60", stored.Size)
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		size    int64
+		want    string
+	}{
+		{"body shorter than the declared size", "short", 30, "file size does not match the upload"},
+		{"body longer than the declared size", strings.Repeat("b", 30), 5, "file size does not match the upload"},
+		{"over the quota", strings.Repeat("c", 41), 41, errAttachmentQuota.Error()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := upload(tt.content, tt.size); err == nil || err.Error() != tt.want {
+				t.Fatalf("Upload error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+
+	used, err := attachmentRepo.TotalSizeByUserID(user.ID)
+	if err != nil {
+		t.Fatalf("TotalSizeByUserID: %v", err)
+	}
+	if used != 60 {
+		t.Errorf("TotalSizeByUserID = %d after rejected uploads, want 60", used)
+	}
+
+	var blobs []string
+	err = filepath.WalkDir(root, func(path string, entry os.DirEntry, err error) error {
+		if err == nil && !entry.IsDir() {
+			blobs = append(blobs, path)
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatalf("list blobs: %v", err)
+	}
+	if len(blobs) != 1 {
+		t.Errorf("found blobs %v, want only the accepted upload", blobs)
+	}
+}
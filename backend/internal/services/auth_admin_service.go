@@ -0,0 +1,116 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"task-management/internal/auth"
+	"task-management/internal/models"
+	"task-management/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+// MinPasswordLength matches the minimum enforced on registration.
+const MinPasswordLength = 6
+
+func validatePassword(password string) error {
+	if len(password) < MinPasswordLength {
+		return fmt.Errorf("%w: password must be at least %d characters", ErrInvalidValue, MinPasswordLength)
+	}
+	return nil
+}
+
+// ResetPassword sets a new password for an active user. Tokens issued
+// before the reset stop working.
+func (s *authService) ResetPassword(username, password string) (*models.User, error) {
+	if err := validatePassword(password); err != nil {
+		return nil, err
+	}
+	user, err := s.activeUser(username)
+	if err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := utils.HashPassword(password)
+	if err != nil {
+		return nil, errors.New("failed to hash password")
+	}
+	if err := s.userRepo.UpdatePassword(user.ID, hashedPassword, time.Now()); err != nil {
+		return nil, errors.New("failed to update password")
+	}
+	return user, nil
+}
+
+// Deactivate blocks the user from logging in and revokes their tokens. The
+// user's data is kept.
+func (s *authService) Deactivate(username string) (*models.User, error) {
+	user, err := s.activeUser(username)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.Deactivate(user.ID, time.Now()); err != nil {
+		return nil, errors.New("failed to deactivate user")
+	}
+	user.IsActive = false
+	return user, nil
+}
+
+// RevokeToken blocks a single token until it expires. The token must have
+// been issued by this server; revocations that have expired are cleaned up
+// on the way.
+func (s *authService) RevokeToken(token string) (*models.RevokedToken, error) {
+	claims, err := auth.ValidateToken(token, s.config)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidValue, err)
+	}
+	if claims.ID == "" || claims.ExpiresAt == nil {
+		return nil, fmt.Errorf("%w: token predates token IDs, revoke all of the user's tokens instead", ErrInvalidValue)
+	}
+
+	if err := s.tokenRepo.DeleteExpired(time.Now()); err != nil {
+		return nil, errors.New("database error")
+	}
+	revoked := &models.RevokedToken{
+		TokenID:   claims.ID,
+		UserID:    claims.UserID,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}
+	if err := s.tokenRepo.Revoke(revoked); err != nil {
+		return nil, errors.New("failed to revoke token")
+	}
+	return revoked, nil
+}
+
+// RevokeUserTokens invalidates every token issued to the user so far.
+func (s *authService) RevokeUserTokens(username string) (*models.User, error) {
+	user, err := s.activeUser(username)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.RevokeTokens(user.ID, time.Now()); err != nil {
+		return nil, errors.New("failed to revoke tokens")
+	}
+	return user, nil
+}
+
+// IsRevoked implements auth.RevocationChecker.
+func (s *authService) IsRevoked(claims *auth.Claims) (bool, error) {
+	var issuedAt time.Time
+	if claims.IssuedAt != nil {
+		issuedAt = claims.IssuedAt.Time
+	}
+	return s.tokenRepo.IsRevoked(claims.ID, claims.UserID, issuedAt)
+}
+
+func (s *authService) activeUser(username string) (*models.User, error) {
+	user, err := s.userRepo.GetByUsername(username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user not found")
+		}
+		return nil, errors.New("database error")
+	}
+	return user, nil
+}
@@ -16,21 +16,32 @@ type AuthService interface {
 	Register(username, email, password, fullname string) (*models.User, error)
 	Login(username, password string) (string, *models.User, error)
 	LoginVulnerable(username, password string) (string, *models.User, error)
+	ResetPassword(username, password string) (*models.User, error)
+	Deactivate(username string) (*models.User, error)
+	RevokeToken(token string) (*models.RevokedToken, error)
+	RevokeUserTokens(username string) (*models.User, error)
+	IsRevoked(claims *auth.Claims) (bool, error)
 }
 
 type authService struct {
-	userRepo repository.UserRepository
-	config   *config.Config
+	userRepo  repository.UserRepository
+	tokenRepo repository.TokenRepository
+	config    *config.Config
 }
 
-func NewAuthService(userRepo repository.UserRepository, cfg *config.Config) AuthService {
+func NewAuthService(userRepo repository.UserRepository, tokenRepo repository.TokenRepository, cfg *config.Config) AuthService {
 	return &authService{
-		userRepo: userRepo,
-		config:   cfg,
+		userRepo:  userRepo,
+		tokenRepo: tokenRepo,
+		config:    cfg,
 	}
 }
 
 func (s *authService) Register(username, email, password, fullname string) (*models.User, error) {
+	if err := validatePassword(password); err != nil {
+		return nil, err
+	}
+
 	// Check if user exists
 	if _, err := s.userRepo.GetByUsername(username); err == nil {
 		return nil, errors.New("username already exists")
@@ -51,7 +62,6 @@ func (s *authService) Register(username, email, password, fullname string) (*mod
 		Username:     username,
 		Email:        email,
 		PasswordHash: hashedPassword,
-		Password:     password,
 		FullName:     fullname,
 		IsActive:     true,
 	}
@@ -86,18 +96,17 @@ func (s *authService) Login(username, password string) (string, *models.User, er
 
 func (s *authService) LoginVulnerable(username, password string) (string, *models.User, error) {
 	// VULNERABLE: Bypass dengan SQL injection
-	// Attacker bisa input:
-	// username: admin' --
-	// password: apapun
+	// Attacker bisa input username yang menyisipkan baris sendiri lewat
+	// UNION SELECT, dengan password_hash bcrypt buatan attacker:
+	// username: nobody' UNION SELECT ... --
+	// password: password yang cocok dengan hash tersebut
 
-	// Atau lebih advanced:
+	// Atau membaca data lain:
 	// username: admin' OR '1'='1
-	// password: ' OR '1'='1
     
 	query := fmt.Sprintf(
-		"SELECT * FROM users WHERE username = '%s' AND password = '%s'",
+		"SELECT * FROM users WHERE username = '%s'",
 		username,
-		password,
 	)
 
 	var user models.User
@@ -106,7 +115,7 @@ func (s *authService) LoginVulnerable(username, password string) (string, *model
 		return "", nil, errors.New("invalid credentials")
 	}
 
-	if err != nil {
+	if err != nil || !utils.CheckPasswordHash(password, user.PasswordHash) {
 		return "", nil, errors.New("invalid credentials")
 	}
 
@@ -0,0 +1,270 @@
+package services
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"task-management/internal/models"
+	"task-management/internal/repository"
+	"task-management/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+const (
+	calendarTokenBytes = 32
+	calendarBatchSize  = 200
+	calendarUIDDomain  = "task-management"
+	icsTimeLayout      = "20060102T150405Z"
+	icsDateLayout      = "20060102"
+	icsLineLimit       = 75
+)
+
+type CalendarService interface {
+	GetFeed(userID uint) (*models.CalendarFeed, error)
+	RegenerateToken(userID uint) (string, *models.CalendarFeed, error)
+	RevokeFeed(userID uint) error
+	FeedOwner(token string) (uint, error)
+	WriteFeed(userID uint, includeEvents bool, w io.Writer) error
+}
+
+type calendarService struct {
+	feedRepo repository.CalendarFeedRepository
+	todoRepo repository.TodoRepository
+}
+
+func NewCalendarService(feedRepo repository.CalendarFeedRepository, todoRepo repository.TodoRepository) CalendarService {
+	return &calendarService{
+		feedRepo: feedRepo,
+		todoRepo: todoRepo,
+	}
+}
+
+func (s *calendarService) GetFeed(userID uint) (*models.CalendarFeed, error) {
+	feed, err := s.feedRepo.GetByUserID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("calendar feed not found")
+		}
+		return nil, errors.New("database error")
+	}
+	return feed, nil
+}
+
+// RegenerateToken issues a new secret token for the user's feed. Any URL
+// built from a previous token stops working immediately. The token itself is
+// only returned here; just its hash is stored.
+func (s *calendarService) RegenerateToken(userID uint) (string, *models.CalendarFeed, error) {
+	token, err := utils.RandomToken(calendarTokenBytes)
+	if err != nil {
+		return "", nil, errors.New("failed to generate calendar token")
+	}
+
+	if err := s.feedRepo.Upsert(&models.CalendarFeed{UserID: userID, TokenHash: hashCalendarToken(token)}); err != nil {
+		return "", nil, errors.New("failed to save calendar feed")
+	}
+
+	feed, err := s.GetFeed(userID)
+	if err != nil {
+		return "", nil, err
+	}
+	return token, feed, nil
+}
+
+func (s *calendarService) RevokeFeed(userID uint) error {
+	deleted, err := s.feedRepo.DeleteByUserID(userID)
+	if err != nil {
+		return errors.New("failed to revoke calendar feed")
+	}
+	if deleted == 0 {
+		return errors.New("calendar feed not found")
+	}
+	return nil
+}
+
+func (s *calendarService) FeedOwner(token string) (uint, error) {
+	if token == "" {
+		return 0, errors.New("calendar feed not found")
+	}
+
+	feed, err := s.feedRepo.GetByTokenHash(hashCalendarToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, errors.New("calendar feed not found")
+		}
+		return 0, errors.New("database error")
+	}
+	return feed.UserID, nil
+}
+
+// WriteFeed renders every todo of the user as a VTODO. With includeEvents,
+// todos that have a due date are additionally rendered as VEVENTs so they
+// show up in calendar apps that ignore tasks.
+func (s *calendarService) WriteFeed(userID uint, includeEvents bool, w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	ics := &icsWriter{w: bw}
+
+	ics.line("BEGIN:VCALENDAR")
+	ics.line("VERSION:2.0")
+	ics.line("PRODID:-//Task Management//Todos//EN")
+	ics.line("CALSCALE:GREGORIAN")
+	ics.line("METHOD:PUBLISH")
+	ics.line("X-WR-CALNAME:Todos")
+	ics.line("REFRESH-INTERVAL;VALUE=DURATION:PT1H")
+	ics.line("X-PUBLISHED-TTL:PT1H")
+
+	now := time.Now()
+	err := s.todoRepo.FindInBatches(repository.MemberScope(userID), "", "", calendarBatchSize, func(todos []models.Todo) error {
+		for i := range todos {
+			writeVTodo(ics, &todos[i], now)
+			if includeEvents && todos[i].DueDate != nil {
+				writeVEvent(ics, &todos[i], now)
+			}
+		}
+		return ics.err
+	})
+	if err != nil {
+		return err
+	}
+
+	ics.line("END:VCALENDAR")
+	if ics.err != nil {
+		return ics.err
+	}
+	return bw.Flush()
+}
+
+func writeVTodo(ics *icsWriter, todo *models.Todo, now time.Time) {
+	ics.line("BEGIN:VTODO")
+	ics.line(fmt.Sprintf("UID:todo-%d@%s", todo.ID, calendarUIDDomain))
+	ics.line("DTSTAMP:" + formatICSTime(now))
+	ics.line("CREATED:" + formatICSTime(todo.CreatedAt))
+	ics.line("LAST-MODIFIED:" + formatICSTime(todo.UpdatedAt))
+	ics.line("SUMMARY:" + escapeICSText(todo.Title))
+	if todo.Description != "" {
+		ics.line("DESCRIPTION:" + escapeICSText(todo.Description))
+	}
+	if todo.DueDate != nil {
+		ics.line("DUE:" + formatICSTime(*todo.DueDate))
+	}
+	ics.line("STATUS:" + icsTodoStatus(todo.Status))
+	if todo.CompletedAt != nil {
+		ics.line("COMPLETED:" + formatICSTime(*todo.CompletedAt))
+	}
+	ics.line(fmt.Sprintf("PRIORITY:%d", icsPriority(todo.Priority)))
+	if todo.Category != "" {
+		ics.line("CATEGORIES:" + escapeICSText(string(todo.Category)))
+	}
+	ics.line("END:VTODO")
+}
+
+// writeVEvent renders the due date as an event. Due dates at midnight UTC
+// are treated as date-only and become all-day events.
+func writeVEvent(ics *icsWriter, todo *models.Todo, now time.Time) {
+	due := todo.DueDate.UTC()
+
+	ics.line("BEGIN:VEVENT")
+	ics.line(fmt.Sprintf("UID:todo-due-%d@%s", todo.ID, calendarUIDDomain))
+	ics.line("DTSTAMP:" + formatICSTime(now))
+	ics.line("LAST-MODIFIED:" + formatICSTime(todo.UpdatedAt))
+	ics.line("SUMMARY:" + escapeICSText(todo.Title))
+	if todo.Description != "" {
+		ics.line("DESCRIPTION:" + escapeICSText(todo.Description))
+	}
+	if due.Hour() == 0 && due.Minute() == 0 && due.Second() == 0 {
+		ics.line("DTSTART;VALUE=DATE:" + due.Format(icsDateLayout))
+		ics.line("DTEND;VALUE=DATE:" + due.AddDate(0, 0, 1).Format(icsDateLayout))
+	} else {
+		ics.line("DTSTART:" + formatICSTime(due))
+		ics.line("DTEND:" + formatICSTime(due))
+	}
+	if todo.Status == models.StatusDone {
+		ics.line("STATUS:CANCELLED")
+	} else {
+		ics.line("STATUS:CONFIRMED")
+	}
+	ics.line("TRANSP:TRANSPARENT")
+	ics.line("END:VEVENT")
+}
+
+func icsTodoStatus(status models.Status) string {
+	switch status {
+	case models.StatusInProgress:
+		return "IN-PROCESS"
+	case models.StatusDone:
+		return "COMPLETED"
+	}
+	return "NEEDS-ACTION"
+}
+
+// icsPriority maps onto the RFC 5545 scale where 1 is the highest priority.
+func icsPriority(priority models.Priority) int {
+	switch priority {
+	case models.PriorityHigh:
+		return 1
+	case models.PriorityLow:
+		return 9
+	}
+	return 5
+}
+
+func formatICSTime(t time.Time) string {
+	return t.UTC().Format(icsTimeLayout)
+}
+
+var icsTextEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+func escapeICSText(value string) string {
+	return icsTextEscaper.Replace(value)
+}
+
+func hashCalendarToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// icsWriter writes content lines terminated by CRLF, folding them at 75
+// octets without splitting UTF-8 sequences. The first error is kept and
+// later writes are skipped.
+type icsWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (iw *icsWriter) line(content string) {
+	if iw.err != nil {
+		return
+	}
+
+	var b strings.Builder
+	limit := icsLineLimit
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		b.WriteString(content[:cut])
+		b.WriteString("\r\n ")
+		content = content[cut:]
+		// Continuation lines start with a space, which counts toward the limit.
+		limit = icsLineLimit - 1
+	}
+	b.WriteString(content)
+	b.WriteString("\r\n")
+
+	_, iw.err = io.WriteString(iw.w, b.String())
+}
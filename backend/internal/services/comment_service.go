@@ -0,0 +1,238 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"task-management/internal/models"
+	"task-management/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+const commentMaxLength = 10000
+
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([A-Za-z0-9_.\-]+)`)
+
+type CommentService interface {
+	GetComments(todoID, userID uint) ([]models.Comment, error)
+	CreateComment(todoID, userID uint, body string) (*models.Comment, error)
+	UpdateComment(todoID, commentID, userID uint, body string) (*models.Comment, error)
+	DeleteComment(todoID, commentID, userID uint) error
+	GetCommentHistory(todoID, commentID, userID uint) ([]models.CommentRevision, error)
+}
+
+type commentService struct {
+	commentRepo         repository.CommentRepository
+	userRepo            repository.UserRepository
+	workspaceRepo       repository.WorkspaceRepository
+	todoService         TodoService
+	notificationService NotificationService
+}
+
+func NewCommentService(commentRepo repository.CommentRepository, userRepo repository.UserRepository, workspaceRepo repository.WorkspaceRepository, todoService TodoService, notificationService NotificationService) CommentService {
+	return &commentService{
+		commentRepo:         commentRepo,
+		userRepo:            userRepo,
+		workspaceRepo:       workspaceRepo,
+		todoService:         todoService,
+		notificationService: notificationService,
+	}
+}
+
+func (s *commentService) GetComments(todoID, userID uint) ([]models.Comment, error) {
+	if _, err := s.todoService.GetTodoByID(todoID, userID); err != nil {
+		return nil, err
+	}
+	return s.commentRepo.GetByTodoID(todoID)
+}
+
+func (s *commentService) CreateComment(todoID, userID uint, body string) (*models.Comment, error) {
+	todo, err := s.todoService.GetTodoByID(todoID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err = validateCommentBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	mentioned := s.resolveMentions(body, userID, todo.WorkspaceID)
+	comment := &models.Comment{
+		TodoID:   todoID,
+		UserID:   userID,
+		Body:     body,
+		Mentions: mentionUsernames(mentioned),
+	}
+
+	if err := s.commentRepo.Create(comment); err != nil {
+		return nil, errors.New("failed to create comment")
+	}
+
+	s.notifyMentions(comment, mentioned, nil)
+	return comment, nil
+}
+
+func (s *commentService) UpdateComment(todoID, commentID, userID uint, body string) (*models.Comment, error) {
+	todo, comment, err := s.getOwnComment(todoID, commentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err = validateCommentBody(body)
+	if err != nil {
+		return nil, err
+	}
+	if body == comment.Body {
+		return comment, nil
+	}
+
+	previousBody := comment.Body
+	previousMentions := comment.Mentions
+	mentioned := s.resolveMentions(body, userID, todo.WorkspaceID)
+
+	now := time.Now()
+	comment.Body = body
+	comment.Mentions = mentionUsernames(mentioned)
+	comment.EditedAt = &now
+
+	if err := s.commentRepo.Update(comment, previousBody); err != nil {
+		return nil, errors.New("failed to update comment")
+	}
+
+	s.notifyMentions(comment, mentioned, previousMentions)
+	return comment, nil
+}
+
+func (s *commentService) DeleteComment(todoID, commentID, userID uint) error {
+	if _, _, err := s.getOwnComment(todoID, commentID, userID); err != nil {
+		return err
+	}
+
+	if err := s.commentRepo.Delete(commentID); err != nil {
+		return errors.New("failed to delete comment")
+	}
+	return nil
+}
+
+func (s *commentService) GetCommentHistory(todoID, commentID, userID uint) ([]models.CommentRevision, error) {
+	if _, err := s.todoService.GetTodoByID(todoID, userID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getComment(todoID, commentID); err != nil {
+		return nil, err
+	}
+
+	return s.commentRepo.GetRevisions(commentID)
+}
+
+// getOwnComment loads a comment the user is allowed to change, together
+// with its todo: only the author may edit or delete it.
+func (s *commentService) getOwnComment(todoID, commentID, userID uint) (*models.Todo, *models.Comment, error) {
+	todo, err := s.todoService.GetTodoByID(todoID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	comment, err := s.getComment(todoID, commentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if comment.UserID != userID {
+		return nil, nil, errors.New("only the author can modify this comment")
+	}
+	return todo, comment, nil
+}
+
+func (s *commentService) getComment(todoID, commentID uint) (*models.Comment, error) {
+	comment, err := s.commentRepo.GetByID(commentID, todoID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("comment not found")
+		}
+		return nil, errors.New("database error")
+	}
+	return comment, nil
+}
+
+// resolveMentions returns the active users referenced as @username in the
+// body, excluding the author. Unknown usernames and users who are not
+// members of the todo's workspace, and so cannot see it, are ignored.
+func (s *commentService) resolveMentions(body string, authorID, workspaceID uint) []*models.User {
+	var users []*models.User
+	seen := make(map[string]bool)
+
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		username := strings.TrimRight(match[1], ".-")
+		if username == "" || seen[strings.ToLower(username)] {
+			continue
+		}
+		seen[strings.ToLower(username)] = true
+
+		user, err := s.userRepo.GetByUsername(username)
+		if err != nil || user.ID == authorID {
+			continue
+		}
+		if _, err := s.workspaceRepo.GetMember(workspaceID, user.ID); err != nil {
+			continue
+		}
+		users = append(users, user)
+	}
+
+	return users
+}
+
+// notifyMentions notifies every mentioned user that was not already
+// mentioned in a previous version of the comment.
+func (s *commentService) notifyMentions(comment *models.Comment, mentioned []*models.User, alreadyNotified []string) {
+	skip := make(map[string]bool, len(alreadyNotified))
+	for _, username := range alreadyNotified {
+		skip[username] = true
+	}
+
+	for _, user := range mentioned {
+		if skip[user.Username] {
+			continue
+		}
+
+		todoID := comment.TodoID
+		commentID := comment.ID
+		err := s.notificationService.Notify(&models.Notification{
+			UserID:    user.ID,
+			ActorID:   comment.UserID,
+			Type:      models.NotificationMention,
+			TodoID:    &todoID,
+			CommentID: &commentID,
+			Message:   fmt.Sprintf("You were mentioned in a comment on todo #%d", todoID),
+		})
+		if err != nil {
+			log.Printf("comment: failed to notify user %d: %v", user.ID, err)
+		}
+	}
+}
+
+func mentionUsernames(users []*models.User) []string {
+	usernames := make([]string, 0, len(users))
+	for _, user := range users {
+		usernames = append(usernames, user.Username)
+	}
+	return usernames
+}
+
+func validateCommentBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", errors.New("comment body is required")
+	}
+	if len(body) > commentMaxLength {
+		return "", fmt.Errorf("comment body must be at most %d characters", commentMaxLength)
+	}
+	return body, nil
+}
@@ -0,0 +1,26 @@
+package services
+
+import (
+	"task-management/internal/models"
+)
+
+// EventPublisher is notified whenever a todo or subtask is mutated. The
+// event concerns every member of workspaceID; userID made the change.
+// Implementations must not block the caller.
+type EventPublisher interface {
+	Publish(workspaceID, userID uint, event models.EventType, data interface{})
+}
+
+type multiPublisher []EventPublisher
+
+// MultiPublisher returns a publisher that forwards every event to all of the
+// given publishers in order.
+func MultiPublisher(publishers ...EventPublisher) EventPublisher {
+	return multiPublisher(publishers)
+}
+
+func (m multiPublisher) Publish(workspaceID, userID uint, event models.EventType, data interface{}) {
+	for _, p := range m {
+		p.Publish(workspaceID, userID, event, data)
+	}
+}
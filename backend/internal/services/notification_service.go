@@ -0,0 +1,53 @@
+package services
+
+import (
+	"errors"
+
+	"task-management/internal/models"
+	"task-management/internal/repository"
+)
+
+const notificationListLimit = 100
+
+type NotificationService interface {
+	Notify(notification *models.Notification) error
+	GetNotifications(userID uint, unreadOnly bool) ([]models.Notification, error)
+	MarkRead(id, userID uint) error
+	MarkAllRead(userID uint) error
+}
+
+type notificationService struct {
+	notificationRepo repository.NotificationRepository
+}
+
+func NewNotificationService(notificationRepo repository.NotificationRepository) NotificationService {
+	return &notificationService{
+		notificationRepo: notificationRepo,
+	}
+}
+
+func (s *notificationService) Notify(notification *models.Notification) error {
+	if err := s.notificationRepo.Create(notification); err != nil {
+		return errors.New("failed to create notification")
+	}
+	return nil
+}
+
+func (s *notificationService) GetNotifications(userID uint, unreadOnly bool) ([]models.Notification, error) {
+	return s.notificationRepo.GetByUserID(userID, unreadOnly, notificationListLimit)
+}
+
+func (s *notificationService) MarkRead(id, userID uint) error {
+	affected, err := s.notificationRepo.MarkRead(id, userID)
+	if err != nil {
+		return errors.New("database error")
+	}
+	if affected == 0 {
+		return errors.New("notification not found")
+	}
+	return nil
+}
+
+func (s *notificationService) MarkAllRead(userID uint) error {
+	return s.notificationRepo.MarkAllRead(userID)
+}
@@ -0,0 +1,586 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"task-management/internal/models"
+)
+
+// QuickAddToken is one recognised part of a quick-add line. Ignored tokens
+// were overridden by a later token for the same field.
+type QuickAddToken struct {
+	Text    string `json:"text"`
+	Field   string `json:"field"`
+	Value   string `json:"value"`
+	Ignored bool   `json:"ignored,omitempty"`
+}
+
+// QuickAddParse is the breakdown of a quick-add line. Words that were not
+// recognised make up the title. When a field is given twice the later token
+// wins and the earlier one is marked ignored.
+type QuickAddParse struct {
+	Input    string          `json:"input"`
+	Title    string          `json:"title"`
+	DueDate  *time.Time      `json:"due_date"`
+	Priority models.Priority `json:"priority"`
+	Category models.Category `json:"category"`
+	Timezone string          `json:"timezone"`
+	Tokens   []QuickAddToken `json:"tokens"`
+}
+
+var quickAddPriorities = map[string]models.Priority{
+	"high": models.PriorityHigh, "tinggi": models.PriorityHigh, "1": models.PriorityHigh,
+	"medium": models.PriorityMedium, "sedang": models.PriorityMedium, "2": models.PriorityMedium,
+	"low": models.PriorityLow, "rendah": models.PriorityLow, "3": models.PriorityLow,
+}
+
+var quickAddCategories = map[string]models.Category{
+	"work": models.CategoryWork, "kerja": models.CategoryWork, "kantor": models.CategoryWork,
+	"personal": models.CategoryPersonal, "pribadi": models.CategoryPersonal,
+	"shopping": models.CategoryShopping, "belanja": models.CategoryShopping,
+	"health": models.CategoryHealth, "kesehatan": models.CategoryHealth,
+	"other": models.CategoryOther, "lainnya": models.CategoryOther, "lain": models.CategoryOther,
+}
+
+var quickAddWeekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "minggu": time.Sunday, "ahad": time.Sunday,
+	"monday": time.Monday, "senin": time.Monday,
+	"tuesday": time.Tuesday, "selasa": time.Tuesday,
+	"wednesday": time.Wednesday, "rabu": time.Wednesday,
+	"thursday": time.Thursday, "kamis": time.Thursday,
+	"friday": time.Friday, "jumat": time.Friday, "jum'at": time.Friday,
+	"saturday": time.Saturday, "sabtu": time.Saturday,
+}
+
+// quickAddWeekdayAbbreviations are also ordinary words ("sun cream", "wed
+// deploy"), so they only count as dates when qualified; see matchWeekday.
+var quickAddWeekdayAbbreviations = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "tues": time.Tuesday,
+	"wed": time.Wednesday, "thu": time.Thursday, "thurs": time.Thursday,
+	"fri": time.Friday, "sat": time.Saturday,
+}
+
+var quickAddMonths = map[string]time.Month{
+	"january": time.January, "jan": time.January, "januari": time.January,
+	"february": time.February, "feb": time.February, "februari": time.February,
+	"march": time.March, "mar": time.March, "maret": time.March,
+	"april": time.April, "apr": time.April,
+	"may": time.May, "mei": time.May,
+	"june": time.June, "jun": time.June, "juni": time.June,
+	"july": time.July, "jul": time.July, "juli": time.July,
+	"august": time.August, "aug": time.August, "agustus": time.August, "agu": time.August, "agt": time.August,
+	"september": time.September, "sep": time.September, "sept": time.September,
+	"october": time.October, "oct": time.October, "oktober": time.October, "okt": time.October,
+	"november": time.November, "nov": time.November, "nopember": time.November,
+	"december": time.December, "dec": time.December, "desember": time.December, "des": time.December,
+}
+
+// quickAddDays maps fixed day words to an offset from today. The bool marks
+// words that also imply an evening time.
+var quickAddDays = map[string]struct {
+	offset  int
+	evening bool
+}{
+	"today":              {0, false},
+	"tonight":            {0, true},
+	"tomorrow":           {1, false},
+	"tmr":                {1, false},
+	"tmrw":               {1, false},
+	"day after tomorrow": {2, false},
+	"hari ini":           {0, false},
+	"malam ini":          {0, true},
+	"nanti malam":        {0, true},
+	"besok":              {1, false},
+	"besok malam":        {1, true},
+	"lusa":               {2, false},
+}
+
+// quickAddUnits are the units of relative dates such as "in 3 days" or
+// "2 minggu lagi".
+var quickAddUnits = map[string]string{
+	"minute": "minute", "minutes": "minute", "min": "minute", "mins": "minute", "menit": "minute",
+	"hour": "hour", "hours": "hour", "hr": "hour", "hrs": "hour", "jam": "hour",
+	"day": "day", "days": "day", "hari": "day",
+	"week": "week", "weeks": "week", "minggu": "week", "pekan": "week",
+	"month": "month", "months": "month", "bulan": "month",
+}
+
+// quickAddConnectors are dropped from the title when they directly precede
+// a date or time, as in "pay rent by friday" or "rapat pada jam 9".
+var quickAddConnectors = map[string]bool{
+	"on": true, "at": true, "by": true, "due": true, "before": true,
+	"pada": true, "tanggal": true, "tgl": true, "sebelum": true,
+}
+
+// quickAddPeriods shift an hour into the part of the day named after it,
+// e.g. "jam 7 malam" is 19:00.
+var quickAddPeriods = map[string]string{
+	"am": "am", "pm": "pm", "pagi": "am", "siang": "noon", "sore": "pm", "malam": "night",
+}
+
+var (
+	quickAddClockPattern = regexp.MustCompile(`^(\d{1,2})(?:[:.](\d{2}))?(am|pm)?$`)
+	quickAddISODate      = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	quickAddDayOfMonth   = regexp.MustCompile(`^\d{1,2}$`)
+	quickAddYear         = regexp.MustCompile(`^\d{4}$`)
+)
+
+const (
+	quickAddTrailing    = ",;!?"
+	quickAddEveningHour = 20
+)
+
+type quickAddClock struct {
+	hour, minute int
+}
+
+// quickAddEndOfDay is the time of a due date given without one.
+var quickAddEndOfDay = quickAddClock{hour: 23, minute: 59}
+
+type quickAddParser struct {
+	words []string
+	lower []string
+	used  []bool
+	now   time.Time
+
+	date   *time.Time
+	clock  *quickAddClock
+	exact  *time.Time
+	result QuickAddParse
+}
+
+// parseQuickAdd parses a single line such as "Pay rent tomorrow 9am !high
+// #personal" in English or Indonesian. Relative dates are resolved against
+// now, whose location is the user's time zone.
+func parseQuickAdd(input string, now time.Time) QuickAddParse {
+	words := strings.Fields(input)
+	p := &quickAddParser{
+		words:  words,
+		lower:  make([]string, len(words)),
+		used:   make([]bool, len(words)),
+		now:    now,
+		result: QuickAddParse{Input: input, Timezone: now.Location().String(), Tokens: []QuickAddToken{}},
+	}
+	for i, word := range words {
+		p.lower[i] = strings.TrimRight(strings.ToLower(word), quickAddTrailing)
+	}
+
+	for i := 0; i < len(words); i++ {
+		if p.used[i] {
+			continue
+		}
+		if n := p.parseAt(i); n > 0 {
+			i += n - 1
+		}
+	}
+
+	p.result.DueDate = p.dueDate()
+
+	var title []string
+	for i, word := range words {
+		if !p.used[i] {
+			title = append(title, word)
+		}
+	}
+	p.result.Title = strings.Join(title, " ")
+	return p.result
+}
+
+// parseAt tries every matcher at word i and returns how many words the
+// match consumed.
+func (p *quickAddParser) parseAt(i int) int {
+	word := p.lower[i]
+
+	if strings.HasPrefix(word, "!") {
+		if priority, ok := quickAddPriorities[word[1:]]; ok {
+			p.result.Priority = priority
+			p.override("priority")
+			p.consume(i, 1, "priority", string(priority), false)
+			return 1
+		}
+	}
+	if strings.HasPrefix(word, "#") || strings.HasPrefix(word, "@") {
+		if category, ok := quickAddCategories[word[1:]]; ok {
+			p.result.Category = category
+			p.override("category")
+			p.consume(i, 1, "category", string(category), false)
+			return 1
+		}
+	}
+
+	for _, match := range []func(int) int{p.matchRelative, p.matchDayWord, p.matchWeekday, p.matchDate, p.matchClock} {
+		if n := match(i); n > 0 {
+			return n
+		}
+	}
+	return 0
+}
+
+// consume marks words[i:i+n] as recognised. Date and time tokens also take
+// a connector word right before them.
+func (p *quickAddParser) consume(i, n int, field, value string, connector bool) {
+	if connector && i > 0 && !p.used[i-1] && quickAddConnectors[p.lower[i-1]] {
+		i--
+		n++
+	}
+	for j := i; j < i+n; j++ {
+		p.used[j] = true
+	}
+	p.result.Tokens = append(p.result.Tokens, QuickAddToken{
+		Text:  strings.Join(p.words[i:i+n], " "),
+		Field: field,
+		Value: value,
+	})
+}
+
+// override marks the tokens recognised so far for the given fields as
+// ignored, as a later token replaces their value.
+func (p *quickAddParser) override(fields ...string) {
+	for i := range p.result.Tokens {
+		for _, field := range fields {
+			if p.result.Tokens[i].Field == field {
+				p.result.Tokens[i].Ignored = true
+			}
+		}
+	}
+}
+
+// phrase returns the n lower-cased words starting at i, or "" if some of
+// them are missing or already recognised.
+func (p *quickAddParser) phrase(i, n int) string {
+	if i+n > len(p.words) {
+		return ""
+	}
+	for j := i; j < i+n; j++ {
+		if p.used[j] {
+			return ""
+		}
+	}
+	return strings.Join(p.lower[i:i+n], " ")
+}
+
+func (p *quickAddParser) today() time.Time {
+	return time.Date(p.now.Year(), p.now.Month(), p.now.Day(), 0, 0, 0, 0, p.now.Location())
+}
+
+func (p *quickAddParser) setDate(date time.Time, i, n int) {
+	p.date = &date
+	p.exact = nil
+	p.override("due_date")
+	p.consume(i, n, "due_date", date.Format(statsDateLayout), true)
+}
+
+// matchRelative matches "in 3 days", "dalam 2 jam" and "3 hari lagi".
+func (p *quickAddParser) matchRelative(i int) int {
+	var amount, unit string
+	n := 0
+	switch p.phrase(i, 1) {
+	case "in", "dalam":
+		if p.phrase(i, 3) == "" {
+			return 0
+		}
+		amount, unit, n = p.lower[i+1], p.lower[i+2], 3
+	default:
+		if p.phrase(i, 3) == "" || p.lower[i+2] != "lagi" {
+			return 0
+		}
+		amount, unit, n = p.lower[i], p.lower[i+1], 3
+	}
+
+	count, err := strconv.Atoi(amount)
+	if amount == "a" || amount == "an" || amount == "se" {
+		count, err = 1, nil
+	}
+	if err != nil || count < 0 || count > 1000 {
+		return 0
+	}
+
+	switch quickAddUnits[unit] {
+	case "minute", "hour":
+		d := time.Duration(count) * time.Minute
+		if quickAddUnits[unit] == "hour" {
+			d = time.Duration(count) * time.Hour
+		}
+		exact := p.now.Add(d).Truncate(time.Minute)
+		p.exact, p.date, p.clock = &exact, nil, nil
+		p.override("due_date", "due_time")
+		p.consume(i, n, "due_date", exact.Format(time.RFC3339), true)
+	case "day":
+		p.setDate(p.today().AddDate(0, 0, count), i, n)
+	case "week":
+		p.setDate(p.today().AddDate(0, 0, 7*count), i, n)
+	case "month":
+		p.setDate(p.today().AddDate(0, count, 0), i, n)
+	default:
+		return 0
+	}
+	return n
+}
+
+// matchDayWord matches today, tomorrow, besok, lusa, next week, bulan depan
+// and similar fixed words.
+func (p *quickAddParser) matchDayWord(i int) int {
+	for n := 3; n >= 1; n-- {
+		phrase := p.phrase(i, n)
+		if phrase == "" {
+			continue
+		}
+
+		if day, ok := quickAddDays[phrase]; ok {
+			p.setDate(p.today().AddDate(0, 0, day.offset), i, n)
+			if day.evening && p.clock == nil {
+				p.clock = &quickAddClock{hour: quickAddEveningHour}
+			}
+			return n
+		}
+
+		switch phrase {
+		case "next week", "minggu depan", "pekan depan":
+			// Monday of next week.
+			today := p.today()
+			offset := (8 - int(today.Weekday())) % 7
+			if offset == 0 {
+				offset = 7
+			}
+			p.setDate(today.AddDate(0, 0, offset), i, n)
+			return n
+		case "next month", "bulan depan":
+			today := p.today()
+			p.setDate(time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, today.Location()), i, n)
+			return n
+		case "weekend", "this weekend", "akhir pekan", "akhir minggu":
+			p.setDate(p.nextWeekday(time.Saturday, true), i, n)
+			return n
+		}
+	}
+	return 0
+}
+
+// matchWeekday matches "friday", "next friday", "on fri", "jumat",
+// "hari jumat" and "jumat depan". Abbreviations such as "fri" need "next",
+// "this" or a connector before them or "depan"/"ini" after them, or must end
+// the line apart from priority and category tags.
+func (p *quickAddParser) matchWeekday(i int) int {
+	start, n := i, 0
+	prefix := p.phrase(i, 1)
+	switch prefix {
+	case "next", "this", "hari":
+		n = 1
+	}
+
+	name := p.phrase(i+n, 1)
+	weekday, ok := quickAddWeekdays[name]
+	if !ok {
+		weekday, ok = quickAddWeekdayAbbreviations[name]
+		if !ok || !p.qualifiesAbbreviation(i, n, prefix) {
+			return 0
+		}
+	}
+	n++
+
+	// "minggu depan" is next week, not next Sunday.
+	if name == "minggu" && n == 1 && (p.phrase(i+n, 1) == "depan" || p.phrase(i+n, 1) == "lalu") {
+		return 0
+	}
+
+	thisWeek := p.lower[start] == "this"
+	if suffix := p.phrase(i+n, 1); suffix == "depan" || suffix == "ini" {
+		thisWeek = suffix == "ini"
+		n++
+	}
+
+	p.setDate(p.nextWeekday(weekday, thisWeek), start, n)
+	return n
+}
+
+// qualifiesAbbreviation reports whether the weekday abbreviation at i+n is
+// meant as a date rather than as a word of the title.
+func (p *quickAddParser) qualifiesAbbreviation(i, n int, prefix string) bool {
+	if n == 1 {
+		return prefix == "next" || prefix == "this"
+	}
+	if i > 0 && !p.used[i-1] && quickAddConnectors[p.lower[i-1]] {
+		return true
+	}
+	if suffix := p.phrase(i+1, 1); suffix == "depan" || suffix == "ini" {
+		return true
+	}
+	for _, word := range p.lower[i+1:] {
+		if !strings.HasPrefix(word, "!") && !strings.HasPrefix(word, "#") && !strings.HasPrefix(word, "@") {
+			return false
+		}
+	}
+	return true
+}
+
+// nextWeekday returns the next date falling on weekday, never today. With
+// orToday, today itself qualifies.
+func (p *quickAddParser) nextWeekday(weekday time.Weekday, orToday bool) time.Time {
+	today := p.today()
+	offset := (int(weekday) - int(today.Weekday()) + 7) % 7
+	if offset == 0 && !orToday {
+		offset = 7
+	}
+	return today.AddDate(0, 0, offset)
+}
+
+// matchDate matches 2024-12-31, "31 dec", "dec 31" and "31 desember 2024".
+// Dates without a year that already passed this year fall in the next one.
+func (p *quickAddParser) matchDate(i int) int {
+	loc := p.now.Location()
+	if word := p.phrase(i, 1); quickAddISODate.MatchString(word) {
+		date, err := time.ParseInLocation(statsDateLayout, word, loc)
+		if err != nil {
+			return 0
+		}
+		p.setDate(date, i, 1)
+		return 1
+	}
+
+	var day string
+	var month time.Month
+	if first, second := p.phrase(i, 1), p.phrase(i+1, 1); quickAddDayOfMonth.MatchString(first) && quickAddMonths[second] != 0 {
+		day, month = first, quickAddMonths[second]
+	} else if quickAddMonths[first] != 0 && quickAddDayOfMonth.MatchString(second) {
+		day, month = second, quickAddMonths[first]
+	} else {
+		return 0
+	}
+	n := 2
+
+	dayNumber, _ := strconv.Atoi(day)
+	year := p.now.Year()
+	explicitYear := false
+	if word := p.phrase(i+2, 1); quickAddYear.MatchString(word) {
+		year, _ = strconv.Atoi(word)
+		explicitYear = true
+		n++
+	}
+
+	date := time.Date(year, month, dayNumber, 0, 0, 0, 0, loc)
+	if date.Day() != dayNumber {
+		return 0
+	}
+	if !explicitYear && date.Before(p.today()) {
+		date = date.AddDate(1, 0, 0)
+	}
+	p.setDate(date, i, n)
+	return n
+}
+
+// matchClock matches 9am, "9:30 pm", 21:00, 21.00, noon, "at 9",
+// "jam 9 pagi" and "pukul 19.30".
+func (p *quickAddParser) matchClock(i int) int {
+	word := p.phrase(i, 1)
+	switch word {
+	case "noon", "midday":
+		p.setClock(quickAddClock{hour: 12}, i, 1)
+		return 1
+	}
+
+	start, n := i, 0
+	prefixed := false
+	if word == "at" || word == "jam" || word == "pukul" {
+		prefixed = true
+		n = 1
+	}
+
+	clock := p.phrase(i+n, 1)
+	match := quickAddClockPattern.FindStringSubmatch(clock)
+	if match == nil {
+		return 0
+	}
+	n++
+
+	hour, _ := strconv.Atoi(match[1])
+	minute := 0
+	if match[2] != "" {
+		minute, _ = strconv.Atoi(match[2])
+	}
+	period := match[3]
+	if period == "" && hour >= 1 && hour <= 12 {
+		if next, ok := quickAddPeriods[p.phrase(i+n, 1)]; ok {
+			period = next
+			n++
+		}
+	}
+
+	// Bare numbers and 9.30 are only times when introduced by at, jam or
+	// pukul or followed by a part of the day, so "buy 2 apples" and "1.50"
+	// stay in the title. 21:00 is always a time.
+	if !prefixed && period == "" && !strings.Contains(clock, ":") {
+		return 0
+	}
+	if hour > 23 || minute > 59 || (period != "" && (hour == 0 || hour > 12)) {
+		return 0
+	}
+
+	switch period {
+	case "am":
+		if hour == 12 {
+			hour = 0
+		}
+	case "pm":
+		if hour < 12 {
+			hour += 12
+		}
+	case "noon":
+		// siang covers late morning to mid afternoon: 11 siang, 2 siang.
+		if hour < 11 {
+			hour += 12
+		}
+	case "night":
+		// malam: 7 malam is 19:00, 12 malam is midnight.
+		if hour == 12 {
+			hour = 0
+		} else if hour >= 6 {
+			hour += 12
+		}
+	}
+
+	p.setClock(quickAddClock{hour: hour, minute: minute}, start, n)
+	return n
+}
+
+func (p *quickAddParser) setClock(clock quickAddClock, i, n int) {
+	if p.exact != nil {
+		// A time replaces the whole of an "in 2 hours" due date.
+		p.override("due_date")
+	}
+	p.clock = &clock
+	p.exact = nil
+	p.override("due_time")
+	p.consume(i, n, "due_time", fmt.Sprintf("%02d:%02d", clock.hour, clock.minute), true)
+}
+
+// dueDate combines the recognised date and time. A date without a time is
+// due at the end of that day; a time without a date is the next time that
+// clock time comes round.
+func (p *quickAddParser) dueDate() *time.Time {
+	if p.exact != nil {
+		return p.exact
+	}
+	if p.date == nil && p.clock == nil {
+		return nil
+	}
+
+	clock := quickAddEndOfDay
+	if p.clock != nil {
+		clock = *p.clock
+	}
+	date := p.today()
+	if p.date != nil {
+		date = *p.date
+	}
+	// Built from the wall clock rather than added to midnight, which is off
+	// by an hour on days with a DST change.
+	due := time.Date(date.Year(), date.Month(), date.Day(), clock.hour, clock.minute, 0, 0, date.Location())
+	if p.date == nil && !due.After(p.now) {
+		due = due.AddDate(0, 0, 1)
+	}
+	return &due
+}
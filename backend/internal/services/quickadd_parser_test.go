@@ -0,0 +1,132 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"task-management/internal/models"
+)
+
+func TestParseQuickAdd(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A Wednesday morning.
+	now := time.Date(2024, 5, 15, 10, 0, 0, 0, jakarta)
+
+	tests := []struct {
+		input    string
+		title    string
+		due      string
+		priority models.Priority
+		category models.Category
+		// ignored lists the text of tokens overridden by a later one.
+		ignored []string
+	}{
+		// English dates and times.
+		{input: "Pay rent tomorrow 9am !high #personal", title: "Pay rent", due: "2024-05-16 09:00", priority: models.PriorityHigh, category: models.CategoryPersonal},
+		{input: "Submit report by friday", title: "Submit report", due: "2024-05-17 23:59"},
+		{input: "Call mom next fri", title: "Call mom", due: "2024-05-17 23:59"},
+		{input: "Dentist on dec 31 at 14:30", title: "Dentist", due: "2024-12-31 14:30"},
+		{input: "Review PR in 2 hours", title: "Review PR", due: "2024-05-15 12:00"},
+		{input: "Water plants in 3 days", title: "Water plants", due: "2024-05-18 23:59"},
+		{input: "Standup at noon", title: "Standup", due: "2024-05-15 12:00"},
+		{input: "Gym 7am", title: "Gym", due: "2024-05-16 07:00"},
+		{input: "Plan trip next week", title: "Plan trip", due: "2024-05-20 23:59"},
+		{input: "Renew passport 2024-06-01", title: "Renew passport", due: "2024-06-01 23:59"},
+		{input: "Party tonight", title: "Party", due: "2024-05-15 20:00"},
+		{input: "Pay taxes jan 15", title: "Pay taxes", due: "2025-01-15 23:59"},
+
+		// Indonesian dates and times.
+		{input: "Rapat besok jam 9 pagi #kerja", title: "Rapat", due: "2024-05-16 09:00", category: models.CategoryWork},
+		{input: "Belanja lusa #belanja", title: "Belanja", due: "2024-05-17 23:59", category: models.CategoryShopping},
+		{input: "Kumpul tugas jumat depan", title: "Kumpul tugas", due: "2024-05-17 23:59"},
+		{input: "Servis motor 3 hari lagi", title: "Servis motor", due: "2024-05-18 23:59"},
+		{input: "Makan malam pukul 19.30", title: "Makan malam", due: "2024-05-15 19:30"},
+		{input: "Arisan minggu depan", title: "Arisan", due: "2024-05-20 23:59"},
+		{input: "Bayar pajak pada 17 agustus", title: "Bayar pajak", due: "2024-08-17 23:59"},
+		{input: "Rapat jam 7 malam !sedang", title: "Rapat", due: "2024-05-15 19:00", priority: models.PriorityMedium},
+		{input: "Cek darah hari sabtu !1 @kesehatan", title: "Cek darah", due: "2024-05-18 23:59", priority: models.PriorityHigh, category: models.CategoryHealth},
+
+		// A later token overrides an earlier one for the same field.
+		{input: "Task !high !low", title: "Task", priority: models.PriorityLow, ignored: []string{"!high"}},
+		{input: "Task @work #health", title: "Task", category: models.CategoryHealth, ignored: []string{"@work"}},
+		{input: "Task tomorrow friday", title: "Task", due: "2024-05-17 23:59", ignored: []string{"tomorrow"}},
+		{input: "Task in 2 hours 9pm", title: "Task", due: "2024-05-15 21:00", ignored: []string{"in 2 hours"}},
+		{input: "Task at 9am at 3pm", title: "Task", due: "2024-05-15 15:00", ignored: []string{"at 9am"}},
+
+		// Weekday abbreviations only count when qualified.
+		{input: "Call mom fri", title: "Call mom", due: "2024-05-17 23:59"},
+		{input: "Call mom fri !high", title: "Call mom", due: "2024-05-17 23:59", priority: models.PriorityHigh},
+		{input: "Read on sat", title: "Read", due: "2024-05-18 23:59"},
+		{input: "Ship this thu", title: "Ship", due: "2024-05-16 23:59"},
+
+		// Titles that merely look like dates.
+		{input: "Buy sun cream", title: "Buy sun cream"},
+		{input: "Fix wed deploy script", title: "Fix wed deploy script"},
+		{input: "Sat nav repair", title: "Sat nav repair"},
+		{input: "Buy 2 apples", title: "Buy 2 apples"},
+		{input: "Price is 1.50", title: "Price is 1.50"},
+		{input: "Fix #123 and !important", title: "Fix #123 and !important"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := parseQuickAdd(tt.input, now)
+
+			if got.Title != tt.title {
+				t.Errorf("title = %q, want %q", got.Title, tt.title)
+			}
+			due := ""
+			if got.DueDate != nil {
+				due = got.DueDate.In(jakarta).Format("2006-01-02 15:04")
+			}
+			if due != tt.due {
+				t.Errorf("due date = %q, want %q", due, tt.due)
+			}
+			if got.Priority != tt.priority {
+				t.Errorf("priority = %q, want %q", got.Priority, tt.priority)
+			}
+			if got.Category != tt.category {
+				t.Errorf("category = %q, want %q", got.Category, tt.category)
+			}
+
+			ignored := map[string]bool{}
+			for _, text := range tt.ignored {
+				ignored[text] = true
+			}
+			for _, token := range got.Tokens {
+				if token.Ignored != ignored[token.Text] {
+					t.Errorf("token %q ignored = %v, want %v", token.Text, token.Ignored, ignored[token.Text])
+				}
+				delete(ignored, token.Text)
+			}
+			for text := range ignored {
+				t.Errorf("no token %q in %+v", text, got.Tokens)
+			}
+		})
+	}
+}
+
+func TestParseQuickAddEndOfDayAcrossDST(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		now  time.Time
+		want time.Time
+	}{
+		// Clocks go forward on 10 March and back on 3 November 2024.
+		{time.Date(2024, 3, 9, 12, 0, 0, 0, newYork), time.Date(2024, 3, 10, 23, 59, 0, 0, newYork)},
+		{time.Date(2024, 11, 2, 12, 0, 0, 0, newYork), time.Date(2024, 11, 3, 23, 59, 0, 0, newYork)},
+	}
+	for _, tt := range tests {
+		got := parseQuickAdd("File taxes tomorrow", tt.now)
+		if got.DueDate == nil || !got.DueDate.Equal(tt.want) {
+			t.Errorf("parseQuickAdd on %s: due date = %v, want %v", tt.now.Format("2006-01-02"), got.DueDate, tt.want)
+		}
+	}
+}
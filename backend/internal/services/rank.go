@@ -0,0 +1,95 @@
+package services
+
+import (
+	"strings"
+)
+
+// Ranks are base-36 strings compared byte by byte, so a todo can be moved
+// between two others by giving it a rank that sorts between theirs without
+// touching any other row. Only digits and lower case letters are used so
+// the order is the same under any database collation. A valid rank never
+// ends in '0', which guarantees there is always room between two ranks.
+const rankDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// rankMaxLength is the length after which a column is re-ranked from
+// scratch instead of growing the rank further.
+const rankMaxLength = 48
+
+// rankBetween returns a rank that sorts strictly after prev and before next.
+// An empty prev means the start of the column and an empty next its end.
+// prev must sort before next.
+func rankBetween(prev, next string) string {
+	if next != "" {
+		n := 0
+		for n < len(next) && rankDigitAt(prev, n) == next[n] {
+			n++
+		}
+		if n > 0 {
+			rest := ""
+			if n < len(prev) {
+				rest = prev[n:]
+			}
+			return next[:n] + rankBetween(rest, next[n:])
+		}
+	}
+
+	lo := 0
+	if prev != "" {
+		lo = strings.IndexByte(rankDigits, prev[0])
+	}
+	hi := len(rankDigits)
+	if next != "" {
+		hi = strings.IndexByte(rankDigits, next[0])
+	}
+
+	if hi-lo > 1 {
+		// Appending is by far the most common move, so step by one digit
+		// instead of halving the remaining space to keep ranks short.
+		if next == "" && prev != "" {
+			return string(rankDigits[lo+1])
+		}
+		return string(rankDigits[(lo+hi+1)/2])
+	}
+	if next != "" && len(next) > 1 {
+		return next[:1]
+	}
+
+	rest := ""
+	if prev != "" {
+		rest = prev[1:]
+	}
+	return string(rankDigits[lo]) + rankBetween(rest, "")
+}
+
+// rankSequence returns n evenly spread ranks in ascending order.
+func rankSequence(n int) []string {
+	ranks := make([]string, n)
+	width := 1
+	for capacity := len(rankDigits) - 1; capacity < n; capacity *= len(rankDigits) {
+		width++
+	}
+
+	space := 1
+	for i := 0; i < width; i++ {
+		space *= len(rankDigits)
+	}
+	step := space / (n + 1)
+
+	for i := range ranks {
+		value := step * (i + 1)
+		digits := make([]byte, width)
+		for j := width - 1; j >= 0; j-- {
+			digits[j] = rankDigits[value%len(rankDigits)]
+			value /= len(rankDigits)
+		}
+		ranks[i] = strings.TrimRight(string(digits), "0")
+	}
+	return ranks
+}
+
+func rankDigitAt(rank string, i int) byte {
+	if i < len(rank) {
+		return rank[i]
+	}
+	return rankDigits[0]
+}
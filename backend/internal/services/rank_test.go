@@ -0,0 +1,132 @@
+package services
+
+import (
+	"strings"
+	"testing"
+)
+
+// checkRank fails unless rank is a valid rank strictly between prev and
+// next, where empty bounds are open.
+func checkRank(t *testing.T, prev, next, rank string) {
+	t.Helper()
+
+	if rank == "" {
+		t.Fatalf("rankBetween(%q, %q) returned an empty rank", prev, next)
+	}
+	if strings.Trim(rank, rankDigits) != "" {
+		t.Errorf("rankBetween(%q, %q) = %q uses characters outside %q", prev, next, rank, rankDigits)
+	}
+	if strings.HasSuffix(rank, "0") {
+		t.Errorf("rankBetween(%q, %q) = %q ends in '0'", prev, next, rank)
+	}
+	if prev != "" && rank <= prev {
+		t.Errorf("rankBetween(%q, %q) = %q does not sort after %q", prev, next, rank, prev)
+	}
+	if next != "" && rank >= next {
+		t.Errorf("rankBetween(%q, %q) = %q does not sort before %q", prev, next, rank, next)
+	}
+}
+
+func TestRankBetween(t *testing.T) {
+	tests := []struct {
+		name       string
+		prev, next string
+		want       string
+	}{
+		{"empty column", "", "", "i"},
+		{"append steps by one digit", "i", "", "j"},
+		{"append after last digit", "z", "", "zi"},
+		{"prepend", "", "i", "9"},
+		{"prepend before lowest digit", "", "1", "0i"},
+		{"prepend before leading zero", "", "01", "00i"},
+		{"midpoint", "a", "k", "f"},
+		{"adjacent digits", "i", "j", "ii"},
+		{"adjacent digits with longer next", "i", "jz", "j"},
+		{"adjacent digits with longer prev", "iz", "j", "izi"},
+		{"prev is prefix of next", "a", "ab", "a6"},
+		{"prev is prefix of next with low digit", "a", "a1", "a0i"},
+		{"shared prefix", "abc", "abe", "abd"},
+		{"shared prefix adjacent", "abc", "abd", "abci"},
+		{"next is longer after shared prefix", "ab", "ab1", "ab0i"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rankBetween(tt.prev, tt.next)
+			checkRank(t, tt.prev, tt.next, got)
+			if got != tt.want {
+				t.Errorf("rankBetween(%q, %q) = %q, want %q", tt.prev, tt.next, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRankBetweenExhaustion(t *testing.T) {
+	tests := []struct {
+		name string
+		next func(prev, last string) (string, string)
+	}{
+		// Always inserting directly below the same todo halves the same gap.
+		{"insert after the same todo", func(prev, last string) (string, string) { return prev, last }},
+		// Always inserting directly above the same todo.
+		{"insert before the same todo", func(prev, last string) (string, string) { return last, "j" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prev, next := "i", "j"
+			for i := 0; ; i++ {
+				rank := rankBetween(prev, next)
+				checkRank(t, prev, next, rank)
+				if t.Failed() {
+					return
+				}
+				if len(rank) > rankMaxLength {
+					break
+				}
+				if i > 10000 {
+					t.Fatalf("rank never grew past rankMaxLength, last rank %q", rank)
+				}
+				prev, next = tt.next(prev, rank)
+			}
+		})
+	}
+}
+
+func TestRankSequence(t *testing.T) {
+	tests := []struct {
+		n     int
+		width int
+	}{
+		{0, 0},
+		{1, 1},
+		{35, 1},
+		{36, 2},
+		{1000, 2},
+		{1260, 2},
+		{1261, 3},
+	}
+
+	for _, tt := range tests {
+		ranks := rankSequence(tt.n)
+		if len(ranks) != tt.n {
+			t.Fatalf("rankSequence(%d) returned %d ranks", tt.n, len(ranks))
+		}
+		for i, rank := range ranks {
+			if rank == "" || strings.HasSuffix(rank, "0") || len(rank) > tt.width {
+				t.Fatalf("rankSequence(%d)[%d] = %q is not a valid rank of at most %d digits", tt.n, i, rank, tt.width)
+			}
+			if i > 0 && ranks[i-1] >= rank {
+				t.Fatalf("rankSequence(%d) is not ascending at %d: %q >= %q", tt.n, i, ranks[i-1], rank)
+			}
+		}
+	}
+
+	// A re-ranked column must leave room to append and to insert anywhere.
+	ranks := rankSequence(100)
+	checkRank(t, ranks[len(ranks)-1], "", rankBetween(ranks[len(ranks)-1], ""))
+	checkRank(t, "", ranks[0], rankBetween("", ranks[0]))
+	for i := 1; i < len(ranks); i++ {
+		checkRank(t, ranks[i-1], ranks[i], rankBetween(ranks[i-1], ranks[i]))
+	}
+}
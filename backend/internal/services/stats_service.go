@@ -0,0 +1,185 @@
+package services
+
+import (
+	"fmt"
+	"time"
+	_ "time/tzdata" // the container image ships without zoneinfo
+
+	"task-management/internal/models"
+	"task-management/internal/repository"
+)
+
+const (
+	statsDateLayout   = "2006-01-02"
+	statsDefaultRange = 30
+	statsMaxRange     = 366
+)
+
+// Stats summarises the todos of a workspace. Counts by status, priority and category
+// always list every value, including those with no todos.
+type Stats struct {
+	Total                   int64                     `json:"total"`
+	ByStatus                map[models.Status]int64   `json:"by_status"`
+	ByPriority              map[models.Priority]int64 `json:"by_priority"`
+	ByCategory              map[models.Category]int64 `json:"by_category"`
+	Overdue                 int64                     `json:"overdue"`
+	DueToday                int64                     `json:"due_today"`
+	CompletionRate          float64                   `json:"completion_rate"`
+	AverageCycleTimeSeconds *float64                  `json:"average_cycle_time_seconds"`
+	From                    string                    `json:"from"`
+	To                      string                    `json:"to"`
+	Timezone                string                    `json:"timezone"`
+	Series                  []DailyStat               `json:"series"`
+}
+
+// DailyStat is the number of todos created and completed on one local day.
+type DailyStat struct {
+	Date      string `json:"date"`
+	Created   int64  `json:"created"`
+	Completed int64  `json:"completed"`
+}
+
+// StatsQuery selects the time series range. From and To are inclusive
+// YYYY-MM-DD dates in Timezone; empty values default to the last 30 days
+// in UTC.
+type StatsQuery struct {
+	From     string
+	To       string
+	Timezone string
+}
+
+type StatsService interface {
+	GetStats(workspaceID, userID uint, query StatsQuery) (*Stats, error)
+}
+
+type statsService struct {
+	statsRepo repository.StatsRepository
+}
+
+func NewStatsService(statsRepo repository.StatsRepository) StatsService {
+	return &statsService{
+		statsRepo: statsRepo,
+	}
+}
+
+func (s *statsService) GetStats(workspaceID, userID uint, query StatsQuery) (*Stats, error) {
+	loc, from, to, err := parseStatsQuery(query, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	scope := repository.WorkspaceScope(workspaceID, userID)
+
+	stats := &Stats{
+		ByStatus:   map[models.Status]int64{models.StatusTodo: 0, models.StatusInProgress: 0, models.StatusDone: 0},
+		ByPriority: map[models.Priority]int64{models.PriorityLow: 0, models.PriorityMedium: 0, models.PriorityHigh: 0},
+		ByCategory: map[models.Category]int64{
+			models.CategoryPersonal: 0, models.CategoryWork: 0, models.CategoryShopping: 0,
+			models.CategoryHealth: 0, models.CategoryOther: 0,
+		},
+		From:     from.Format(statsDateLayout),
+		To:       to.AddDate(0, 0, -1).Format(statsDateLayout),
+		Timezone: loc.String(),
+	}
+
+	byStatus, err := s.statsRepo.CountBy(scope, "status")
+	if err != nil {
+		return nil, fmt.Errorf("failed to count todos by status: %w", err)
+	}
+	for status, count := range byStatus {
+		stats.ByStatus[models.Status(status)] = count
+		stats.Total += count
+	}
+
+	byPriority, err := s.statsRepo.CountBy(scope, "priority")
+	if err != nil {
+		return nil, fmt.Errorf("failed to count todos by priority: %w", err)
+	}
+	for priority, count := range byPriority {
+		stats.ByPriority[models.Priority(priority)] = count
+	}
+
+	byCategory, err := s.statsRepo.CountBy(scope, "category")
+	if err != nil {
+		return nil, fmt.Errorf("failed to count todos by category: %w", err)
+	}
+	for category, count := range byCategory {
+		stats.ByCategory[models.Category(category)] = count
+	}
+
+	if stats.Total > 0 {
+		stats.CompletionRate = float64(stats.ByStatus[models.StatusDone]) / float64(stats.Total)
+	}
+
+	now := time.Now().In(loc)
+	if stats.Overdue, err = s.statsRepo.CountOverdue(scope, now); err != nil {
+		return nil, fmt.Errorf("failed to count overdue todos: %w", err)
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	if stats.DueToday, err = s.statsRepo.CountDueBetween(scope, today, today.AddDate(0, 0, 1)); err != nil {
+		return nil, fmt.Errorf("failed to count todos due today: %w", err)
+	}
+
+	if stats.AverageCycleTimeSeconds, err = s.statsRepo.AverageCycleTime(scope); err != nil {
+		return nil, fmt.Errorf("failed to compute cycle time: %w", err)
+	}
+
+	created, err := s.statsRepo.DailyCounts(scope, "created_at", from, to, loc.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to count created todos: %w", err)
+	}
+	completed, err := s.statsRepo.DailyCounts(scope, "completed_at", from, to, loc.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to count completed todos: %w", err)
+	}
+
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format(statsDateLayout)
+		stats.Series = append(stats.Series, DailyStat{
+			Date:      date,
+			Created:   created[date],
+			Completed: completed[date],
+		})
+	}
+
+	return stats, nil
+}
+
+// parseStatsQuery resolves the query into a location and a half-open
+// [from, to) range of local midnights.
+func parseStatsQuery(query StatsQuery, now time.Time) (*time.Location, time.Time, time.Time, error) {
+	loc := time.UTC
+	if query.Timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(query.Timezone); err != nil {
+			return nil, time.Time{}, time.Time{}, fmt.Errorf("%w: unknown timezone %q", ErrInvalidValue, query.Timezone)
+		}
+	}
+
+	now = now.In(loc)
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	if query.To != "" {
+		parsed, err := time.ParseInLocation(statsDateLayout, query.To, loc)
+		if err != nil {
+			return nil, time.Time{}, time.Time{}, fmt.Errorf("%w: to must be a YYYY-MM-DD date", ErrInvalidValue)
+		}
+		to = parsed
+	}
+	from := to.AddDate(0, 0, -(statsDefaultRange - 1))
+	if query.From != "" {
+		parsed, err := time.ParseInLocation(statsDateLayout, query.From, loc)
+		if err != nil {
+			return nil, time.Time{}, time.Time{}, fmt.Errorf("%w: from must be a YYYY-MM-DD date", ErrInvalidValue)
+		}
+		from = parsed
+	}
+
+	// Make the end exclusive so the whole of the last day is counted.
+	to = to.AddDate(0, 0, 1)
+	if !from.Before(to) {
+		return nil, time.Time{}, time.Time{}, fmt.Errorf("%w: from must not be after to", ErrInvalidValue)
+	}
+	if from.AddDate(0, 0, statsMaxRange).Before(to) {
+		return nil, time.Time{}, time.Time{}, fmt.Errorf("%w: range must not exceed %d days", ErrInvalidValue, statsMaxRange)
+	}
+	return loc, from, to, nil
+}
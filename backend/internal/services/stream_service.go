@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"task-management/internal/config"
+	"task-management/internal/models"
+	"task-management/internal/repository"
+)
+
+// StreamChannel is the Postgres NOTIFY channel used to fan events out
+// across backend instances.
+const StreamChannel = "todo_events"
+
+const (
+	streamReplayLimit      = 1000
+	streamSubscriberBuffer = 64
+)
+
+type StreamService interface {
+	EventPublisher
+	Subscribe(userID uint, lastEventID uint64) (*Subscription, error)
+	HandleNotification(payload string)
+	Run(ctx context.Context)
+}
+
+// Subscription delivers the events of the workspaces a user belongs to to
+// one connected client.
+// Replay holds the events missed since the Last-Event-ID sent by the client;
+// Events is closed when the subscriber falls too far behind and has to
+// reconnect.
+type Subscription struct {
+	Replay []models.TodoEvent
+	Events <-chan models.TodoEvent
+
+	close func()
+}
+
+func (s *Subscription) Close() {
+	s.close()
+}
+
+type streamService struct {
+	eventRepo     repository.EventRepository
+	workspaceRepo repository.WorkspaceRepository
+	config        *config.Config
+
+	mu          sync.RWMutex
+	subscribers map[uint]map[chan models.TodoEvent]struct{}
+}
+
+func NewStreamService(eventRepo repository.EventRepository, workspaceRepo repository.WorkspaceRepository, cfg *config.Config) StreamService {
+	return &streamService{
+		eventRepo:     eventRepo,
+		workspaceRepo: workspaceRepo,
+		config:        cfg,
+		subscribers:   make(map[uint]map[chan models.TodoEvent]struct{}),
+	}
+}
+
+// Publish stores the event and notifies every instance through Postgres.
+// When NOTIFY is unavailable the event is only dispatched locally.
+func (s *streamService) Publish(workspaceID, userID uint, event models.EventType, data interface{}) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		log.Printf("stream: failed to encode %s payload: %v", event, err)
+		return
+	}
+
+	todoEvent := &models.TodoEvent{
+		WorkspaceID: workspaceID,
+		TodoID:      eventTodoID(data),
+		UserID:      userID,
+		Type:        event,
+		Payload:     string(payload),
+	}
+	if err := s.eventRepo.Create(todoEvent); err != nil {
+		log.Printf("stream: failed to store %s event: %v", event, err)
+		return
+	}
+
+	if err := s.eventRepo.Notify(StreamChannel, strconv.FormatUint(todoEvent.ID, 10)); err != nil {
+		if !errors.Is(err, repository.ErrNotifyUnsupported) {
+			log.Printf("stream: notify failed, dispatching locally: %v", err)
+		}
+		s.dispatch(*todoEvent)
+	}
+}
+
+// eventTodoID returns the ID of the todo an event payload is about.
+func eventTodoID(data interface{}) *uint {
+	switch v := data.(type) {
+	case *models.Todo:
+		return &v.ID
+	case *models.Subtask:
+		return &v.TodoID
+	}
+	return nil
+}
+
+// HandleNotification is called for every NOTIFY received on StreamChannel.
+func (s *streamService) HandleNotification(payload string) {
+	id, err := strconv.ParseUint(payload, 10, 64)
+	if err != nil {
+		log.Printf("stream: ignoring malformed notification %q", payload)
+		return
+	}
+
+	if !s.hasSubscribers() {
+		return
+	}
+
+	event, err := s.eventRepo.GetByID(id)
+	if err != nil {
+		log.Printf("stream: failed to load event %d: %v", id, err)
+		return
+	}
+	s.dispatch(*event)
+}
+
+func (s *streamService) Subscribe(userID uint, lastEventID uint64) (*Subscription, error) {
+	ch := make(chan models.TodoEvent, streamSubscriberBuffer)
+
+	s.mu.Lock()
+	if s.subscribers[userID] == nil {
+		s.subscribers[userID] = make(map[chan models.TodoEvent]struct{})
+	}
+	s.subscribers[userID][ch] = struct{}{}
+	s.mu.Unlock()
+
+	sub := &Subscription{
+		Events: ch,
+		close:  func() { s.unsubscribe(userID, ch) },
+	}
+
+	// Register before reading the backlog so nothing is lost in between;
+	// callers skip live events already covered by the replay.
+	if lastEventID > 0 {
+		replay, err := s.eventRepo.GetSince(userID, lastEventID, streamReplayLimit)
+		if err != nil {
+			sub.Close()
+			return nil, errors.New("failed to load missed events")
+		}
+		sub.Replay = replay
+	}
+
+	return sub, nil
+}
+
+// Run prunes events older than the configured retention until ctx is
+// cancelled.
+func (s *streamService) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		if err := s.eventRepo.DeleteOlderThan(time.Now().Add(-s.config.Stream.Retention)); err != nil {
+			log.Printf("stream: failed to prune events: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatch sends the event to the connected members of its workspace.
+func (s *streamService) dispatch(event models.TodoEvent) {
+	if !s.hasSubscribers() {
+		return
+	}
+
+	members, err := s.workspaceRepo.GetMemberIDs(event.WorkspaceID)
+	if err != nil {
+		log.Printf("stream: failed to load members of workspace %d: %v", event.WorkspaceID, err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, userID := range members {
+		for ch := range s.subscribers[userID] {
+			select {
+			case ch <- event:
+			default:
+				// Slow consumer: drop it, the client resumes with Last-Event-ID.
+				delete(s.subscribers[userID], ch)
+				close(ch)
+			}
+		}
+	}
+}
+
+func (s *streamService) unsubscribe(userID uint, ch chan models.TodoEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.subscribers[userID][ch]; ok {
+		delete(s.subscribers[userID], ch)
+		close(ch)
+	}
+	if len(s.subscribers[userID]) == 0 {
+		delete(s.subscribers, userID)
+	}
+}
+
+func (s *streamService) hasSubscribers() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.subscribers) > 0
+}
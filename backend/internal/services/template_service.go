@@ -0,0 +1,299 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"task-management/internal/models"
+	"task-management/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+const (
+	templateNameMaxLength = 100
+	templateMaxSubtasks   = 100
+	// maxDueOffsetMinutes caps how far after instantiation a template's due
+	// date may lie: five years.
+	maxDueOffsetMinutes = 5 * 365 * 24 * 60
+)
+
+var templateVariablePattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// TemplateInput holds the editable fields of a template. Priority and
+// category default to medium and personal.
+type TemplateInput struct {
+	Name        string
+	Title       string
+	Description string
+	Priority    models.Priority
+	Category    models.Category
+	DueOffset   *int
+	Estimate    *int
+	Subtasks    []string
+}
+
+type TemplateService interface {
+	GetTemplates(workspaceID, userID uint) ([]models.TodoTemplate, error)
+	CreateTemplate(workspaceID, userID uint, input TemplateInput) (*models.TodoTemplate, error)
+	GetTemplate(id, userID uint) (*models.TodoTemplate, error)
+	UpdateTemplate(id, userID uint, input TemplateInput) (*models.TodoTemplate, error)
+	DeleteTemplate(id, userID uint) error
+	InstantiateTemplate(id, userID uint, variables map[string]string, from time.Time, timezone string) (*models.Todo, error)
+}
+
+type templateService struct {
+	templateRepo  repository.TemplateRepository
+	workspaceRepo repository.WorkspaceRepository
+	todoService   TodoService
+}
+
+func NewTemplateService(templateRepo repository.TemplateRepository, workspaceRepo repository.WorkspaceRepository, todoService TodoService) TemplateService {
+	return &templateService{
+		templateRepo:  templateRepo,
+		workspaceRepo: workspaceRepo,
+		todoService:   todoService,
+	}
+}
+
+func (s *templateService) GetTemplates(workspaceID, userID uint) ([]models.TodoTemplate, error) {
+	if _, err := workspaceRole(s.workspaceRepo, workspaceID, userID); err != nil {
+		return nil, err
+	}
+
+	templates, err := s.templateRepo.GetByWorkspaceID(workspaceID)
+	if err != nil {
+		return nil, errors.New("database error")
+	}
+	for i := range templates {
+		templates[i].Variables = templateVariables(&templates[i])
+	}
+	return templates, nil
+}
+
+func (s *templateService) CreateTemplate(workspaceID, userID uint, input TemplateInput) (*models.TodoTemplate, error) {
+	if err := s.authorize(workspaceID, userID, models.RoleMember); err != nil {
+		return nil, err
+	}
+
+	template := &models.TodoTemplate{WorkspaceID: workspaceID, UserID: userID}
+	if err := applyTemplateInput(template, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.templateRepo.Create(template); err != nil {
+		return nil, errors.New("failed to create template")
+	}
+	template.Variables = templateVariables(template)
+	return template, nil
+}
+
+func (s *templateService) GetTemplate(id, userID uint) (*models.TodoTemplate, error) {
+	return s.getTemplate(id, userID, models.RoleGuest)
+}
+
+func (s *templateService) UpdateTemplate(id, userID uint, input TemplateInput) (*models.TodoTemplate, error) {
+	template, err := s.getTemplate(id, userID, models.RoleMember)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyTemplateInput(template, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.templateRepo.Update(template); err != nil {
+		return nil, errors.New("failed to update template")
+	}
+	template.Variables = templateVariables(template)
+	return template, nil
+}
+
+func (s *templateService) DeleteTemplate(id, userID uint) error {
+	if _, err := s.getTemplate(id, userID, models.RoleMember); err != nil {
+		return err
+	}
+
+	if err := s.templateRepo.Delete(id); err != nil {
+		return errors.New("failed to delete template")
+	}
+	return nil
+}
+
+// InstantiateTemplate creates a todo and its subtasks from the template in
+// the template's workspace. Every variable the template uses must be given;
+// the due date is the template's offset added to from. Whole days of the
+// offset are calendar days in the given IANA time zone, which defaults to
+// UTC, so the due time keeps its wall clock time across DST changes.
+func (s *templateService) InstantiateTemplate(id, userID uint, variables map[string]string, from time.Time, timezone string) (*models.Todo, error) {
+	loc := time.UTC
+	if timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(timezone); err != nil {
+			return nil, fmt.Errorf("%w: unknown timezone %q", ErrInvalidValue, timezone)
+		}
+	}
+
+	template, err := s.getTemplate(id, userID, models.RoleMember)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, name := range template.Variables {
+		if _, ok := variables[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: missing variables: %s", ErrInvalidValue, strings.Join(missing, ", "))
+	}
+
+	todo := &models.Todo{
+		WorkspaceID: template.WorkspaceID,
+		Title:       strings.TrimSpace(renderTemplate(template.Title, variables)),
+		Description: renderTemplate(template.Description, variables),
+		Priority:    template.Priority,
+		Category:    template.Category,
+		Estimate:    template.Estimate,
+	}
+	if todo.Title == "" {
+		return nil, fmt.Errorf("%w: title is empty after substituting variables", ErrInvalidValue)
+	}
+	if template.DueOffset != nil {
+		due := addDueOffset(from.In(loc), *template.DueOffset)
+		todo.DueDate = &due
+	}
+	for _, title := range template.Subtasks {
+		title = strings.TrimSpace(renderTemplate(title, variables))
+		if title == "" {
+			return nil, fmt.Errorf("%w: subtask title is empty after substituting variables", ErrInvalidValue)
+		}
+		todo.Subtasks = append(todo.Subtasks, models.Subtask{Title: title, IsCompleted: models.CompletionNo})
+	}
+
+	return s.todoService.CreateFromTemplate(todo, userID)
+}
+
+// addDueOffset adds an offset in minutes to from, counting whole days as
+// calendar days rather than 24 hours.
+func addDueOffset(from time.Time, minutes int) time.Time {
+	const minutesPerDay = 24 * 60
+	days := minutes / minutesPerDay
+	return from.AddDate(0, 0, days).Add(time.Duration(minutes-days*minutesPerDay) * time.Minute)
+}
+
+// getTemplate loads a template of a workspace the user holds at least the
+// min role in. Templates of other workspaces are reported as not found.
+func (s *templateService) getTemplate(id, userID uint, min models.WorkspaceRole) (*models.TodoTemplate, error) {
+	template, err := s.templateRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("template not found")
+		}
+		return nil, errors.New("database error")
+	}
+
+	if err := s.authorize(template.WorkspaceID, userID, min); err != nil {
+		if err.Error() == "workspace not found" {
+			return nil, errors.New("template not found")
+		}
+		return nil, err
+	}
+
+	template.Variables = templateVariables(template)
+	return template, nil
+}
+
+func (s *templateService) authorize(workspaceID, userID uint, min models.WorkspaceRole) error {
+	role, err := workspaceRole(s.workspaceRepo, workspaceID, userID)
+	if err != nil {
+		return err
+	}
+	if !role.AtLeast(min) {
+		return ErrWorkspaceForbidden
+	}
+	return nil
+}
+
+func applyTemplateInput(template *models.TodoTemplate, input TemplateInput) error {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidValue)
+	}
+	if len([]rune(name)) > templateNameMaxLength {
+		return fmt.Errorf("%w: name must be at most %d characters", ErrInvalidValue, templateNameMaxLength)
+	}
+
+	title := strings.TrimSpace(input.Title)
+	if title == "" {
+		return fmt.Errorf("%w: title is required", ErrInvalidValue)
+	}
+
+	if input.Priority == "" {
+		input.Priority = models.PriorityMedium
+	}
+	if input.Category == "" {
+		input.Category = models.CategoryPersonal
+	}
+	if err := validatePriority(input.Priority); err != nil {
+		return err
+	}
+	if err := validateCategory(input.Category); err != nil {
+		return err
+	}
+	if err := validateEstimate(input.Estimate); err != nil {
+		return err
+	}
+	if input.DueOffset != nil && (*input.DueOffset < 0 || *input.DueOffset > maxDueOffsetMinutes) {
+		return fmt.Errorf("%w: due_offset_minutes must be between 0 and %d", ErrInvalidValue, maxDueOffsetMinutes)
+	}
+
+	if len(input.Subtasks) > templateMaxSubtasks {
+		return fmt.Errorf("%w: a template can have at most %d subtasks", ErrInvalidValue, templateMaxSubtasks)
+	}
+	subtasks := make([]string, 0, len(input.Subtasks))
+	for _, subtask := range input.Subtasks {
+		subtask = strings.TrimSpace(subtask)
+		if subtask == "" {
+			return fmt.Errorf("%w: subtask titles must not be empty", ErrInvalidValue)
+		}
+		subtasks = append(subtasks, subtask)
+	}
+
+	template.Name = name
+	template.Title = title
+	template.Description = input.Description
+	template.Priority = input.Priority
+	template.Category = input.Category
+	template.DueOffset = input.DueOffset
+	template.Estimate = input.Estimate
+	template.Subtasks = subtasks
+	return nil
+}
+
+// templateVariables returns the distinct variable names used in the
+// template, in order of first use.
+func templateVariables(template *models.TodoTemplate) []string {
+	texts := append([]string{template.Title, template.Description}, template.Subtasks...)
+
+	seen := map[string]bool{}
+	variables := []string{}
+	for _, text := range texts {
+		for _, match := range templateVariablePattern.FindAllStringSubmatch(text, -1) {
+			if !seen[match[1]] {
+				seen[match[1]] = true
+				variables = append(variables, match[1])
+			}
+		}
+	}
+	return variables
+}
+
+func renderTemplate(text string, variables map[string]string) string {
+	return templateVariablePattern.ReplaceAllStringFunc(text, func(match string) string {
+		return variables[templateVariablePattern.FindStringSubmatch(match)[1]]
+	})
+}
@@ -0,0 +1,37 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAddDueOffset(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+	// Clocks in Berlin go from 02:00 to 03:00 on 2024-03-31.
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2024, 3, day, hour, min, 0, 0, berlin)
+	}
+
+	tests := []struct {
+		name    string
+		from    time.Time
+		minutes int
+		want    time.Time
+	}{
+		{"days keep the wall clock time", at(29, 9, 0), 3 * 24 * 60, time.Date(2024, 4, 1, 9, 0, 0, 0, berlin)},
+		{"days and minutes", at(30, 9, 0), 24*60 + 90, at(31, 10, 30)},
+		{"negative days", time.Date(2024, 4, 1, 9, 0, 0, 0, berlin), -3 * 24 * 60, at(29, 9, 0)},
+		{"minutes are elapsed time", at(31, 1, 30), 90, at(31, 4, 0)},
+		{"no offset", at(31, 9, 0), 0, at(31, 9, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := addDueOffset(tt.from, tt.minutes); !got.Equal(tt.want) {
+				t.Errorf("addDueOffset(%v, %d) = %v, want %v", tt.from, tt.minutes, got, tt.want)
+			}
+		})
+	}
+}
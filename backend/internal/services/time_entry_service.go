@@ -0,0 +1,345 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"task-management/internal/models"
+	"task-management/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+const (
+	timeEntryNoteMaxLength = 1000
+	timeEntryMaxDuration   = 24 * time.Hour
+)
+
+// ErrTimerRunning is returned when starting a timer while another one is
+// still running.
+var ErrTimerRunning = errors.New("a timer is already running")
+
+type ReportGrouping string
+
+const (
+	GroupByDay  ReportGrouping = "day"
+	GroupByWeek ReportGrouping = "week"
+)
+
+// TimeEntryInput holds the fields of a manual time entry. On update, nil
+// fields are left unchanged.
+type TimeEntryInput struct {
+	StartedAt *time.Time
+	EndedAt   *time.Time
+	Note      *string
+}
+
+// TodoTimeTotal compares the time tracked on a todo with its estimate.
+// Remaining is negative once the estimate is exceeded.
+type TodoTimeTotal struct {
+	TodoID    uint              `json:"todo_id"`
+	Estimate  *int              `json:"estimate_minutes"`
+	Tracked   int64             `json:"tracked_seconds"`
+	Remaining *int64            `json:"remaining_seconds"`
+	Running   *models.TimeEntry `json:"running,omitempty"`
+}
+
+// TimeReport is the tracked time in a date range, grouped by day or by week.
+// Weeks start on Monday and are labelled with that date.
+type TimeReport struct {
+	From     string             `json:"from"`
+	To       string             `json:"to"`
+	Timezone string             `json:"timezone"`
+	GroupBy  ReportGrouping     `json:"group_by"`
+	Total    int64              `json:"total_seconds"`
+	Periods  []TimeReportPeriod `json:"periods"`
+}
+
+type TimeReportPeriod struct {
+	Start   string `json:"start"`
+	Seconds int64  `json:"seconds"`
+}
+
+// TimeReportQuery selects the report range like StatsQuery, plus how to
+// group it.
+type TimeReportQuery struct {
+	StatsQuery
+	GroupBy ReportGrouping
+}
+
+type TimeEntryService interface {
+	StartTimer(todoID, userID uint, note string) (*models.TimeEntry, error)
+	StopTimer(userID uint) (*models.TimeEntry, error)
+	GetRunningTimer(userID uint) (*models.TimeEntry, error)
+	GetEntries(todoID, userID uint) ([]models.TimeEntry, error)
+	CreateEntry(todoID, userID uint, input TimeEntryInput) (*models.TimeEntry, error)
+	UpdateEntry(todoID, entryID, userID uint, input TimeEntryInput) (*models.TimeEntry, error)
+	DeleteEntry(todoID, entryID, userID uint) error
+	GetTodoTotal(todoID, userID uint) (*TodoTimeTotal, error)
+	GetCategoryTotals(userID uint) (map[models.Category]int64, error)
+	GetReport(userID uint, query TimeReportQuery) (*TimeReport, error)
+}
+
+type timeEntryService struct {
+	timeEntryRepo repository.TimeEntryRepository
+	todoService   TodoService
+}
+
+func NewTimeEntryService(timeEntryRepo repository.TimeEntryRepository, todoService TodoService) TimeEntryService {
+	return &timeEntryService{
+		timeEntryRepo: timeEntryRepo,
+		todoService:   todoService,
+	}
+}
+
+func (s *timeEntryService) StartTimer(todoID, userID uint, note string) (*models.TimeEntry, error) {
+	if _, err := s.todoService.GetEditableTodo(todoID, userID); err != nil {
+		return nil, err
+	}
+
+	note, err := validateTimeEntryNote(note)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.timeEntryRepo.GetRunning(userID); err == nil {
+		return nil, ErrTimerRunning
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("database error")
+	}
+
+	entry := &models.TimeEntry{
+		TodoID:    todoID,
+		UserID:    userID,
+		Note:      note,
+		StartedAt: time.Now(),
+	}
+	// The partial unique index rejects a second timer started concurrently.
+	if err := s.timeEntryRepo.Create(entry); err != nil {
+		return nil, errors.New("failed to start timer")
+	}
+	return entry, nil
+}
+
+func (s *timeEntryService) StopTimer(userID uint) (*models.TimeEntry, error) {
+	entry, err := s.GetRunningTimer(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.Stop(time.Now())
+	if err := s.timeEntryRepo.Update(entry); err != nil {
+		return nil, errors.New("failed to stop timer")
+	}
+	return entry, nil
+}
+
+func (s *timeEntryService) GetRunningTimer(userID uint) (*models.TimeEntry, error) {
+	entry, err := s.timeEntryRepo.GetRunning(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("no timer is running")
+		}
+		return nil, errors.New("database error")
+	}
+	return entry, nil
+}
+
+func (s *timeEntryService) GetEntries(todoID, userID uint) ([]models.TimeEntry, error) {
+	if _, err := s.todoService.GetTodoByID(todoID, userID); err != nil {
+		return nil, err
+	}
+	return s.timeEntryRepo.GetByTodoID(todoID, userID)
+}
+
+func (s *timeEntryService) CreateEntry(todoID, userID uint, input TimeEntryInput) (*models.TimeEntry, error) {
+	if _, err := s.todoService.GetEditableTodo(todoID, userID); err != nil {
+		return nil, err
+	}
+	if input.StartedAt == nil || input.EndedAt == nil {
+		return nil, fmt.Errorf("%w: started_at and ended_at are required", ErrInvalidValue)
+	}
+
+	entry := &models.TimeEntry{TodoID: todoID, UserID: userID}
+	if err := applyTimeEntryInput(entry, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.timeEntryRepo.Create(entry); err != nil {
+		return nil, errors.New("failed to create time entry")
+	}
+	return entry, nil
+}
+
+func (s *timeEntryService) UpdateEntry(todoID, entryID, userID uint, input TimeEntryInput) (*models.TimeEntry, error) {
+	entry, err := s.getEntry(todoID, entryID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyTimeEntryInput(entry, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.timeEntryRepo.Update(entry); err != nil {
+		return nil, errors.New("failed to update time entry")
+	}
+	return entry, nil
+}
+
+func (s *timeEntryService) DeleteEntry(todoID, entryID, userID uint) error {
+	if _, err := s.getEntry(todoID, entryID, userID); err != nil {
+		return err
+	}
+
+	if err := s.timeEntryRepo.Delete(entryID); err != nil {
+		return errors.New("failed to delete time entry")
+	}
+	return nil
+}
+
+func (s *timeEntryService) GetTodoTotal(todoID, userID uint) (*TodoTimeTotal, error) {
+	todo, err := s.todoService.GetTodoByID(todoID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tracked, err := s.timeEntryRepo.TotalByTodo(todoID, userID)
+	if err != nil {
+		return nil, errors.New("database error")
+	}
+
+	total := &TodoTimeTotal{
+		TodoID:   todoID,
+		Estimate: todo.Estimate,
+		Tracked:  tracked,
+	}
+	if todo.Estimate != nil {
+		remaining := int64(*todo.Estimate)*60 - tracked
+		total.Remaining = &remaining
+	}
+	if running, err := s.timeEntryRepo.GetRunning(userID); err == nil && running.TodoID == todoID {
+		total.Running = running
+	}
+	return total, nil
+}
+
+func (s *timeEntryService) GetCategoryTotals(userID uint) (map[models.Category]int64, error) {
+	totals, err := s.timeEntryRepo.TotalsByCategory(userID)
+	if err != nil {
+		return nil, errors.New("database error")
+	}
+
+	byCategory := map[models.Category]int64{
+		models.CategoryPersonal: 0, models.CategoryWork: 0, models.CategoryShopping: 0,
+		models.CategoryHealth: 0, models.CategoryOther: 0,
+	}
+	for category, total := range totals {
+		byCategory[models.Category(category)] = total
+	}
+	return byCategory, nil
+}
+
+func (s *timeEntryService) GetReport(userID uint, query TimeReportQuery) (*TimeReport, error) {
+	if query.GroupBy == "" {
+		query.GroupBy = GroupByDay
+	}
+	if query.GroupBy != GroupByDay && query.GroupBy != GroupByWeek {
+		return nil, fmt.Errorf("%w: group_by must be day or week", ErrInvalidValue)
+	}
+
+	loc, from, to, err := parseStatsQuery(query.StatsQuery, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	daily, err := s.timeEntryRepo.DailyTotals(userID, from, to, loc.String())
+	if err != nil {
+		return nil, errors.New("database error")
+	}
+
+	report := &TimeReport{
+		From:     from.Format(statsDateLayout),
+		To:       to.AddDate(0, 0, -1).Format(statsDateLayout),
+		Timezone: loc.String(),
+		GroupBy:  query.GroupBy,
+		Periods:  []TimeReportPeriod{},
+	}
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		seconds := daily[day.Format(statsDateLayout)]
+		report.Total += seconds
+
+		start := day
+		if query.GroupBy == GroupByWeek {
+			// Monday is the first day of the week
+			start = day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+		}
+
+		label := start.Format(statsDateLayout)
+		if n := len(report.Periods); n > 0 && report.Periods[n-1].Start == label {
+			report.Periods[n-1].Seconds += seconds
+			continue
+		}
+		report.Periods = append(report.Periods, TimeReportPeriod{Start: label, Seconds: seconds})
+	}
+	return report, nil
+}
+
+func (s *timeEntryService) getEntry(todoID, entryID, userID uint) (*models.TimeEntry, error) {
+	if _, err := s.todoService.GetTodoByID(todoID, userID); err != nil {
+		return nil, err
+	}
+
+	entry, err := s.timeEntryRepo.GetByID(entryID, todoID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("time entry not found")
+		}
+		return nil, errors.New("database error")
+	}
+	return entry, nil
+}
+
+// applyTimeEntryInput copies the input onto the entry and validates the
+// result. Setting EndedAt on a running timer stops it.
+func applyTimeEntryInput(entry *models.TimeEntry, input TimeEntryInput) error {
+	if input.Note != nil {
+		note, err := validateTimeEntryNote(*input.Note)
+		if err != nil {
+			return err
+		}
+		entry.Note = note
+	}
+	if input.StartedAt != nil {
+		entry.StartedAt = *input.StartedAt
+	}
+	if input.EndedAt != nil {
+		entry.Stop(*input.EndedAt)
+	}
+
+	if entry.StartedAt.After(time.Now()) {
+		return fmt.Errorf("%w: started_at cannot be in the future", ErrInvalidValue)
+	}
+	if entry.Running() {
+		return nil
+	}
+	if !entry.EndedAt.After(entry.StartedAt) {
+		return fmt.Errorf("%w: ended_at must be after started_at", ErrInvalidValue)
+	}
+	if entry.EndedAt.Sub(entry.StartedAt) > timeEntryMaxDuration {
+		return fmt.Errorf("%w: a time entry cannot be longer than %d hours", ErrInvalidValue, int(timeEntryMaxDuration.Hours()))
+	}
+	// Recompute in case only the start moved.
+	entry.Stop(*entry.EndedAt)
+	return nil
+}
+
+func validateTimeEntryNote(note string) (string, error) {
+	note = strings.TrimSpace(note)
+	if len([]rune(note)) > timeEntryNoteMaxLength {
+		return "", fmt.Errorf("%w: note must be at most %d characters", ErrInvalidValue, timeEntryNoteMaxLength)
+	}
+	return note, nil
+}
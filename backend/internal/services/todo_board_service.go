@@ -0,0 +1,237 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"task-management/internal/models"
+	"task-management/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// boardStatuses are the Kanban columns in display order.
+var boardStatuses = []models.Status{models.StatusTodo, models.StatusInProgress, models.StatusDone}
+
+type BoardColumn struct {
+	Status models.Status `json:"status"`
+	Todos  []models.Todo `json:"todos"`
+}
+
+// MoveOperation places a todo in a status column. AfterID is the todo that
+// should end up directly above it and BeforeID the one directly below; with
+// neither the todo goes to the end of the column.
+type MoveOperation struct {
+	Status   models.Status
+	AfterID  *uint
+	BeforeID *uint
+}
+
+var (
+	errRankExhausted     = errors.New("no room between neighbours")
+	errNeighbourNotFound = errors.New("neighbour todo not found")
+	errNeighbourColumn   = errors.New("neighbour todo is not in the target column")
+	errNeighbourOrder    = fmt.Errorf("%w: after_id must be above before_id in the column", ErrInvalidValue)
+)
+
+// GetBoard returns the workspace's todos grouped into status columns, each in
+// rank order. Todos created before ranking existed have no rank and head
+// their column, oldest first, until a move next to them ranks it; reading the
+// board never writes.
+func (s *todoService) GetBoard(workspaceID, userID uint, category string) ([]BoardColumn, error) {
+	todos, err := s.todoRepo.GetBoard(repository.WorkspaceScope(workspaceID, userID), category)
+	if err != nil {
+		return nil, errors.New("database error")
+	}
+
+	if err := s.markBlocked(todos); err != nil {
+		return nil, err
+	}
+
+	columns := make([]BoardColumn, len(boardStatuses))
+	index := make(map[models.Status]int, len(boardStatuses))
+	for i, status := range boardStatuses {
+		columns[i] = BoardColumn{Status: status, Todos: []models.Todo{}}
+		index[status] = i
+	}
+	for _, todo := range todos {
+		if i, ok := index[todo.Status]; ok {
+			columns[i].Todos = append(columns[i].Todos, todo)
+		}
+	}
+	return columns, nil
+}
+
+// MoveTodo changes the todo's column and position. Only the moved todo is
+// written unless its neighbours have no room left between them, in which
+// case the target column is re-ranked first.
+func (s *todoService) MoveTodo(id, userID uint, op MoveOperation) (*models.Todo, error) {
+	todo, err := s.GetEditableTodo(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	previousStatus := todo.Status
+	before := todo.Snapshot()
+	if err := s.setStatus(todo, op.Status); err != nil {
+		return nil, err
+	}
+
+	err = s.todoRepo.Transaction(func(repo repository.TodoRepository) error {
+		if err := s.checkBlockers(repo, todo, previousStatus); err != nil {
+			return err
+		}
+
+		rank, err := moveRank(repo, todo, userID, op)
+		if errors.Is(err, errRankExhausted) {
+			if err := rebalanceColumn(repo, todo.WorkspaceID, op.Status, todo.ID); err != nil {
+				return err
+			}
+			rank, err = moveRank(repo, todo, userID, op)
+		}
+		if err != nil {
+			return err
+		}
+		todo.Rank = rank
+
+		if err := repo.Update(todo); err != nil {
+			return err
+		}
+		return recordRevision(repo, todo, &before, userID, models.RevisionUpdated, nil)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrStaleVersion):
+			return nil, s.todoConflict(id, userID)
+		case errors.Is(err, ErrTodoBlocked), errors.Is(err, errNeighbourNotFound), errors.Is(err, errNeighbourColumn), errors.Is(err, errNeighbourOrder):
+			return nil, err
+		}
+		return nil, errors.New("failed to move todo")
+	}
+
+	s.publish(todo.WorkspaceID, userID, models.EventTodoUpdated, todo)
+	if todo.Status == models.StatusDone && previousStatus != models.StatusDone {
+		s.publish(todo.WorkspaceID, userID, models.EventTodoCompleted, todo)
+	}
+
+	return todo, nil
+}
+
+// moveRank works out the rank between the requested neighbours. It returns
+// errRankExhausted when the column has to be re-ranked first.
+func moveRank(repo repository.TodoRepository, todo *models.Todo, userID uint, op MoveOperation) (string, error) {
+	neighbour := func(id uint) (*models.Todo, error) {
+		if id == todo.ID {
+			return nil, errNeighbourNotFound
+		}
+		n, err := repo.GetByID(id, userID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errNeighbourNotFound
+			}
+			return nil, err
+		}
+		if n.WorkspaceID != todo.WorkspaceID {
+			return nil, errNeighbourNotFound
+		}
+		if n.Status != op.Status {
+			return nil, errNeighbourColumn
+		}
+		if n.Rank == "" {
+			return nil, errRankExhausted
+		}
+		return n, nil
+	}
+
+	var prev, next string
+
+	if op.AfterID != nil {
+		after, err := neighbour(*op.AfterID)
+		if err != nil {
+			return "", err
+		}
+		prev = after.Rank
+	}
+	if op.BeforeID != nil {
+		before, err := neighbour(*op.BeforeID)
+		if err != nil {
+			return "", err
+		}
+		next = before.Rank
+	}
+	// Re-ranking cannot help neighbours given the wrong way round. Equal
+	// ranks are left to the re-ranking, which separates them.
+	if op.AfterID != nil && op.BeforeID != nil && prev > next {
+		return "", errNeighbourOrder
+	}
+
+	var err error
+	switch {
+	case op.AfterID != nil && op.BeforeID == nil:
+		next, err = repo.AdjacentRank(todo.WorkspaceID, op.Status, prev, true, todo.ID)
+	case op.BeforeID != nil && op.AfterID == nil:
+		prev, err = repo.AdjacentRank(todo.WorkspaceID, op.Status, next, false, todo.ID)
+	case op.AfterID == nil && op.BeforeID == nil:
+		prev, err = repo.LastRank(todo.WorkspaceID, op.Status)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if next != "" && prev >= next {
+		return "", errRankExhausted
+	}
+	rank := rankBetween(prev, next)
+	if len(rank) > rankMaxLength {
+		return "", errRankExhausted
+	}
+	return rank, nil
+}
+
+// appendRank puts the todo at the end of its status column.
+func appendRank(repo repository.TodoRepository, todo *models.Todo) error {
+	last, err := repo.LastRank(todo.WorkspaceID, todo.Status)
+	if err != nil {
+		return err
+	}
+
+	rank := rankBetween(last, "")
+	if len(rank) > rankMaxLength {
+		if err := rebalanceColumn(repo, todo.WorkspaceID, todo.Status, todo.ID); err != nil {
+			return err
+		}
+		if last, err = repo.LastRank(todo.WorkspaceID, todo.Status); err != nil {
+			return err
+		}
+		rank = rankBetween(last, "")
+	}
+
+	todo.Rank = rank
+	return nil
+}
+
+// rebalanceColumn spreads the ranks of a column evenly, keeping the current
+// order. excludeID is left out so the todo being moved can be placed again.
+func rebalanceColumn(repo repository.TodoRepository, workspaceID uint, status models.Status, excludeID uint) error {
+	todos, err := repo.GetColumn(workspaceID, status)
+	if err != nil {
+		return err
+	}
+
+	column := todos[:0]
+	for _, todo := range todos {
+		if todo.ID != excludeID {
+			column = append(column, todo)
+		}
+	}
+
+	ranks := rankSequence(len(column))
+	for i, todo := range column {
+		if todo.Rank == ranks[i] {
+			continue
+		}
+		if err := repo.UpdateRank(todo.ID, ranks[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,244 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"task-management/internal/models"
+	"task-management/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type BulkAction string
+
+const (
+	BulkSetStatus   BulkAction = "set_status"
+	BulkSetPriority BulkAction = "set_priority"
+	BulkSetCategory BulkAction = "set_category"
+	BulkSetDueDate  BulkAction = "set_due_date"
+	BulkDelete      BulkAction = "delete"
+	BulkRestore     BulkAction = "restore"
+)
+
+// BulkOperation selects todos either by IDs or by the same filters GetTodos
+// accepts, and applies a single action to each of them.
+type BulkOperation struct {
+	IDs      []uint
+	Status   string
+	Category string
+	Action   BulkAction
+	// Value is the new status, priority, category or RFC3339 due date.
+	// A nil value clears the due date.
+	Value *string
+}
+
+type BulkResult struct {
+	ID      uint   `json:"id"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+type bulkEvent struct {
+	event models.EventType
+	todo  *models.Todo
+}
+
+// BulkUpdate applies the operation inside one transaction. Todos that do not
+// exist or are not in the workspace are reported per item; any database
+// error rolls back the whole batch.
+func (s *todoService) BulkUpdate(workspaceID, userID uint, op BulkOperation) ([]BulkResult, error) {
+	if err := s.authorize(workspaceID, userID, models.RoleMember); err != nil {
+		return nil, err
+	}
+
+	apply, err := s.bulkApplier(op, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	maxItems := s.config.Todo.BulkMaxItems
+	ids := op.IDs
+	if len(ids) == 0 {
+		if op.Status == "" && op.Category == "" {
+			return nil, errors.New("either ids or a filter is required")
+		}
+		ids, err = s.todoRepo.GetIDsByFilter(repository.WorkspaceScope(workspaceID, userID), op.Status, op.Category, op.Action == BulkRestore, maxItems+1)
+		if err != nil {
+			return nil, errors.New("database error")
+		}
+	}
+	if len(ids) > maxItems {
+		return nil, fmt.Errorf("bulk operations are limited to %d todos", maxItems)
+	}
+
+	var results []BulkResult
+	var events []bulkEvent
+	err = s.todoRepo.Transaction(func(repo repository.TodoRepository) error {
+		results = make([]BulkResult, 0, len(ids))
+		events = nil
+		seen := make(map[uint]bool, len(ids))
+
+		for _, id := range ids {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			event, todo, err := apply(repo, id, userID)
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					results = append(results, BulkResult{ID: id, Error: "todo not found"})
+					continue
+				}
+				if errors.Is(err, ErrTodoBlocked) || errors.Is(err, ErrInvalidTransition) {
+					results = append(results, BulkResult{ID: id, Error: err.Error()})
+					continue
+				}
+				if errors.Is(err, repository.ErrStaleVersion) {
+					results = append(results, BulkResult{ID: id, Error: "todo was modified concurrently"})
+					continue
+				}
+				return err
+			}
+
+			results = append(results, BulkResult{ID: id, Success: true})
+			events = append(events, bulkEvent{event: event, todo: todo})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.New("bulk operation failed")
+	}
+
+	for _, e := range events {
+		// A completion is an update as well, as for single todos.
+		if e.event == models.EventTodoCompleted {
+			s.publish(e.todo.WorkspaceID, userID, models.EventTodoUpdated, e.todo)
+		}
+		s.publish(e.todo.WorkspaceID, userID, e.event, e.todo)
+	}
+
+	return results, nil
+}
+
+type bulkApplyFunc func(repo repository.TodoRepository, id, userID uint) (models.EventType, *models.Todo, error)
+
+func (s *todoService) bulkApplier(op BulkOperation, workspaceID uint) (bulkApplyFunc, error) {
+	// Todos of the user's other workspaces are reported as not found.
+	load := func(todo *models.Todo, err error) (*models.Todo, error) {
+		if err == nil && todo.WorkspaceID != workspaceID {
+			return nil, gorm.ErrRecordNotFound
+		}
+		return todo, err
+	}
+
+	update := func(mutate func(todo *models.Todo) error) bulkApplyFunc {
+		return func(repo repository.TodoRepository, id, userID uint) (models.EventType, *models.Todo, error) {
+			todo, err := load(repo.GetByID(id, userID))
+			if err != nil {
+				return "", nil, err
+			}
+			previousStatus := todo.Status
+			before := todo.Snapshot()
+			if err := mutate(todo); err != nil {
+				return "", nil, err
+			}
+			if err := s.checkBlockers(repo, todo, previousStatus); err != nil {
+				return "", nil, err
+			}
+			if todo.Status != previousStatus {
+				if err := appendRank(repo, todo); err != nil {
+					return "", nil, err
+				}
+			}
+			if err := repo.Update(todo); err != nil {
+				return "", nil, err
+			}
+			if err := recordRevision(repo, todo, &before, userID, models.RevisionUpdated, nil); err != nil {
+				return "", nil, err
+			}
+			if todo.Status == models.StatusDone && previousStatus != models.StatusDone {
+				return models.EventTodoCompleted, todo, nil
+			}
+			return models.EventTodoUpdated, todo, nil
+		}
+	}
+
+	value := ""
+	if op.Value != nil {
+		value = *op.Value
+	}
+
+	switch op.Action {
+	case BulkSetStatus:
+		status := models.Status(value)
+		if !status.IsValid() {
+			return nil, errors.New("invalid status value")
+		}
+		return update(func(todo *models.Todo) error { return s.setStatus(todo, status) }), nil
+	case BulkSetPriority:
+		priority := models.Priority(value)
+		if !priority.IsValid() {
+			return nil, errors.New("invalid priority value")
+		}
+		return update(func(todo *models.Todo) error {
+			todo.Priority = priority
+			return nil
+		}), nil
+	case BulkSetCategory:
+		category := models.Category(value)
+		if !category.IsValid() {
+			return nil, errors.New("invalid category value")
+		}
+		return update(func(todo *models.Todo) error {
+			todo.Category = category
+			return nil
+		}), nil
+	case BulkSetDueDate:
+		var dueDate *time.Time
+		if value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, errors.New("invalid date format. Use RFC3339 (e.g., 2024-12-31T23:59:59Z)")
+			}
+			dueDate = &parsed
+		}
+		return update(func(todo *models.Todo) error {
+			todo.DueDate = dueDate
+			return nil
+		}), nil
+	case BulkDelete:
+		return func(repo repository.TodoRepository, id, userID uint) (models.EventType, *models.Todo, error) {
+			todo, err := load(repo.GetByID(id, userID))
+			if err != nil {
+				return "", nil, err
+			}
+			if err := repo.Delete(id, userID, todo.Version); err != nil {
+				return "", nil, err
+			}
+			if err := recordRevision(repo, todo, nil, userID, models.RevisionDeleted, nil); err != nil {
+				return "", nil, err
+			}
+			return models.EventTodoDeleted, todo, nil
+		}, nil
+	case BulkRestore:
+		return func(repo repository.TodoRepository, id, userID uint) (models.EventType, *models.Todo, error) {
+			todo, err := load(repo.GetDeletedByID(id, userID))
+			if err != nil {
+				return "", nil, err
+			}
+			if err := repo.Restore(id, userID); err != nil {
+				return "", nil, err
+			}
+			todo.DeletedAt = gorm.DeletedAt{}
+			if err := recordRevision(repo, todo, nil, userID, models.RevisionRestored, nil); err != nil {
+				return "", nil, err
+			}
+			return models.EventTodoRestored, todo, nil
+		}, nil
+	}
+
+	return nil, errors.New("unknown bulk action")
+}
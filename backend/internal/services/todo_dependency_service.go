@@ -0,0 +1,138 @@
+package services
+
+import (
+	"errors"
+
+	"task-management/internal/models"
+	"task-management/internal/repository"
+)
+
+// ErrTodoBlocked is returned when a todo with open blockers is moved to a
+// status that requires its blockers to be done first.
+var ErrTodoBlocked = errors.New("todo is blocked by open todos")
+
+var (
+	errDependencyCycle  = errors.New("dependency would create a cycle")
+	errDependencyExists = errors.New("dependency already exists")
+)
+
+func (s *todoService) GetBlockers(id, userID uint) ([]models.Todo, error) {
+	if _, err := s.GetTodoByID(id, userID); err != nil {
+		return nil, err
+	}
+
+	blockers, err := s.todoRepo.GetBlockers(id)
+	if err != nil {
+		return nil, errors.New("database error")
+	}
+	if err := s.markBlocked(blockers); err != nil {
+		return nil, err
+	}
+	return blockers, nil
+}
+
+// AddBlocker makes blockerID a prerequisite of id. Both todos must be in the
+// same workspace, and the new edge must not close a cycle.
+func (s *todoService) AddBlocker(id, blockerID, userID uint) (*models.TodoDependency, error) {
+	if id == blockerID {
+		return nil, errors.New("a todo cannot block itself")
+	}
+	todo, err := s.GetEditableTodo(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	blocker, err := s.GetTodoByID(blockerID, userID)
+	if err != nil {
+		if err.Error() == "todo not found" {
+			return nil, errors.New("blocker not found")
+		}
+		return nil, err
+	}
+	if blocker.WorkspaceID != todo.WorkspaceID {
+		return nil, errors.New("blocker not found")
+	}
+
+	dependency := &models.TodoDependency{TodoID: id, BlockerID: blockerID}
+	err = s.todoRepo.Transaction(func(repo repository.TodoRepository) error {
+		cycle, err := repo.DependsOn(blockerID, id)
+		if err != nil {
+			return err
+		}
+		if cycle {
+			return errDependencyCycle
+		}
+		created, err := repo.AddDependency(dependency)
+		if err != nil {
+			return err
+		}
+		if created == 0 {
+			return errDependencyExists
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, errDependencyCycle) || errors.Is(err, errDependencyExists) {
+			return nil, err
+		}
+		return nil, errors.New("failed to add dependency")
+	}
+
+	return dependency, nil
+}
+
+func (s *todoService) RemoveBlocker(id, blockerID, userID uint) error {
+	if _, err := s.GetEditableTodo(id, userID); err != nil {
+		return err
+	}
+
+	removed, err := s.todoRepo.RemoveDependency(id, blockerID)
+	if err != nil {
+		return errors.New("failed to remove dependency")
+	}
+	if removed == 0 {
+		return errors.New("dependency not found")
+	}
+	return nil
+}
+
+// checkBlockers returns ErrTodoBlocked when the todo would move into a
+// status that its open blockers do not allow yet.
+func (s *todoService) checkBlockers(repo repository.TodoRepository, todo *models.Todo, previousStatus models.Status) error {
+	if todo.Status == previousStatus {
+		return nil
+	}
+	if todo.Status != models.StatusDone && !(todo.Status == models.StatusInProgress && s.config.Todo.BlockInProgress) {
+		return nil
+	}
+
+	open, err := repo.CountOpenBlockers(todo.ID)
+	if err != nil {
+		return err
+	}
+	if open > 0 {
+		return ErrTodoBlocked
+	}
+	return nil
+}
+
+// markBlocked fills the computed Blocked flag of the todos.
+func (s *todoService) markBlocked(todos []models.Todo) error {
+	ids := make([]uint, len(todos))
+	for i := range todos {
+		ids[i] = todos[i].ID
+	}
+
+	blocked, err := s.todoRepo.GetBlockedIDs(ids)
+	if err != nil {
+		return errors.New("database error")
+	}
+
+	set := make(map[uint]bool, len(blocked))
+	for _, id := range blocked {
+		set[id] = true
+	}
+	for i := range todos {
+		todos[i].Blocked = set[todos[i].ID]
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+package services
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"task-management/internal/models"
+	"task-management/internal/repository"
+)
+
+type ExportFormat string
+
+const (
+	ExportJSON ExportFormat = "json"
+	ExportCSV  ExportFormat = "csv"
+)
+
+const exportBatchSize = 200
+
+// ExportedTodo is the portable representation of a todo used by exports and
+// understood by the importer.
+type ExportedTodo struct {
+	ID          uint              `json:"id"`
+	Title       string            `json:"title"`
+	Description string            `json:"description"`
+	Status      models.Status     `json:"status"`
+	Priority    models.Priority   `json:"priority"`
+	Category    models.Category   `json:"category"`
+	CategoryID  *uint             `json:"category_id"`
+	DueDate     *time.Time        `json:"due_date"`
+	Estimate    *int              `json:"estimate_minutes"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+	Subtasks    []ExportedSubtask `json:"subtasks"`
+}
+
+type ExportedSubtask struct {
+	Title       string     `json:"title"`
+	IsCompleted bool       `json:"is_completed"`
+	CompletedAt *time.Time `json:"completed_at"`
+}
+
+// ExportCSVHeader is the column layout of CSV exports.
+var ExportCSVHeader = []string{
+	"id", "title", "description", "status", "priority", "category",
+	"category_id", "due_date", "created_at", "updated_at", "subtasks",
+	"estimate_minutes",
+}
+
+func (f ExportFormat) IsValid() bool {
+	return f == ExportJSON || f == ExportCSV
+}
+
+func (f ExportFormat) ContentType() string {
+	if f == ExportCSV {
+		return "text/csv; charset=utf-8"
+	}
+	return "application/json; charset=utf-8"
+}
+
+func NewExportedTodo(todo *models.Todo) ExportedTodo {
+	exported := ExportedTodo{
+		ID:          todo.ID,
+		Title:       todo.Title,
+		Description: todo.Description,
+		Status:      todo.Status,
+		Priority:    todo.Priority,
+		Category:    todo.Category,
+		CategoryID:  todo.CategoryID,
+		DueDate:     todo.DueDate,
+		Estimate:    todo.Estimate,
+		CreatedAt:   todo.CreatedAt,
+		UpdatedAt:   todo.UpdatedAt,
+		Subtasks:    make([]ExportedSubtask, 0, len(todo.Subtasks)),
+	}
+	for _, subtask := range todo.Subtasks {
+		exported.Subtasks = append(exported.Subtasks, ExportedSubtask{
+			Title:       subtask.Title,
+			IsCompleted: subtask.IsCompleted == models.CompletionYes,
+			CompletedAt: subtask.CompletedAt,
+		})
+	}
+	return exported
+}
+
+// ExportTodos writes every todo of the workspace that matches the filters to
+// w, batch by batch. If w can be flushed it is flushed after every batch so
+// the response starts streaming immediately.
+func (s *todoService) ExportTodos(workspaceID, userID uint, status, category string, format ExportFormat, w io.Writer) error {
+	scope := repository.WorkspaceScope(workspaceID, userID)
+	switch format {
+	case ExportJSON:
+		return s.exportJSON(scope, status, category, w)
+	case ExportCSV:
+		return s.exportCSV(scope, status, category, w)
+	}
+	return errors.New("unsupported export format")
+}
+
+func (s *todoService) exportJSON(scope repository.TodoScope, status, category string, w io.Writer) error {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+
+	first := true
+	err := s.todoRepo.FindInBatches(scope, status, category, exportBatchSize, func(todos []models.Todo) error {
+		for i := range todos {
+			data, err := json.Marshal(NewExportedTodo(&todos[i]))
+			if err != nil {
+				return err
+			}
+			if !first {
+				if _, err := io.WriteString(w, ","); err != nil {
+					return err
+				}
+			}
+			first = false
+			if _, err := w.Write(data); err != nil {
+				return err
+			}
+		}
+		flush(w)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "]")
+	return err
+}
+
+func (s *todoService) exportCSV(scope repository.TodoScope, status, category string, w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(ExportCSVHeader); err != nil {
+		return err
+	}
+
+	err := s.todoRepo.FindInBatches(scope, status, category, exportBatchSize, func(todos []models.Todo) error {
+		for i := range todos {
+			if err := cw.Write(csvRecord(NewExportedTodo(&todos[i]))); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		flush(w)
+		return cw.Error()
+	})
+	if err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func csvRecord(todo ExportedTodo) []string {
+	categoryID := ""
+	if todo.CategoryID != nil {
+		categoryID = strconv.FormatUint(uint64(*todo.CategoryID), 10)
+	}
+	estimate := ""
+	if todo.Estimate != nil {
+		estimate = strconv.Itoa(*todo.Estimate)
+	}
+
+	// One subtask per line, prefixed with a markdown style checkbox.
+	subtasks := make([]string, 0, len(todo.Subtasks))
+	for _, subtask := range todo.Subtasks {
+		box := "[ ] "
+		if subtask.IsCompleted {
+			box = "[x] "
+		}
+		subtasks = append(subtasks, box+subtask.Title)
+	}
+
+	record := []string{
+		strconv.FormatUint(uint64(todo.ID), 10),
+		todo.Title,
+		todo.Description,
+		string(todo.Status),
+		string(todo.Priority),
+		string(todo.Category),
+		categoryID,
+		formatOptionalTime(todo.DueDate),
+		todo.CreatedAt.UTC().Format(time.RFC3339),
+		todo.UpdatedAt.UTC().Format(time.RFC3339),
+		strings.Join(subtasks, "\n"),
+		estimate,
+	}
+	for i := range record {
+		record[i] = escapeCSVFormula(record[i])
+	}
+	return record
+}
+
+// escapeCSVFormula prevents spreadsheet applications from evaluating cells
+// that start with a formula trigger character (CSV injection).
+func escapeCSVFormula(value string) string {
+	if value == "" {
+		return value
+	}
+	switch value[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + value
+	}
+	return value
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func flush(w io.Writer) {
+	if f, ok := w.(interface{ Flush() }); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,386 @@
+package services
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"task-management/internal/models"
+)
+
+// importDateLayouts are tried in order when a source stores dates as text.
+var importDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"01/02/2006",
+}
+
+var todoistLabelPattern = regexp.MustCompile(`(?:^|\s)@([\w\-]+)`)
+
+func parseImportDate(value string) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range importDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("unrecognised date %q", value)
+}
+
+// parseExportJSON reads the array produced by GET /todos/export?format=json.
+func parseExportJSON(r io.Reader) ([]ImportRow, error) {
+	var exported []ExportedTodo
+	if err := json.NewDecoder(r).Decode(&exported); err != nil {
+		return nil, errors.New("invalid JSON export: " + err.Error())
+	}
+
+	rows := make([]ImportRow, 0, len(exported))
+	for i, todo := range exported {
+		rows = append(rows, ImportRow{
+			Row: i + 1,
+			Todo: ImportedTodo{
+				Title:       todo.Title,
+				Description: todo.Description,
+				Status:      todo.Status,
+				Priority:    todo.Priority,
+				Category:    todo.Category,
+				DueDate:     todo.DueDate,
+				Estimate:    todo.Estimate,
+				Subtasks:    todo.Subtasks,
+			},
+		})
+	}
+	return rows, nil
+}
+
+// parseExportCSV reads the CSV produced by GET /todos/export?format=csv.
+func parseExportCSV(r io.Reader) ([]ImportRow, error) {
+	records, columns, err := readCSV(r)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, errors.New("CSV is missing the title column")
+	}
+
+	rows := make([]ImportRow, 0, len(records))
+	for i, record := range records {
+		get := func(name string) string {
+			if idx, ok := columns[name]; ok && idx < len(record) {
+				return unescapeCSVFormula(record[idx])
+			}
+			return ""
+		}
+
+		row := ImportRow{
+			Row: i + 2, // header is line 1
+			Todo: ImportedTodo{
+				Title:       get("title"),
+				Description: get("description"),
+				Status:      models.Status(get("status")),
+				Priority:    models.Priority(get("priority")),
+				Category:    models.Category(get("category")),
+				Subtasks:    parseChecklistLines(get("subtasks")),
+			},
+		}
+
+		dueDate, err := parseImportDate(get("due_date"))
+		if err != nil {
+			row.Errors = append(row.Errors, err.Error())
+		}
+		row.Todo.DueDate = dueDate
+
+		if value := strings.TrimSpace(get("estimate_minutes")); value != "" {
+			estimate, err := strconv.Atoi(value)
+			if err != nil {
+				row.Errors = append(row.Errors, fmt.Sprintf("invalid estimate_minutes %q", value))
+			} else {
+				row.Todo.Estimate = &estimate
+			}
+		}
+
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// parseTodoistCSV reads a Todoist project export. Tasks with an INDENT
+// greater than one become subtasks of the preceding top level task, and
+// @labels in the content are mapped onto categories.
+func parseTodoistCSV(r io.Reader) ([]ImportRow, error) {
+	records, columns, err := readCSV(r)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range []string{"type", "content"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("Todoist CSV is missing the %s column", strings.ToUpper(name))
+		}
+	}
+
+	var rows []ImportRow
+	for i, record := range records {
+		get := func(name string) string {
+			if idx, ok := columns[name]; ok && idx < len(record) {
+				return strings.TrimSpace(record[idx])
+			}
+			return ""
+		}
+
+		if !strings.EqualFold(get("type"), "task") {
+			continue
+		}
+
+		labels := todoistLabelPattern.FindAllStringSubmatch(get("content"), -1)
+		title := strings.TrimSpace(todoistLabelPattern.ReplaceAllString(get("content"), " "))
+		title = strings.Join(strings.Fields(title), " ")
+
+		indent, _ := strconv.Atoi(get("indent"))
+		if indent > 1 && len(rows) > 0 {
+			parent := &rows[len(rows)-1].Todo
+			parent.Subtasks = append(parent.Subtasks, ExportedSubtask{Title: title})
+			continue
+		}
+
+		row := ImportRow{
+			Row: i + 2,
+			Todo: ImportedTodo{
+				Title:       title,
+				Description: get("description"),
+				Priority:    todoistPriority(get("priority")),
+			},
+		}
+		for _, label := range labels {
+			if category := models.Category(strings.ToLower(label[1])); category.IsValid() {
+				row.Todo.Category = category
+				break
+			}
+		}
+
+		dueDate, err := parseImportDate(get("date"))
+		if err != nil {
+			row.Errors = append(row.Errors, err.Error())
+		}
+		row.Todo.DueDate = dueDate
+
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// todoistPriority maps Todoist's p1 (most urgent) to p4 (default) scale.
+func todoistPriority(value string) models.Priority {
+	switch value {
+	case "1":
+		return models.PriorityHigh
+	case "2":
+		return models.PriorityMedium
+	case "3":
+		return models.PriorityLow
+	}
+	return models.PriorityMedium
+}
+
+type trelloBoard struct {
+	Lists []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"lists"`
+	Labels []struct {
+		ID    string `json:"id"`
+		Name  string `json:"name"`
+		Color string `json:"color"`
+	} `json:"labels"`
+	Checklists []struct {
+		ID         string  `json:"id"`
+		IDCard     string  `json:"idCard"`
+		Pos        float64 `json:"pos"`
+		CheckItems []struct {
+			Name  string  `json:"name"`
+			State string  `json:"state"`
+			Pos   float64 `json:"pos"`
+		} `json:"checkItems"`
+	} `json:"checklists"`
+	Cards []struct {
+		ID          string   `json:"id"`
+		Name        string   `json:"name"`
+		Desc        string   `json:"desc"`
+		Closed      bool     `json:"closed"`
+		Due         string   `json:"due"`
+		DueComplete bool     `json:"dueComplete"`
+		IDList      string   `json:"idList"`
+		IDLabels    []string `json:"idLabels"`
+	} `json:"cards"`
+}
+
+// parseTrelloJSON reads a Trello board export. Archived cards are skipped,
+// the list a card is in decides its status, labels map onto priority and
+// category, and checklists become subtasks.
+func parseTrelloJSON(r io.Reader) ([]ImportRow, error) {
+	var board trelloBoard
+	if err := json.NewDecoder(r).Decode(&board); err != nil {
+		return nil, errors.New("invalid Trello board JSON: " + err.Error())
+	}
+
+	listNames := make(map[string]string, len(board.Lists))
+	for _, list := range board.Lists {
+		listNames[list.ID] = list.Name
+	}
+
+	type label struct{ name, color string }
+	labels := make(map[string]label, len(board.Labels))
+	for _, l := range board.Labels {
+		labels[l.ID] = label{name: strings.ToLower(strings.TrimSpace(l.Name)), color: l.Color}
+	}
+
+	checklists := board.Checklists
+	sort.SliceStable(checklists, func(i, j int) bool { return checklists[i].Pos < checklists[j].Pos })
+	subtasks := make(map[string][]ExportedSubtask)
+	for _, checklist := range checklists {
+		items := checklist.CheckItems
+		sort.SliceStable(items, func(i, j int) bool { return items[i].Pos < items[j].Pos })
+		for _, item := range items {
+			subtasks[checklist.IDCard] = append(subtasks[checklist.IDCard], ExportedSubtask{
+				Title:       item.Name,
+				IsCompleted: item.State == "complete",
+			})
+		}
+	}
+
+	var rows []ImportRow
+	for i, card := range board.Cards {
+		if card.Closed {
+			continue
+		}
+
+		row := ImportRow{
+			Row: i + 1,
+			Todo: ImportedTodo{
+				Title:       card.Name,
+				Description: card.Desc,
+				Status:      trelloListStatus(listNames[card.IDList]),
+				Subtasks:    subtasks[card.ID],
+			},
+		}
+		if card.DueComplete {
+			row.Todo.Status = models.StatusDone
+		}
+
+		for _, id := range card.IDLabels {
+			l := labels[id]
+			if priority := trelloLabelPriority(l.name, l.color); priority != "" && row.Todo.Priority == "" {
+				row.Todo.Priority = priority
+			}
+			if category := models.Category(l.name); category.IsValid() && row.Todo.Category == "" {
+				row.Todo.Category = category
+			}
+		}
+
+		dueDate, err := parseImportDate(card.Due)
+		if err != nil {
+			row.Errors = append(row.Errors, err.Error())
+		}
+		row.Todo.DueDate = dueDate
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func trelloListStatus(name string) models.Status {
+	name = strings.ToLower(name)
+	switch {
+	case strings.Contains(name, "done"), strings.Contains(name, "complete"), strings.Contains(name, "selesai"):
+		return models.StatusDone
+	case strings.Contains(name, "doing"), strings.Contains(name, "progress"), strings.Contains(name, "dikerjakan"):
+		return models.StatusInProgress
+	}
+	return models.StatusTodo
+}
+
+func trelloLabelPriority(name, color string) models.Priority {
+	switch name {
+	case "high", "urgent", "critical", "tinggi":
+		return models.PriorityHigh
+	case "medium", "normal", "sedang":
+		return models.PriorityMedium
+	case "low", "rendah":
+		return models.PriorityLow
+	}
+	if name == "" && color == "red" {
+		return models.PriorityHigh
+	}
+	return ""
+}
+
+// parseChecklistLines reads the "[x] title" lines written by the CSV export.
+func parseChecklistLines(value string) []ExportedSubtask {
+	var subtasks []ExportedSubtask
+	for _, line := range strings.Split(value, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		subtask := ExportedSubtask{Title: line}
+		switch {
+		case strings.HasPrefix(line, "[x] "), strings.HasPrefix(line, "[X] "):
+			subtask.Title = strings.TrimSpace(line[4:])
+			subtask.IsCompleted = true
+		case strings.HasPrefix(line, "[ ] "):
+			subtask.Title = strings.TrimSpace(line[4:])
+		}
+		subtasks = append(subtasks, subtask)
+	}
+	return subtasks
+}
+
+// readCSV returns the data records and a lower-cased column name index built
+// from the header row.
+func readCSV(r io.Reader) ([][]string, map[string]int, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, errors.New("invalid CSV: missing header row")
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.TrimPrefix(name, "\ufeff")
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, errors.New("invalid CSV: " + err.Error())
+	}
+	return records, columns, nil
+}
+
+// unescapeCSVFormula reverses escapeCSVFormula.
+func unescapeCSVFormula(value string) string {
+	if len(value) >= 2 && value[0] == '\'' {
+		switch value[1] {
+		case '=', '+', '-', '@', '\t', '\r':
+			return value[1:]
+		}
+	}
+	return value
+}
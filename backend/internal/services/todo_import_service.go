@@ -0,0 +1,215 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"task-management/internal/models"
+	"task-management/internal/repository"
+)
+
+type ImportFormat string
+
+const (
+	ImportJSON    ImportFormat = "json"
+	ImportCSV     ImportFormat = "csv"
+	ImportTodoist ImportFormat = "todoist"
+	ImportTrello  ImportFormat = "trello"
+)
+
+const importTitleMaxLength = 255
+
+func (f ImportFormat) IsValid() bool {
+	switch f {
+	case ImportJSON, ImportCSV, ImportTodoist, ImportTrello:
+		return true
+	}
+	return false
+}
+
+// ImportedTodo is a todo recognised in an import file, already mapped onto
+// our priorities, categories and statuses.
+type ImportedTodo struct {
+	Title       string            `json:"title"`
+	Description string            `json:"description"`
+	Status      models.Status     `json:"status"`
+	Priority    models.Priority   `json:"priority"`
+	Category    models.Category   `json:"category"`
+	DueDate     *time.Time        `json:"due_date"`
+	Estimate    *int              `json:"estimate_minutes"`
+	Subtasks    []ExportedSubtask `json:"subtasks"`
+}
+
+// ImportRow is the preview of a single source record. Row is the 1-based
+// position in the source (CSV line or JSON element).
+type ImportRow struct {
+	Row    int          `json:"row"`
+	Todo   ImportedTodo `json:"todo"`
+	Errors []string     `json:"errors,omitempty"`
+}
+
+type ImportResult struct {
+	DryRun   bool        `json:"dry_run"`
+	Total    int         `json:"total"`
+	Valid    int         `json:"valid"`
+	Invalid  int         `json:"invalid"`
+	Imported int         `json:"imported"`
+	Rows     []ImportRow `json:"rows"`
+}
+
+// ErrImportInvalid is returned when a non dry-run import contains rows that
+// failed validation. Nothing is imported in that case.
+var ErrImportInvalid = errors.New("import contains invalid rows")
+
+// ImportTodos parses the file and validates every row. With dryRun the
+// result is only a preview; otherwise all rows are created in a single
+// transaction, and only if every row is valid.
+func (s *todoService) ImportTodos(workspaceID, userID uint, format ImportFormat, r io.Reader, dryRun bool) (*ImportResult, error) {
+	if err := s.authorize(workspaceID, userID, models.RoleMember); err != nil {
+		return nil, err
+	}
+
+	var rows []ImportRow
+	var err error
+
+	switch format {
+	case ImportJSON:
+		rows, err = parseExportJSON(r)
+	case ImportCSV:
+		rows, err = parseExportCSV(r)
+	case ImportTodoist:
+		rows, err = parseTodoistCSV(r)
+	case ImportTrello:
+		rows, err = parseTrelloJSON(r)
+	default:
+		return nil, errors.New("unsupported import format")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.New("import file contains no todos")
+	}
+	if max := s.config.Todo.ImportMaxRows; len(rows) > max {
+		return nil, fmt.Errorf("imports are limited to %d todos", max)
+	}
+
+	result := &ImportResult{DryRun: dryRun, Total: len(rows), Rows: rows}
+	for i := range result.Rows {
+		validateImportedTodo(&result.Rows[i])
+		if len(result.Rows[i].Errors) == 0 {
+			result.Valid++
+		} else {
+			result.Invalid++
+		}
+	}
+
+	if dryRun {
+		return result, nil
+	}
+	if result.Invalid > 0 {
+		return result, ErrImportInvalid
+	}
+
+	created := make([]*models.Todo, 0, len(rows))
+	err = s.todoRepo.Transaction(func(repo repository.TodoRepository) error {
+		created = created[:0]
+		for _, row := range result.Rows {
+			todo := row.Todo.toModel(workspaceID, userID)
+			if err := appendRank(repo, todo); err != nil {
+				return err
+			}
+			if err := repo.Create(todo); err != nil {
+				return err
+			}
+			if err := recordRevision(repo, todo, nil, userID, models.RevisionCreated, nil); err != nil {
+				return err
+			}
+			created = append(created, todo)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.New("failed to import todos")
+	}
+
+	for _, todo := range created {
+		s.publish(todo.WorkspaceID, userID, models.EventTodoCreated, todo)
+	}
+
+	result.Imported = len(created)
+	return result, nil
+}
+
+func (t ImportedTodo) toModel(workspaceID, userID uint) *models.Todo {
+	todo := &models.Todo{
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+		Title:       t.Title,
+		Description: t.Description,
+		Status:      t.Status,
+		Priority:    t.Priority,
+		Category:    t.Category,
+		DueDate:     t.DueDate,
+		Estimate:    t.Estimate,
+	}
+	todo.StampStatus(models.StatusTodo, time.Now())
+
+	for _, st := range t.Subtasks {
+		subtask := models.Subtask{Title: st.Title, IsCompleted: models.CompletionNo}
+		if st.IsCompleted {
+			subtask.IsCompleted = models.CompletionYes
+			subtask.CompletedAt = st.CompletedAt
+			if subtask.CompletedAt == nil {
+				now := time.Now()
+				subtask.CompletedAt = &now
+			}
+		}
+		todo.Subtasks = append(todo.Subtasks, subtask)
+	}
+	return todo
+}
+
+// validateImportedTodo fills in defaults and appends a message for every
+// problem found in the row.
+func validateImportedTodo(row *ImportRow) {
+	todo := &row.Todo
+	todo.Title = strings.TrimSpace(todo.Title)
+
+	if todo.Status == "" {
+		todo.Status = models.StatusTodo
+	}
+	if todo.Priority == "" {
+		todo.Priority = models.PriorityMedium
+	}
+	if todo.Category == "" {
+		todo.Category = models.CategoryPersonal
+	}
+
+	if todo.Title == "" {
+		row.Errors = append(row.Errors, "title is required")
+	} else if len([]rune(todo.Title)) > importTitleMaxLength {
+		row.Errors = append(row.Errors, fmt.Sprintf("title must be at most %d characters", importTitleMaxLength))
+	}
+	if !todo.Status.IsValid() {
+		row.Errors = append(row.Errors, fmt.Sprintf("invalid status %q", todo.Status))
+	}
+	if !todo.Priority.IsValid() {
+		row.Errors = append(row.Errors, fmt.Sprintf("invalid priority %q", todo.Priority))
+	}
+	if !todo.Category.IsValid() {
+		row.Errors = append(row.Errors, fmt.Sprintf("invalid category %q", todo.Category))
+	}
+	if todo.Estimate != nil && (*todo.Estimate < 0 || *todo.Estimate > maxEstimateMinutes) {
+		row.Errors = append(row.Errors, fmt.Sprintf("invalid estimate_minutes %d", *todo.Estimate))
+	}
+	for i, subtask := range todo.Subtasks {
+		if strings.TrimSpace(subtask.Title) == "" {
+			row.Errors = append(row.Errors, fmt.Sprintf("subtask %d has no title", i+1))
+		}
+	}
+}
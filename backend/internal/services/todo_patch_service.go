@@ -0,0 +1,343 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+
+	"task-management/internal/models"
+)
+
+type PatchFormat string
+
+const (
+	// PatchMerge is a JSON Merge Patch (RFC 7396).
+	PatchMerge PatchFormat = "application/merge-patch+json"
+	// PatchJSON is a JSON Patch (RFC 6902).
+	PatchJSON PatchFormat = "application/json-patch+json"
+)
+
+func (f PatchFormat) IsValid() bool {
+	return f == PatchMerge || f == PatchJSON
+}
+
+var (
+	// ErrInvalidPatch is returned when the patch document itself is
+	// malformed or refers to fields that do not exist.
+	ErrInvalidPatch = errors.New("invalid patch")
+	// ErrPatchTestFailed is returned when a JSON Patch "test" operation
+	// does not match the current todo.
+	ErrPatchTestFailed = errors.New("patch test failed")
+)
+
+// patchableFields are the members of the document a patch is applied to.
+var patchableFields = []string{"title", "description", "priority", "category", "status", "due_date", "estimate_minutes"}
+
+type jsonPatchOperation struct {
+	Op    string
+	Path  string
+	From  string
+	Value json.RawMessage
+	// HasValue tells a missing value from an explicit null, which add,
+	// replace and test accept.
+	HasValue bool
+}
+
+func (o *jsonPatchOperation) UnmarshalJSON(data []byte) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+	for key, field := range map[string]*string{"op": &o.Op, "path": &o.Path, "from": &o.From} {
+		if raw, ok := members[key]; ok {
+			if err := json.Unmarshal(raw, field); err != nil {
+				return err
+			}
+		}
+	}
+	o.Value, o.HasValue = members["value"]
+	return nil
+}
+
+// PatchTodo applies a merge patch or JSON patch to the editable fields of the
+// todo. A field set to null (or removed) is cleared; fields that cannot be
+// empty are rejected instead. Every resulting field is validated before
+// anything is saved.
+func (s *todoService) PatchTodo(id, userID uint, format PatchFormat, patch []byte, ifMatch VersionMatch) (*models.Todo, error) {
+	todo, err := s.GetEditableTodo(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !ifMatch.Allows(todo.Version) {
+		return nil, &ConflictError{Resource: "todo", Version: todo.Version, Current: todo}
+	}
+	previousStatus := todo.Status
+	before := todo.Snapshot()
+
+	doc := todoPatchDocument(todo)
+	switch format {
+	case PatchMerge:
+		err = applyMergePatch(doc, patch)
+	case PatchJSON:
+		err = applyJSONPatch(doc, patch)
+	default:
+		return nil, errors.New("unsupported patch format")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyPatchDocument(todo, doc); err != nil {
+		return nil, err
+	}
+
+	if err := s.saveUpdate(todo, before, previousStatus, userID); err != nil {
+		return nil, err
+	}
+	return todo, nil
+}
+
+// todoPatchDocument returns the patchable fields as decoded JSON values, so
+// they compare equal to the values of a decoded patch.
+func todoPatchDocument(todo *models.Todo) map[string]interface{} {
+	doc := map[string]interface{}{
+		"title":            todo.Title,
+		"description":      todo.Description,
+		"priority":         string(todo.Priority),
+		"category":         string(todo.Category),
+		"status":           string(todo.Status),
+		"due_date":         nil,
+		"estimate_minutes": nil,
+	}
+	if todo.DueDate != nil {
+		doc["due_date"] = todo.DueDate.UTC().Format(time.RFC3339Nano)
+	}
+	if todo.Estimate != nil {
+		doc["estimate_minutes"] = float64(*todo.Estimate)
+	}
+	return doc
+}
+
+// applyMergePatch follows RFC 7396. The todo document is flat, so a member
+// is either replaced or, when null, removed.
+func applyMergePatch(doc map[string]interface{}, patch []byte) error {
+	var members map[string]interface{}
+	if err := decodePatch(patch, &members); err != nil || members == nil {
+		return fmt.Errorf("%w: a merge patch must be a JSON object", ErrInvalidPatch)
+	}
+
+	for key, value := range members {
+		if !isPatchableField(key) {
+			return fmt.Errorf("%w: unknown field %q", ErrInvalidPatch, key)
+		}
+		if value == nil {
+			delete(doc, key)
+			continue
+		}
+		doc[key] = value
+	}
+	return nil
+}
+
+// applyJSONPatch follows RFC 6902. Operations are applied in order and the
+// first failure aborts the whole patch.
+func applyJSONPatch(doc map[string]interface{}, patch []byte) error {
+	var operations []jsonPatchOperation
+	if err := decodePatch(patch, &operations); err != nil {
+		return fmt.Errorf("%w: a JSON patch must be an array of operations", ErrInvalidPatch)
+	}
+
+	for i, op := range operations {
+		if err := applyJSONPatchOperation(doc, op); err != nil {
+			return fmt.Errorf("operation %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func applyJSONPatchOperation(doc map[string]interface{}, op jsonPatchOperation) error {
+	key, err := patchPointer(op.Path)
+	if err != nil {
+		return err
+	}
+
+	value := func() (interface{}, error) {
+		if !op.HasValue {
+			return nil, fmt.Errorf("%w: %s requires a value", ErrInvalidPatch, op.Op)
+		}
+		var v interface{}
+		if err := json.Unmarshal(op.Value, &v); err != nil {
+			return nil, fmt.Errorf("%w: invalid value", ErrInvalidPatch)
+		}
+		return v, nil
+	}
+
+	switch op.Op {
+	case "add", "replace":
+		v, err := value()
+		if err != nil {
+			return err
+		}
+		if _, exists := doc[key]; op.Op == "replace" && !exists {
+			return fmt.Errorf("%w: %s does not exist", ErrInvalidPatch, op.Path)
+		}
+		doc[key] = v
+	case "remove":
+		if _, exists := doc[key]; !exists {
+			return fmt.Errorf("%w: %s does not exist", ErrInvalidPatch, op.Path)
+		}
+		delete(doc, key)
+	case "move", "copy":
+		from, err := patchPointer(op.From)
+		if err != nil {
+			return err
+		}
+		v, exists := doc[from]
+		if !exists {
+			return fmt.Errorf("%w: %s does not exist", ErrInvalidPatch, op.From)
+		}
+		if op.Op == "move" {
+			delete(doc, from)
+		}
+		doc[key] = v
+	case "test":
+		v, err := value()
+		if err != nil {
+			return err
+		}
+		if !reflect.DeepEqual(doc[key], v) {
+			return fmt.Errorf("%w: %s does not match", ErrPatchTestFailed, op.Path)
+		}
+	default:
+		return fmt.Errorf("%w: unknown op %q", ErrInvalidPatch, op.Op)
+	}
+	return nil
+}
+
+// patchPointer resolves a JSON Pointer (RFC 6901) to a top level field.
+func patchPointer(pointer string) (string, error) {
+	if !strings.HasPrefix(pointer, "/") || strings.Count(pointer, "/") != 1 {
+		return "", fmt.Errorf("%w: unsupported path %q", ErrInvalidPatch, pointer)
+	}
+
+	key := strings.NewReplacer("~1", "/", "~0", "~").Replace(pointer[1:])
+	if !isPatchableField(key) {
+		return "", fmt.Errorf("%w: unknown field %q", ErrInvalidPatch, key)
+	}
+	return key, nil
+}
+
+// applyPatchDocument validates the patched document and copies it onto the
+// todo. Missing members are treated as null.
+func (s *todoService) applyPatchDocument(todo *models.Todo, doc map[string]interface{}) error {
+	text := func(field string) (*string, error) {
+		value, ok := doc[field]
+		if !ok || value == nil {
+			return nil, nil
+		}
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s must be a string", ErrInvalidValue, field)
+		}
+		return &str, nil
+	}
+	required := func(field string) (string, error) {
+		str, err := text(field)
+		if err != nil {
+			return "", err
+		}
+		if str == nil || strings.TrimSpace(*str) == "" {
+			return "", fmt.Errorf("%w: %s cannot be empty", ErrInvalidValue, field)
+		}
+		return *str, nil
+	}
+
+	title, err := required("title")
+	if err != nil {
+		return err
+	}
+	description, err := text("description")
+	if err != nil {
+		return err
+	}
+	priority, err := required("priority")
+	if err != nil {
+		return err
+	}
+	if err := validatePriority(models.Priority(priority)); err != nil {
+		return err
+	}
+	category, err := required("category")
+	if err != nil {
+		return err
+	}
+	if err := validateCategory(models.Category(category)); err != nil {
+		return err
+	}
+	status, err := required("status")
+	if err != nil {
+		return err
+	}
+	dueDate, err := text("due_date")
+	if err != nil {
+		return err
+	}
+
+	var due *time.Time
+	if dueDate != nil {
+		parsed, err := time.Parse(time.RFC3339, *dueDate)
+		if err != nil {
+			return fmt.Errorf("%w: due_date must be an RFC3339 date (e.g., 2024-12-31T23:59:59Z)", ErrInvalidValue)
+		}
+		due = &parsed
+	}
+
+	var estimate *int
+	if value, ok := doc["estimate_minutes"]; ok && value != nil {
+		minutes, ok := value.(float64)
+		if !ok || minutes != math.Trunc(minutes) || minutes < 0 || minutes > maxEstimateMinutes {
+			return fmt.Errorf("%w: estimate_minutes must be a whole number between 0 and %d", ErrInvalidValue, maxEstimateMinutes)
+		}
+		m := int(minutes)
+		estimate = &m
+	}
+
+	if err := s.setStatus(todo, models.Status(status)); err != nil {
+		return err
+	}
+	todo.Title = title
+	todo.Description = ""
+	if description != nil {
+		todo.Description = *description
+	}
+	todo.Priority = models.Priority(priority)
+	todo.Category = models.Category(category)
+	todo.DueDate = due
+	todo.Estimate = estimate
+	return nil
+}
+
+func isPatchableField(field string) bool {
+	for _, f := range patchableFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+func decodePatch(patch []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(patch))
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	if decoder.More() {
+		return errors.New("trailing data after patch")
+	}
+	return nil
+}
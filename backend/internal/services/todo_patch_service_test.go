@@ -0,0 +1,137 @@
+package services
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"task-management/internal/models"
+)
+
+func patchTestTodo() *models.Todo {
+	due := time.Date(2024, 12, 31, 23, 59, 0, 0, time.UTC)
+	estimate := 30
+	return &models.Todo{
+		Title:    "Write report",
+		Priority: models.PriorityHigh,
+		Category: models.CategoryWork,
+		Status:   models.StatusTodo,
+		DueDate:  &due,
+		Estimate: &estimate,
+	}
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		todo    func(todo *models.Todo)
+		patch   string
+		set     map[string]interface{}
+		removed []string
+		wantErr error
+	}{
+		{
+			name:  "replace with null clears the field",
+			patch: `[{"op":"replace","path":"/due_date","value":null}]`,
+			set:   map[string]interface{}{"due_date": nil},
+		},
+		{
+			name:  "add with null clears the field",
+			patch: `[{"op":"add","path":"/estimate_minutes","value":null}]`,
+			set:   map[string]interface{}{"estimate_minutes": nil},
+		},
+		{
+			name:  "test against null passes for an empty field",
+			todo:  func(todo *models.Todo) { todo.DueDate = nil },
+			patch: `[{"op":"test","path":"/due_date","value":null},{"op":"replace","path":"/title","value":"Renamed"}]`,
+			set:   map[string]interface{}{"title": "Renamed"},
+		},
+		{
+			name:    "test against null fails for a set field",
+			patch:   `[{"op":"test","path":"/due_date","value":null}]`,
+			wantErr: ErrPatchTestFailed,
+		},
+		{
+			name:    "test against a value",
+			patch:   `[{"op":"test","path":"/estimate_minutes","value":30},{"op":"remove","path":"/estimate_minutes"}]`,
+			removed: []string{"estimate_minutes"},
+		},
+		{
+			name:    "replace without a value",
+			patch:   `[{"op":"replace","path":"/due_date"}]`,
+			wantErr: ErrInvalidPatch,
+		},
+		{
+			name:    "test without a value",
+			patch:   `[{"op":"test","path":"/due_date"}]`,
+			wantErr: ErrInvalidPatch,
+		},
+		{
+			name:    "null operation",
+			patch:   `[null]`,
+			wantErr: ErrInvalidPatch,
+		},
+		{
+			name:    "move to a field",
+			patch:   `[{"op":"move","from":"/title","path":"/description"}]`,
+			set:     map[string]interface{}{"description": "Write report"},
+			removed: []string{"title"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			todo := patchTestTodo()
+			if tt.todo != nil {
+				tt.todo(todo)
+			}
+			doc := todoPatchDocument(todo)
+			want := todoPatchDocument(todo)
+
+			err := applyJSONPatch(doc, []byte(tt.patch))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("applyJSONPatch(%s) error = %v, want %v", tt.patch, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("applyJSONPatch(%s): %v", tt.patch, err)
+			}
+
+			for key, value := range tt.set {
+				want[key] = value
+			}
+			for _, key := range tt.removed {
+				delete(want, key)
+			}
+			if !reflect.DeepEqual(doc, want) {
+				t.Errorf("applyJSONPatch(%s) = %v, want %v", tt.patch, doc, want)
+			}
+		})
+	}
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	doc := todoPatchDocument(patchTestTodo())
+	if err := applyMergePatch(doc, []byte(`{"due_date":null,"estimate_minutes":null,"title":"Renamed"}`)); err != nil {
+		t.Fatalf("applyMergePatch: %v", err)
+	}
+
+	if _, ok := doc["due_date"]; ok {
+		t.Errorf("due_date = %v after merging null, want it removed", doc["due_date"])
+	}
+	if _, ok := doc["estimate_minutes"]; ok {
+		t.Errorf("estimate_minutes = %v after merging null, want it removed", doc["estimate_minutes"])
+	}
+	if doc["title"] != "Renamed" || doc["priority"] != "high" {
+		t.Errorf("merged document = %v, want title replaced and other fields kept", doc)
+	}
+
+	for _, patch := range []string{`null`, `[]`, `{"owner":1}`} {
+		if err := applyMergePatch(todoPatchDocument(patchTestTodo()), []byte(patch)); !errors.Is(err, ErrInvalidPatch) {
+			t.Errorf("applyMergePatch(%s) error = %v, want ErrInvalidPatch", patch, err)
+		}
+	}
+}
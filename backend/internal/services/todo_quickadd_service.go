@@ -0,0 +1,53 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"task-management/internal/models"
+)
+
+// QuickAddResult holds the parse breakdown of a quick-add line and, unless
+// it was a dry run, the todo created from it.
+type QuickAddResult struct {
+	Parsed QuickAddParse `json:"parsed"`
+	Todo   *models.Todo  `json:"todo,omitempty"`
+}
+
+// QuickAdd parses a single line of text into a todo in the workspace.
+// Relative dates such as "tomorrow 9am" or "besok jam 9" are resolved in
+// the given IANA time zone, which defaults to UTC.
+func (s *todoService) QuickAdd(workspaceID, userID uint, text, timezone string, dryRun bool) (*QuickAddResult, error) {
+	loc := time.UTC
+	if timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(timezone); err != nil {
+			return nil, fmt.Errorf("%w: unknown timezone %q", ErrInvalidValue, timezone)
+		}
+	}
+
+	result := &QuickAddResult{Parsed: parseQuickAdd(strings.TrimSpace(text), time.Now().In(loc))}
+	if result.Parsed.Title == "" {
+		return nil, fmt.Errorf("%w: the text contains no title", ErrInvalidValue)
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	priority := result.Parsed.Priority
+	if priority == "" {
+		priority = models.PriorityMedium
+	}
+	category := result.Parsed.Category
+	if category == "" {
+		category = models.CategoryPersonal
+	}
+
+	todo, err := s.CreateTodo(workspaceID, userID, result.Parsed.Title, "", priority, category, result.Parsed.DueDate, nil)
+	if err != nil {
+		return nil, err
+	}
+	result.Todo = todo
+	return result, nil
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"errors"
+
+	"task-management/internal/models"
+	"task-management/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+func (s *todoService) GetHistory(id, userID uint) ([]models.TodoRevision, error) {
+	if _, err := s.GetTodoByID(id, userID); err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.todoRepo.GetRevisions(id)
+	if err != nil {
+		return nil, errors.New("database error")
+	}
+	return revisions, nil
+}
+
+// RevertTodo restores the state captured by a previous revision. The revert
+// itself is recorded as a new revision, so history is never rewritten.
+func (s *todoService) RevertTodo(id, userID uint, revision int) (*models.Todo, error) {
+	todo, err := s.GetEditableTodo(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := s.todoRepo.GetRevision(id, revision)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("revision not found")
+		}
+		return nil, errors.New("database error")
+	}
+
+	previousStatus := todo.Status
+	before := todo.Snapshot()
+	todo.ApplySnapshot(target.Snapshot)
+	// The status goes through the workflow like any other status change.
+	todo.Status = previousStatus
+	if err := s.setStatus(todo, target.Snapshot.Status); err != nil {
+		return nil, err
+	}
+
+	err = s.todoRepo.Transaction(func(repo repository.TodoRepository) error {
+		if err := s.checkBlockers(repo, todo, previousStatus); err != nil {
+			return err
+		}
+		if todo.Status != previousStatus {
+			if err := appendRank(repo, todo); err != nil {
+				return err
+			}
+		}
+		if err := repo.Update(todo); err != nil {
+			return err
+		}
+		return recordRevision(repo, todo, &before, userID, models.RevisionReverted, &target.Revision)
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrStaleVersion) {
+			return nil, s.todoConflict(id, userID)
+		}
+		if errors.Is(err, ErrTodoBlocked) {
+			return nil, err
+		}
+		return nil, errors.New("failed to revert todo")
+	}
+
+	s.publish(todo.WorkspaceID, userID, models.EventTodoUpdated, todo)
+	if todo.Status == models.StatusDone && previousStatus != models.StatusDone {
+		s.publish(todo.WorkspaceID, userID, models.EventTodoCompleted, todo)
+	}
+
+	return todo, nil
+}
+
+// recordRevision appends a revision describing the todo's current state.
+// before is the state prior to the change and may be nil for actions that do
+// not edit fields. Updates that change nothing are not recorded.
+func recordRevision(repo repository.TodoRepository, todo *models.Todo, before *models.TodoSnapshot, actorID uint, action models.RevisionAction, revertedFrom *int) error {
+	after := todo.Snapshot()
+
+	changes := map[string]models.FieldChange{}
+	switch action {
+	case models.RevisionCreated, models.RevisionUpdated, models.RevisionReverted:
+		changes = models.DiffSnapshots(before, after)
+	}
+	if action == models.RevisionUpdated && len(changes) == 0 {
+		return nil
+	}
+
+	number, err := repo.NextRevisionNumber(todo.ID)
+	if err != nil {
+		return err
+	}
+
+	return repo.CreateRevision(&models.TodoRevision{
+		TodoID:       todo.ID,
+		Revision:     number,
+		UserID:       actorID,
+		Action:       action,
+		Changes:      changes,
+		Snapshot:     after,
+		RevertedFrom: revertedFrom,
+	})
+}
@@ -1,8 +1,12 @@
 package services
 
 import (
+    "context"
+    "io"
+    "log"
     "time"
     "errors"
+    "task-management/internal/config"
     "task-management/internal/models"
     "task-management/internal/repository"
     
@@ -10,50 +14,119 @@ import (
 )
 
 type TodoService interface {
-    CreateTodo(userID uint, title, description string, priority models.Priority, category models.Category, dueDate *time.Time) (*models.Todo, error)
-    GetTodos(userID uint, status, category string) ([]models.Todo, error)
+    CreateTodo(workspaceID, userID uint, title, description string, priority models.Priority, category models.Category, dueDate *time.Time, estimate *int) (*models.Todo, error)
+    GetTodos(workspaceID, userID uint, status, category string, assigneeID uint) ([]models.Todo, error)
+    ExportTodos(workspaceID, userID uint, status, category string, format ExportFormat, w io.Writer) error
+    ImportTodos(workspaceID, userID uint, format ImportFormat, r io.Reader, dryRun bool) (*ImportResult, error)
+    CreateFromTemplate(todo *models.Todo, userID uint) (*models.Todo, error)
+    QuickAdd(workspaceID, userID uint, text, timezone string, dryRun bool) (*QuickAddResult, error)
     GetTodoByID(id, userID uint) (*models.Todo, error)
+    GetEditableTodo(id, userID uint) (*models.Todo, error)
     GetByIDPublic(id uint) (*models.Todo, error)
-    UpdateTodo(id, userID uint, updates map[string]interface{}) (*models.Todo, error)
-    DeleteTodo(id, userID uint) error
+    UpdateTodo(id, userID uint, updates map[string]interface{}, ifMatch VersionMatch) (*models.Todo, error)
+    PatchTodo(id, userID uint, format PatchFormat, patch []byte, ifMatch VersionMatch) (*models.Todo, error)
+    DeleteTodo(id, userID uint, ifMatch VersionMatch) error
+    UpdateSubtask(todoID, subtaskID, userID uint, updates map[string]interface{}, ifMatch VersionMatch) (*models.Subtask, error)
+    GetSubtaskRules(userID uint) (*models.SubtaskRules, error)
+    UpdateSubtaskRules(userID uint, autoComplete, autoReopen bool) (*models.SubtaskRules, error)
+    BulkUpdate(workspaceID, userID uint, op BulkOperation) ([]BulkResult, error)
+    GetTrash(workspaceID, userID uint) ([]models.Todo, error)
+    RestoreTodo(id, userID uint) (*models.Todo, error)
+    PurgeTodo(id, userID uint) error
+    RunTrashRetention(ctx context.Context)
+    GetHistory(id, userID uint) ([]models.TodoRevision, error)
+    RevertTodo(id, userID uint, revision int) (*models.Todo, error)
+    GetBlockers(id, userID uint) ([]models.Todo, error)
+    AddBlocker(id, blockerID, userID uint) (*models.TodoDependency, error)
+    RemoveBlocker(id, blockerID, userID uint) error
+    GetBoard(workspaceID, userID uint, category string) ([]BoardColumn, error)
+    MoveTodo(id, userID uint, op MoveOperation) (*models.Todo, error)
 }
 
 type todoService struct {
-    todoRepo repository.TodoRepository
+    todoRepo      repository.TodoRepository
+    workspaceRepo repository.WorkspaceRepository
+    publisher     EventPublisher
+    config        *config.Config
+    workflow      models.StatusWorkflow
 }
 
-func NewTodoService(todoRepo repository.TodoRepository) TodoService {
+func NewTodoService(todoRepo repository.TodoRepository, workspaceRepo repository.WorkspaceRepository, publisher EventPublisher, cfg *config.Config) TodoService {
+    workflow, err := models.ParseStatusWorkflow(cfg.Todo.StatusTransitions)
+    if err != nil {
+        log.Printf("todo: %v, using the default status workflow", err)
+        workflow, _ = models.ParseStatusWorkflow(models.DefaultStatusTransitions)
+    }
+    
     return &todoService{
-        todoRepo: todoRepo,
+        todoRepo:      todoRepo,
+        workspaceRepo: workspaceRepo,
+        publisher:     publisher,
+        config:        cfg,
+        workflow:      workflow,
     }
 }
 
 func (s *todoService) CreateTodo(
-    userID uint,
+    workspaceID, userID uint,
     title, description string,
     priority models.Priority,
     category models.Category,
     dueDate *time.Time,
+    estimate *int,
 ) (*models.Todo, error) {
+    if err := validatePriority(priority); err != nil {
+        return nil, err
+    }
+    if err := validateCategory(category); err != nil {
+        return nil, err
+    }
+    if err := validateEstimate(estimate); err != nil {
+        return nil, err
+    }
+    if err := s.authorize(workspaceID, userID, models.RoleMember); err != nil {
+        return nil, err
+    }
+    
     todo := &models.Todo{
         UserID:      userID,
+        WorkspaceID: workspaceID,
         Title:       title,
         Description: description,
         Priority:    priority,
         Category:    category,
         Status:      models.StatusTodo,
         DueDate:     dueDate,
+        Estimate:    estimate,
     }
 
-    if err := s.todoRepo.Create(todo); err != nil {
+    err := s.todoRepo.Transaction(func(repo repository.TodoRepository) error {
+        if err := appendRank(repo, todo); err != nil {
+            return err
+        }
+        if err := repo.Create(todo); err != nil {
+            return err
+        }
+        return recordRevision(repo, todo, nil, userID, models.RevisionCreated, nil)
+    })
+    if err != nil {
         return nil, errors.New("failed to create todo")
     }
 
+    s.publish(todo.WorkspaceID, userID, models.EventTodoCreated, todo)
+
     return todo, nil
 }
 
-func (s *todoService) GetTodos(userID uint, status, category string) ([]models.Todo, error) {
-    return s.todoRepo.GetByUserID(userID, status, category)
+func (s *todoService) GetTodos(workspaceID, userID uint, status, category string, assigneeID uint) ([]models.Todo, error) {
+    todos, err := s.todoRepo.Find(repository.WorkspaceScope(workspaceID, userID), status, category, assigneeID)
+    if err != nil {
+        return nil, err
+    }
+    if err := s.markBlocked(todos); err != nil {
+        return nil, err
+    }
+    return todos, nil
 }
 
 func (s *todoService) GetTodoByID(id, userID uint) (*models.Todo, error) {
@@ -71,11 +144,16 @@ func (s *todoService) GetByIDPublic(id uint) (*models.Todo, error) {
 	return s.todoRepo.GetByIDPublic(id)
 }
 
-func (s *todoService) UpdateTodo(id, userID uint, updates map[string]interface{}) (*models.Todo, error) {
-    todo, err := s.GetTodoByID(id, userID)
+func (s *todoService) UpdateTodo(id, userID uint, updates map[string]interface{}, ifMatch VersionMatch) (*models.Todo, error) {
+    todo, err := s.GetEditableTodo(id, userID)
     if err != nil {
         return nil, err
     }
+    if !ifMatch.Allows(todo.Version) {
+        return nil, &ConflictError{Resource: "todo", Version: todo.Version, Current: todo}
+    }
+    previousStatus := todo.Status
+    before := todo.Snapshot()
     
     // Apply updates
     for key, value := range updates {
@@ -89,33 +167,279 @@ func (s *todoService) UpdateTodo(id, userID uint, updates map[string]interface{}
                 todo.Description = v
             }
         case "status":
-            if v, ok := value.(string); ok {
-                todo.Status = models.Status(v)
+            if v, ok := enumString(value); ok {
+                if err := s.setStatus(todo, models.Status(v)); err != nil {
+                    return nil, err
+                }
             }
         case "priority":
-            if v, ok := value.(string); ok {
+            if v, ok := enumString(value); ok {
+                if err := validatePriority(models.Priority(v)); err != nil {
+                    return nil, err
+                }
                 todo.Priority = models.Priority(v)
             }
         case "category":
-            if v, ok := value.(string); ok {
+            if v, ok := enumString(value); ok {
+                if err := validateCategory(models.Category(v)); err != nil {
+                    return nil, err
+                }
                 todo.Category = models.Category(v)
             }
+        case "due_date":
+            if v, ok := value.(*time.Time); ok {
+                todo.DueDate = v
+            }
+        case "estimate_minutes":
+            if v, ok := value.(*int); ok {
+                if err := validateEstimate(v); err != nil {
+                    return nil, err
+                }
+                todo.Estimate = v
+            }
         }
     }
     
-    if err := s.todoRepo.Update(todo); err != nil {
-        return nil, errors.New("failed to update todo")
+    if err := s.saveUpdate(todo, before, previousStatus, userID); err != nil {
+        return nil, err
+    }
+    return todo, nil
+}
+
+// saveUpdate stores an edited todo together with its revision and publishes
+// the resulting events. before and previousStatus describe the todo as it
+// was loaded.
+func (s *todoService) saveUpdate(todo *models.Todo, before models.TodoSnapshot, previousStatus models.Status, userID uint) error {
+    err := s.todoRepo.Transaction(func(repo repository.TodoRepository) error {
+        if err := s.checkBlockers(repo, todo, previousStatus); err != nil {
+            return err
+        }
+        if todo.Status != previousStatus {
+            if err := appendRank(repo, todo); err != nil {
+                return err
+            }
+        }
+        if err := repo.Update(todo); err != nil {
+            return err
+        }
+        return recordRevision(repo, todo, &before, userID, models.RevisionUpdated, nil)
+    })
+    if err != nil {
+        if errors.Is(err, repository.ErrStaleVersion) {
+            return s.todoConflict(todo.ID, userID)
+        }
+        if errors.Is(err, ErrTodoBlocked) {
+            return err
+        }
+        return errors.New("failed to update todo")
+    }
+    
+    s.publish(todo.WorkspaceID, userID, models.EventTodoUpdated, todo)
+    if todo.Status == models.StatusDone && previousStatus != models.StatusDone {
+        s.publish(todo.WorkspaceID, userID, models.EventTodoCompleted, todo)
+    }
+    return nil
+}
+
+func (s *todoService) DeleteTodo(id, userID uint, ifMatch VersionMatch) error {
+    // Check if todo exists and the user may change it
+    todo, err := s.GetEditableTodo(id, userID)
+    if err != nil {
+        return err
+    }
+    if !ifMatch.Allows(todo.Version) {
+        return &ConflictError{Resource: "todo", Version: todo.Version, Current: todo}
+    }
+    
+    err = s.todoRepo.Transaction(func(repo repository.TodoRepository) error {
+        if err := repo.Delete(id, userID, todo.Version); err != nil {
+            return err
+        }
+        return recordRevision(repo, todo, nil, userID, models.RevisionDeleted, nil)
+    })
+    if err != nil {
+        switch {
+        case errors.Is(err, repository.ErrStaleVersion):
+            // Changed between the If-Match check and the delete.
+            return s.todoConflict(id, userID)
+        case errors.Is(err, gorm.ErrRecordNotFound):
+            return errors.New("todo not found")
+        }
+        return errors.New("failed to delete todo")
     }
     
+    s.publish(todo.WorkspaceID, userID, models.EventTodoDeleted, todo)
+    return nil
+}
+
+func (s *todoService) GetTrash(workspaceID, userID uint) ([]models.Todo, error) {
+    return s.todoRepo.GetDeleted(repository.WorkspaceScope(workspaceID, userID))
+}
+
+func (s *todoService) RestoreTodo(id, userID uint) (*models.Todo, error) {
+    if _, err := s.getDeletedTodo(id, userID); err != nil {
+        return nil, err
+    }
+    
+    var todo *models.Todo
+    err := s.todoRepo.Transaction(func(repo repository.TodoRepository) error {
+        if err := repo.Restore(id, userID); err != nil {
+            return err
+        }
+        restored, err := repo.GetByID(id, userID)
+        if err != nil {
+            return err
+        }
+        todo = restored
+        return recordRevision(repo, todo, nil, userID, models.RevisionRestored, nil)
+    })
+    if err != nil {
+        return nil, errors.New("failed to restore todo")
+    }
+    
+    s.publish(todo.WorkspaceID, userID, models.EventTodoRestored, todo)
+    return todo, nil
+}
+
+func (s *todoService) PurgeTodo(id, userID uint) error {
+    if _, err := s.getDeletedTodo(id, userID); err != nil {
+        return err
+    }
+    
+    if err := s.todoRepo.Purge(id, userID); err != nil {
+        return errors.New("failed to purge todo")
+    }
+    return nil
+}
+
+// RunTrashRetention permanently removes todos that have been in the trash
+// longer than the configured retention, checking once an hour until ctx is
+// cancelled. A retention of zero days disables purging.
+func (s *todoService) RunTrashRetention(ctx context.Context) {
+    days := s.config.Todo.TrashRetentionDays
+    if days <= 0 {
+        return
+    }
+    
+    ticker := time.NewTicker(time.Hour)
+    defer ticker.Stop()
+    
+    for {
+        cutoff := time.Now().AddDate(0, 0, -days)
+        purged, err := s.todoRepo.PurgeDeletedBefore(cutoff)
+        if err != nil {
+            log.Printf("trash: failed to purge expired todos: %v", err)
+        } else if purged > 0 {
+            log.Printf("trash: purged %d todos deleted before %s", purged, cutoff.Format(time.RFC3339))
+        }
+        
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+        }
+    }
+}
+
+func (s *todoService) getDeletedTodo(id, userID uint) (*models.Todo, error) {
+    todo, err := s.todoRepo.GetDeletedByID(id, userID)
+    if err != nil {
+        if errors.Is(err, gorm.ErrRecordNotFound) {
+            return nil, errors.New("todo not found in trash")
+        }
+        return nil, errors.New("database error")
+    }
+    if err := s.authorize(todo.WorkspaceID, userID, models.RoleMember); err != nil {
+        return nil, err
+    }
     return todo, nil
 }
 
-func (s *todoService) DeleteTodo(id, userID uint) error {
-    // Check if todo exists and belongs to user
-    _, err := s.GetTodoByID(id, userID)
+func (s *todoService) UpdateSubtask(todoID, subtaskID, userID uint, updates map[string]interface{}, ifMatch VersionMatch) (*models.Subtask, error) {
+    // Check if todo exists and the user may change it
+    todo, err := s.GetEditableTodo(todoID, userID)
+    if err != nil {
+        return nil, err
+    }
+    
+    subtask, err := s.todoRepo.GetSubtaskByID(subtaskID, todoID)
     if err != nil {
+        if errors.Is(err, gorm.ErrRecordNotFound) {
+            return nil, errors.New("subtask not found")
+        }
+        return nil, errors.New("database error")
+    }
+    if !ifMatch.Allows(subtask.Version) {
+        return nil, &ConflictError{Resource: "subtask", Version: subtask.Version, Current: subtask}
+    }
+    wasCompleted := subtask.IsCompleted == models.CompletionYes
+    
+    for key, value := range updates {
+        switch key {
+        case "title":
+            if v, ok := value.(string); ok {
+                subtask.Title = v
+            }
+        case "is_completed":
+            if v, ok := value.(models.CompletionStatus); ok {
+                if v != models.CompletionYes && v != models.CompletionNo {
+                    return nil, errors.New("is_completed must be yes or no")
+                }
+                subtask.IsCompleted = v
+            }
+        }
+    }
+    
+    if subtask.IsCompleted == models.CompletionYes && !wasCompleted {
+        now := time.Now()
+        subtask.CompletedAt = &now
+    } else if subtask.IsCompleted == models.CompletionNo {
+        subtask.CompletedAt = nil
+    }
+    
+    // Checking or unchecking may move the parent under the user's rules;
+    // both changes commit together.
+    completionChanged := (subtask.IsCompleted == models.CompletionYes) != wasCompleted
+    var parent *models.Todo
+    err = s.todoRepo.Transaction(func(repo repository.TodoRepository) error {
+        if err := repo.UpdateSubtask(subtask); err != nil {
+            return err
+        }
+        if !completionChanged {
+            return nil
+        }
+        var err error
+        parent, err = s.applySubtaskRules(repo, todoID, userID, !wasCompleted)
         return err
+    })
+    if err != nil {
+        if errors.Is(err, repository.ErrStaleVersion) {
+            current, err := s.todoRepo.GetSubtaskByID(subtaskID, todoID)
+            if err != nil {
+                return nil, errors.New("database error")
+            }
+            return nil, &ConflictError{Resource: "subtask", Version: current.Version, Current: current}
+        }
+        return nil, errors.New("failed to update subtask")
     }
     
-    return s.todoRepo.Delete(id, userID)
+    if subtask.IsCompleted == models.CompletionYes && !wasCompleted {
+        s.publish(todo.WorkspaceID, userID, models.EventSubtaskCompleted, subtask)
+    }
+    if parent != nil {
+        s.publish(parent.WorkspaceID, userID, models.EventTodoUpdated, parent)
+        if parent.Status == models.StatusDone {
+            s.publish(parent.WorkspaceID, userID, models.EventTodoCompleted, parent)
+        }
+    }
+    
+    return subtask, nil
+}
+
+// publish announces the change to everyone with access to the workspace
+// the todo belongs to; userID is the user who made it.
+func (s *todoService) publish(workspaceID, userID uint, event models.EventType, data interface{}) {
+    if s.publisher != nil {
+        s.publisher.Publish(workspaceID, userID, event, data)
+    }
 }
\ No newline at end of file
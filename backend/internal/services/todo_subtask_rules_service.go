@@ -0,0 +1,97 @@
+package services
+
+import (
+	"errors"
+	"log"
+
+	"task-management/internal/models"
+	"task-management/internal/repository"
+)
+
+func (s *todoService) GetSubtaskRules(userID uint) (*models.SubtaskRules, error) {
+	rules, err := s.todoRepo.GetSubtaskRules(userID)
+	if err != nil {
+		return nil, errors.New("database error")
+	}
+	return rules, nil
+}
+
+func (s *todoService) UpdateSubtaskRules(userID uint, autoComplete, autoReopen bool) (*models.SubtaskRules, error) {
+	rules := &models.SubtaskRules{UserID: userID, AutoComplete: autoComplete, AutoReopen: autoReopen}
+	if err := s.todoRepo.SaveSubtaskRules(rules); err != nil {
+		return nil, errors.New("failed to save subtask rules")
+	}
+	return rules, nil
+}
+
+// applySubtaskRules runs the acting user's subtask rules after a subtask of
+// todoID was checked or unchecked. It returns the parent when its status
+// changed, or nil otherwise.
+// Completion goes through in progress when the workflow does not allow
+// finishing the parent directly. A parent whose blockers or workflow forbid
+// the change is left alone, and the skipped change is logged.
+func (s *todoService) applySubtaskRules(repo repository.TodoRepository, todoID, userID uint, completed bool) (*models.Todo, error) {
+	rules, err := repo.GetSubtaskRules(userID)
+	if err != nil {
+		return nil, err
+	}
+	if (completed && !rules.AutoComplete) || (!completed && !rules.AutoReopen) {
+		return nil, nil
+	}
+
+	todo, err := repo.GetByID(todoID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var steps []models.Status
+	if completed {
+		total, done, err := repo.CountSubtasks(todoID)
+		if err != nil {
+			return nil, err
+		}
+		if todo.Status == models.StatusDone || total == 0 || done < total {
+			return nil, nil
+		}
+		steps = []models.Status{models.StatusDone}
+		if !s.workflow.Allows(todo.Status, models.StatusDone) && todo.Status != models.StatusInProgress &&
+			s.workflow.Allows(todo.Status, models.StatusInProgress) && s.workflow.Allows(models.StatusInProgress, models.StatusDone) {
+			steps = []models.Status{models.StatusInProgress, models.StatusDone}
+		}
+	} else {
+		if todo.Status != models.StatusDone {
+			return nil, nil
+		}
+		steps = []models.Status{models.StatusInProgress}
+		if !s.workflow.Allows(todo.Status, models.StatusInProgress) {
+			steps = []models.Status{models.StatusTodo}
+		}
+	}
+
+	previousStatus := todo.Status
+	before := todo.Snapshot()
+	for _, step := range steps {
+		if err := s.setStatus(todo, step); err != nil {
+			log.Printf("todo: subtask rules left todo %d in %s: %v", todoID, previousStatus, err)
+			return nil, nil
+		}
+	}
+	if err := s.checkBlockers(repo, todo, previousStatus); err != nil {
+		if errors.Is(err, ErrTodoBlocked) {
+			log.Printf("todo: subtask rules left todo %d in %s: %v", todoID, previousStatus, err)
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err := appendRank(repo, todo); err != nil {
+		return nil, err
+	}
+	if err := repo.Update(todo); err != nil {
+		return nil, err
+	}
+	if err := recordRevision(repo, todo, &before, userID, models.RevisionUpdated, nil); err != nil {
+		return nil, err
+	}
+
+	return todo, nil
+}
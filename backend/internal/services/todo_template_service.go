@@ -0,0 +1,38 @@
+package services
+
+import (
+	"errors"
+
+	"task-management/internal/models"
+	"task-management/internal/repository"
+)
+
+// CreateFromTemplate creates a todo rendered from a template together with
+// its subtasks in a single transaction. The todo goes to the end of the
+// todo column of its workspace.
+func (s *todoService) CreateFromTemplate(todo *models.Todo, userID uint) (*models.Todo, error) {
+	if err := s.authorize(todo.WorkspaceID, userID, models.RoleMember); err != nil {
+		return nil, err
+	}
+
+	todo.UserID = userID
+	todo.Status = models.StatusTodo
+
+	err := s.todoRepo.Transaction(func(repo repository.TodoRepository) error {
+		if err := appendRank(repo, todo); err != nil {
+			return err
+		}
+		if err := repo.Create(todo); err != nil {
+			return err
+		}
+		return recordRevision(repo, todo, nil, userID, models.RevisionCreated, nil)
+	})
+	if err != nil {
+		return nil, errors.New("failed to create todo")
+	}
+	todo.SubtaskTotal = len(todo.Subtasks)
+
+	s.publish(todo.WorkspaceID, userID, models.EventTodoCreated, todo)
+
+	return todo, nil
+}
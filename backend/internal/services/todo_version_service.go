@@ -0,0 +1,51 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrVersionConflict is the sentinel wrapped by every ConflictError.
+var ErrVersionConflict = errors.New("version conflict")
+
+// VersionMatch holds the versions listed in an If-Match header. A nil
+// VersionMatch accepts any version; an empty one accepts none.
+type VersionMatch []uint
+
+func (m VersionMatch) Allows(version uint) bool {
+	if m == nil {
+		return true
+	}
+	for _, v := range m {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+// ConflictError is returned when a todo or subtask was changed since the
+// client read it. Current is the stored representation, so the client can
+// merge its changes and retry.
+type ConflictError struct {
+	Resource string
+	Version  uint
+	Current  interface{}
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s has been modified since it was read", e.Resource)
+}
+
+func (e *ConflictError) Unwrap() error {
+	return ErrVersionConflict
+}
+
+// todoConflict reloads the todo and wraps it in a ConflictError.
+func (s *todoService) todoConflict(id, userID uint) error {
+	current, err := s.GetTodoByID(id, userID)
+	if err != nil {
+		return err
+	}
+	return &ConflictError{Resource: "todo", Version: current.Version, Current: current}
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"task-management/internal/models"
+)
+
+const maxEstimateMinutes = 365 * 24 * 60
+
+var (
+	// ErrInvalidValue is returned for priorities, categories and statuses
+	// outside the supported enums.
+	ErrInvalidValue = errors.New("invalid value")
+	// ErrInvalidTransition is returned when the status workflow does not
+	// allow the requested status change.
+	ErrInvalidTransition = errors.New("invalid status transition")
+)
+
+// setStatus moves the todo to status if the workflow allows it and stamps
+// the StartedAt and CompletedAt timestamps accordingly.
+func (s *todoService) setStatus(todo *models.Todo, status models.Status) error {
+	if !status.IsValid() {
+		return fmt.Errorf("%w: status must be one of todo, inprogress, done", ErrInvalidValue)
+	}
+	if !s.workflow.Allows(todo.Status, status) {
+		return fmt.Errorf("%w from %s to %s", ErrInvalidTransition, todo.Status, status)
+	}
+
+	previous := todo.Status
+	todo.Status = status
+	todo.StampStatus(previous, time.Now())
+	return nil
+}
+
+func validatePriority(priority models.Priority) error {
+	if !priority.IsValid() {
+		return fmt.Errorf("%w: priority must be one of low, medium, high", ErrInvalidValue)
+	}
+	return nil
+}
+
+func validateCategory(category models.Category) error {
+	if !category.IsValid() {
+		return fmt.Errorf("%w: category must be one of personal, work, shopping, health, other", ErrInvalidValue)
+	}
+	return nil
+}
+
+// validateEstimate accepts no estimate or a whole number of minutes up to a
+// year of work.
+func validateEstimate(estimate *int) error {
+	if estimate != nil && (*estimate < 0 || *estimate > maxEstimateMinutes) {
+		return fmt.Errorf("%w: estimate_minutes must be between 0 and %d", ErrInvalidValue, maxEstimateMinutes)
+	}
+	return nil
+}
+
+// enumString accepts both plain strings and the typed enums handlers pass
+// in update maps.
+func enumString(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case models.Status:
+		return string(v), true
+	case models.Priority:
+		return string(v), true
+	case models.Category:
+		return string(v), true
+	}
+	return "", false
+}
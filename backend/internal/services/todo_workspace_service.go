@@ -0,0 +1,31 @@
+package services
+
+import (
+	"task-management/internal/models"
+)
+
+// GetEditableTodo loads a todo the user may change. Guests of the todo's
+// workspace can only read it.
+func (s *todoService) GetEditableTodo(id, userID uint) (*models.Todo, error) {
+	todo, err := s.GetTodoByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorize(todo.WorkspaceID, userID, models.RoleMember); err != nil {
+		return nil, err
+	}
+	return todo, nil
+}
+
+// authorize returns ErrWorkspaceForbidden unless the user's role in the
+// workspace is at least min.
+func (s *todoService) authorize(workspaceID, userID uint, min models.WorkspaceRole) error {
+	role, err := workspaceRole(s.workspaceRepo, workspaceID, userID)
+	if err != nil {
+		return err
+	}
+	if !role.AtLeast(min) {
+		return ErrWorkspaceForbidden
+	}
+	return nil
+}
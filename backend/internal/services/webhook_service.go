@@ -0,0 +1,439 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+
+	"task-management/internal/config"
+	"task-management/internal/models"
+	"task-management/internal/repository"
+	"task-management/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+const (
+	webhookDeliveryListLimit = 100
+	webhookResponseBodyLimit = 1024
+	webhookQueueSize         = 1024
+)
+
+var errWebhookAddress = errors.New("webhook url must point to a public address")
+
+type WebhookService interface {
+	EventPublisher
+	CreateWebhook(userID uint, rawURL string, events []models.EventType) (*models.Webhook, error)
+	GetWebhooks(userID uint) ([]models.Webhook, error)
+	GetWebhookByID(id, userID uint) (*models.Webhook, error)
+	UpdateWebhook(id, userID uint, rawURL string, events []models.EventType, isActive *bool) (*models.Webhook, error)
+	DeleteWebhook(id, userID uint) error
+	GetDeliveries(webhookID, userID uint) ([]models.WebhookDelivery, error)
+	Redeliver(webhookID, deliveryID, userID uint) error
+}
+
+// WebhookPayload is the JSON body posted to webhook endpoints.
+type WebhookPayload struct {
+	ID        string           `json:"id"`
+	Event     models.EventType `json:"event"`
+	CreatedAt time.Time        `json:"created_at"`
+	Data      interface{}      `json:"data"`
+}
+
+// webhookJob is an event waiting for the worker to look up the webhooks
+// subscribed to it.
+type webhookJob struct {
+	workspaceID uint
+	event       models.EventType
+	body        []byte
+}
+
+type webhookService struct {
+	webhookRepo repository.WebhookRepository
+	config      *config.Config
+	client      *http.Client
+	jobs        chan webhookJob
+}
+
+func NewWebhookService(webhookRepo repository.WebhookRepository, cfg *config.Config) WebhookService {
+	s := &webhookService{
+		webhookRepo: webhookRepo,
+		config:      cfg,
+		client:      newWebhookClient(cfg.Webhook.Timeout),
+		jobs:        make(chan webhookJob, webhookQueueSize),
+	}
+	go s.run()
+	return s
+}
+
+// newWebhookClient returns a client that only connects to public addresses.
+// The check runs on the resolved address of every connection, so a host
+// that re-resolves to an internal address after validation is still
+// refused. Redirects are not followed, as they could lead anywhere, and no
+// proxy is used so the check sees the real destination.
+func newWebhookClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+				return errWebhookAddress
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConns:        100,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// webhookDeniedNetworks are the IANA special-purpose ranges that are not
+// globally reachable, or that reach internal hosts through translation.
+var webhookDeniedNetworks = parseCIDRs(
+	"0.0.0.0/8",       // this network
+	"10.0.0.0/8",      // private
+	"100.64.0.0/10",   // shared address space (CGNAT)
+	"127.0.0.0/8",     // loopback
+	"169.254.0.0/16",  // link-local
+	"172.16.0.0/12",   // private
+	"192.0.0.0/24",    // IETF protocol assignments
+	"192.0.2.0/24",    // documentation
+	"192.88.99.0/24",  // 6to4 relay anycast
+	"192.168.0.0/16",  // private
+	"198.18.0.0/15",   // benchmarking
+	"198.51.100.0/24", // documentation
+	"203.0.113.0/24",  // documentation
+	"224.0.0.0/4",     // multicast
+	"240.0.0.0/4",     // reserved, including broadcast
+	"::/96",           // unspecified, loopback and IPv4-compatible
+	"64:ff9b:1::/48",  // local-use IPv4/IPv6 translation
+	"100::/64",        // discard-only
+	"2001::/23",       // IETF protocol assignments, including Teredo
+	"2001:db8::/32",   // documentation
+	"2002::/16",       // 6to4
+	"fc00::/7",        // unique local
+	"fe80::/10",       // link-local
+	"ff00::/8",        // multicast
+)
+
+// webhookNAT64 is the well-known NAT64 prefix, whose addresses stand for
+// the IPv4 address in their last four bytes.
+var webhookNAT64 = parseCIDRs("64:ff9b::/96")[0]
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks[i] = network
+	}
+	return networks
+}
+
+// isPublicIP reports whether webhooks may be delivered to ip. IPv4-mapped
+// and NAT64 addresses are judged by the IPv4 address they carry.
+func isPublicIP(ip net.IP) bool {
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	} else if webhookNAT64.Contains(ip) {
+		ip = net.IP(ip.To16()[12:16])
+	}
+
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	for _, network := range webhookDeniedNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *webhookService) CreateWebhook(userID uint, rawURL string, events []models.EventType) (*models.Webhook, error) {
+	if err := validateWebhook(rawURL, events); err != nil {
+		return nil, err
+	}
+
+	secret, err := utils.RandomToken(32)
+	if err != nil {
+		return nil, errors.New("failed to generate webhook secret")
+	}
+
+	webhook := &models.Webhook{
+		UserID:   userID,
+		URL:      rawURL,
+		Secret:   secret,
+		Events:   events,
+		IsActive: true,
+	}
+
+	if err := s.webhookRepo.Create(webhook); err != nil {
+		return nil, errors.New("failed to create webhook")
+	}
+
+	return webhook, nil
+}
+
+func (s *webhookService) GetWebhooks(userID uint) ([]models.Webhook, error) {
+	return s.webhookRepo.GetByUserID(userID)
+}
+
+func (s *webhookService) GetWebhookByID(id, userID uint) (*models.Webhook, error) {
+	webhook, err := s.webhookRepo.GetByID(id, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("webhook not found")
+		}
+		return nil, errors.New("database error")
+	}
+	return webhook, nil
+}
+
+func (s *webhookService) UpdateWebhook(id, userID uint, rawURL string, events []models.EventType, isActive *bool) (*models.Webhook, error) {
+	webhook, err := s.GetWebhookByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if rawURL != "" {
+		webhook.URL = rawURL
+	}
+	if events != nil {
+		webhook.Events = events
+	}
+	if isActive != nil {
+		webhook.IsActive = *isActive
+	}
+
+	if err := validateWebhook(webhook.URL, webhook.Events); err != nil {
+		return nil, err
+	}
+
+	if err := s.webhookRepo.Update(webhook); err != nil {
+		return nil, errors.New("failed to update webhook")
+	}
+
+	return webhook, nil
+}
+
+func (s *webhookService) DeleteWebhook(id, userID uint) error {
+	if _, err := s.GetWebhookByID(id, userID); err != nil {
+		return err
+	}
+	return s.webhookRepo.Delete(id, userID)
+}
+
+func (s *webhookService) GetDeliveries(webhookID, userID uint) ([]models.WebhookDelivery, error) {
+	if _, err := s.GetWebhookByID(webhookID, userID); err != nil {
+		return nil, err
+	}
+	return s.webhookRepo.GetDeliveries(webhookID, webhookDeliveryListLimit)
+}
+
+func (s *webhookService) Redeliver(webhookID, deliveryID, userID uint) error {
+	webhook, err := s.GetWebhookByID(webhookID, userID)
+	if err != nil {
+		return err
+	}
+
+	delivery, err := s.webhookRepo.GetDeliveryByID(deliveryID, webhookID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("delivery not found")
+		}
+		return errors.New("database error")
+	}
+
+	go s.deliver(*webhook, delivery.Event, []byte(delivery.Payload))
+	return nil
+}
+
+// Publish queues the event for every active webhook subscribed to it that
+// belongs to a member of the workspace. The webhooks are looked up and called by a background
+// worker, so the request publishing the event never waits on either.
+func (s *webhookService) Publish(workspaceID, userID uint, event models.EventType, data interface{}) {
+	eventID, err := utils.RandomToken(16)
+	if err != nil {
+		log.Printf("webhook: failed to generate event id: %v", err)
+		return
+	}
+	body, err := json.Marshal(WebhookPayload{
+		ID:        eventID,
+		Event:     event,
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+	})
+	if err != nil {
+		log.Printf("webhook: failed to encode %s payload: %v", event, err)
+		return
+	}
+
+	select {
+	case s.jobs <- webhookJob{workspaceID: workspaceID, event: event, body: body}:
+	default:
+		log.Printf("webhook: queue full, dropping %s event for workspace %d", event, workspaceID)
+	}
+}
+
+// run hands each queued event to the subscribed webhooks.
+func (s *webhookService) run() {
+	for job := range s.jobs {
+		webhooks, err := s.webhookRepo.GetActiveByWorkspace(job.workspaceID)
+		if err != nil {
+			log.Printf("webhook: failed to load webhooks for workspace %d: %v", job.workspaceID, err)
+			continue
+		}
+		for _, webhook := range webhooks {
+			if webhook.Subscribes(job.event) {
+				go s.deliver(webhook, job.event, job.body)
+			}
+		}
+	}
+}
+
+// deliver posts the payload and retries with exponential backoff until the
+// endpoint answers with a 2xx status or the attempts are exhausted. Every
+// attempt is recorded.
+func (s *webhookService) deliver(webhook models.Webhook, event models.EventType, body []byte) {
+	deliveryID, err := utils.RandomToken(16)
+	if err != nil {
+		log.Printf("webhook: failed to generate delivery id: %v", err)
+		return
+	}
+	s.attempt(webhook, event, deliveryID, body, 1, s.config.Webhook.InitialBackoff)
+}
+
+// attempt makes one delivery attempt and, when it fails, schedules the next
+// one after backoff instead of holding a goroutine while waiting.
+func (s *webhookService) attempt(webhook models.Webhook, event models.EventType, deliveryID string, body []byte, attempt int, backoff time.Duration) {
+	delivery := s.send(webhook, event, deliveryID, body, attempt)
+	if err := s.webhookRepo.CreateDelivery(delivery); err != nil {
+		log.Printf("webhook: failed to record delivery %s: %v", deliveryID, err)
+	}
+	if delivery.Success || attempt >= s.config.Webhook.MaxAttempts {
+		return
+	}
+
+	time.AfterFunc(backoff, func() {
+		s.attempt(webhook, event, deliveryID, body, attempt+1, backoff*2)
+	})
+}
+
+func (s *webhookService) send(webhook models.Webhook, event models.EventType, deliveryID string, body []byte, attempt int) *models.WebhookDelivery {
+	delivery := &models.WebhookDelivery{
+		WebhookID:  webhook.ID,
+		DeliveryID: deliveryID,
+		Event:      event,
+		Payload:    string(body),
+		Attempt:    attempt,
+	}
+
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "task-management-webhooks/1.0")
+	req.Header.Set("X-Webhook-Event", string(event))
+	req.Header.Set("X-Webhook-Delivery", deliveryID)
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", "sha256="+SignWebhookPayload(webhook.Secret, timestamp, body))
+
+	start := time.Now()
+	resp, err := s.client.Do(req)
+	delivery.DurationMs = time.Since(start).Milliseconds()
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, webhookResponseBodyLimit))
+	delivery.StatusCode = resp.StatusCode
+	delivery.ResponseBody = string(respBody)
+	delivery.Success = resp.StatusCode >= 200 && resp.StatusCode < 300
+
+	return delivery
+}
+
+// SignWebhookPayload computes the hex encoded HMAC-SHA256 of
+// "<timestamp>.<body>" using the webhook secret. Receivers recompute it to
+// verify the X-Webhook-Signature header.
+func SignWebhookPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func validateWebhook(rawURL string, events []models.EventType) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return errors.New("webhook url must be an absolute http(s) url")
+	}
+	if err := validateWebhookHost(u.Hostname()); err != nil {
+		return err
+	}
+
+	if len(events) == 0 {
+		return errors.New("at least one event is required")
+	}
+	for _, event := range events {
+		if !event.IsValid() {
+			return errors.New("unknown event: " + string(event))
+		}
+	}
+
+	return nil
+}
+
+// validateWebhookHost rejects hosts resolving to an address webhooks are not
+// delivered to. The delivery client checks again on every connection.
+func validateWebhookHost(host string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil || len(addrs) == 0 {
+		return errors.New("webhook url host could not be resolved")
+	}
+	for _, addr := range addrs {
+		if !isPublicIP(addr.IP) {
+			return errWebhookAddress
+		}
+	}
+	return nil
+}
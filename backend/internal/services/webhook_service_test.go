@@ -0,0 +1,59 @@
+package services
+
+import (
+	"net"
+	"testing"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	tests := []struct {
+		ip     string
+		public bool
+	}{
+		{"93.184.216.34", true},
+		{"8.8.8.8", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"64:ff9b::808:808", true}, // NAT64 form of 8.8.8.8
+		{"::ffff:8.8.8.8", true},
+
+		{"127.0.0.1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"0.0.0.0", false},
+		{"100.64.0.1", false},
+		{"100.100.100.200", false},
+		{"192.0.0.170", false},
+		{"198.18.0.1", false},
+		{"203.0.113.7", false},
+		{"240.0.0.1", false},
+		{"255.255.255.255", false},
+		{"224.0.0.251", false},
+		{"::1", false},
+		{"::", false},
+		{"fd00::1", false},
+		{"fe80::1", false},
+		{"ff02::1", false},
+		{"2001:db8::1", false},
+		{"2002:a9fe:a9fe::1", false},
+		{"2001:0:4136:e378:8000:63bf:3fff:fdd2", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:169.254.169.254", false},
+		{"::ffff:100.64.0.1", false},
+		{"::127.0.0.1", false},
+		{"64:ff9b::7f00:1", false},    // NAT64 form of 127.0.0.1
+		{"64:ff9b::a9fe:a9fe", false}, // NAT64 form of 169.254.169.254
+		{"64:ff9b:1::a00:1", false},
+	}
+
+	for _, tt := range tests {
+		ip := net.ParseIP(tt.ip)
+		if ip == nil {
+			t.Fatalf("invalid test address %q", tt.ip)
+		}
+		if got := isPublicIP(ip); got != tt.public {
+			t.Errorf("isPublicIP(%s) = %v, want %v", tt.ip, got, tt.public)
+		}
+	}
+}
@@ -0,0 +1,462 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"task-management/internal/models"
+	"task-management/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+const (
+	workspaceNameMaxLength = 100
+	personalWorkspaceName  = "Personal"
+)
+
+// ErrWorkspaceForbidden is returned when the user's role in a workspace does
+// not allow the action.
+var ErrWorkspaceForbidden = errors.New("insufficient workspace role")
+
+// InviteInput names the invitee by username or by email address. An email
+// address without an account can be invited and is matched once the person
+// signs up.
+type InviteInput struct {
+	Username string
+	Email    string
+	Role     models.WorkspaceRole
+}
+
+type WorkspaceService interface {
+	GetWorkspaces(userID uint) ([]models.Workspace, error)
+	CreateWorkspace(userID uint, name string) (*models.Workspace, error)
+	GetWorkspace(id, userID uint) (*models.Workspace, error)
+	UpdateWorkspace(id, userID uint, name string) (*models.Workspace, error)
+	DeleteWorkspace(id, userID uint) error
+	SwitchWorkspace(id, userID uint) (*models.Workspace, error)
+	ResolveWorkspace(userID, requested uint) (*models.Workspace, error)
+	GetMembers(id, userID uint) ([]models.Member, error)
+	UpdateMemberRole(id, userID, memberID uint, role models.WorkspaceRole) error
+	RemoveMember(id, userID, memberID uint) error
+	Invite(id, userID uint, input InviteInput) (*models.WorkspaceInvitation, error)
+	GetInvitations(id, userID uint) ([]models.WorkspaceInvitation, error)
+	RevokeInvitation(id, invitationID, userID uint) error
+	GetMyInvitations(userID uint) ([]models.WorkspaceInvitation, error)
+	AcceptInvitation(invitationID, userID uint) (*models.Workspace, error)
+	DeclineInvitation(invitationID, userID uint) error
+}
+
+type workspaceService struct {
+	workspaceRepo       repository.WorkspaceRepository
+	userRepo            repository.UserRepository
+	notificationService NotificationService
+}
+
+func NewWorkspaceService(workspaceRepo repository.WorkspaceRepository, userRepo repository.UserRepository, notificationService NotificationService) WorkspaceService {
+	return &workspaceService{
+		workspaceRepo:       workspaceRepo,
+		userRepo:            userRepo,
+		notificationService: notificationService,
+	}
+}
+
+func (s *workspaceService) GetWorkspaces(userID uint) ([]models.Workspace, error) {
+	if _, err := s.personalWorkspace(userID); err != nil {
+		return nil, err
+	}
+
+	workspaces, err := s.workspaceRepo.GetByMember(userID)
+	if err != nil {
+		return nil, errors.New("database error")
+	}
+	return workspaces, nil
+}
+
+func (s *workspaceService) CreateWorkspace(userID uint, name string) (*models.Workspace, error) {
+	name, err := validateWorkspaceName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	workspace := &models.Workspace{Name: name, OwnerID: userID}
+	if err := s.workspaceRepo.Create(workspace); err != nil {
+		return nil, errors.New("failed to create workspace")
+	}
+	return workspace, nil
+}
+
+func (s *workspaceService) GetWorkspace(id, userID uint) (*models.Workspace, error) {
+	return s.authorize(id, userID, models.RoleGuest)
+}
+
+func (s *workspaceService) UpdateWorkspace(id, userID uint, name string) (*models.Workspace, error) {
+	workspace, err := s.authorize(id, userID, models.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	if workspace.Name, err = validateWorkspaceName(name); err != nil {
+		return nil, err
+	}
+	if err := s.workspaceRepo.Update(workspace); err != nil {
+		return nil, errors.New("failed to update workspace")
+	}
+	return workspace, nil
+}
+
+func (s *workspaceService) DeleteWorkspace(id, userID uint) error {
+	workspace, err := s.authorize(id, userID, models.RoleOwner)
+	if err != nil {
+		return err
+	}
+	if workspace.Personal {
+		return errors.New("the personal workspace cannot be deleted")
+	}
+
+	if err := s.workspaceRepo.Delete(id); err != nil {
+		return errors.New("failed to delete workspace")
+	}
+	return nil
+}
+
+// SwitchWorkspace makes the workspace the default for requests that do not
+// name one with the X-Workspace-ID header.
+func (s *workspaceService) SwitchWorkspace(id, userID uint) (*models.Workspace, error) {
+	workspace, err := s.authorize(id, userID, models.RoleGuest)
+	if err != nil {
+		return nil, err
+	}
+
+	current := &workspace.ID
+	if workspace.Personal {
+		current = nil
+	}
+	if err := s.userRepo.SetCurrentWorkspace(userID, current); err != nil {
+		return nil, errors.New("failed to switch workspace")
+	}
+	return workspace, nil
+}
+
+// ResolveWorkspace returns the workspace a request acts on: the requested
+// one if given, otherwise the one the user switched to, otherwise their
+// personal workspace.
+func (s *workspaceService) ResolveWorkspace(userID, requested uint) (*models.Workspace, error) {
+	if requested != 0 {
+		return s.authorize(requested, userID, models.RoleGuest)
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	if user.CurrentWorkspaceID != nil {
+		workspace, err := s.authorize(*user.CurrentWorkspaceID, userID, models.RoleGuest)
+		if err == nil {
+			return workspace, nil
+		}
+		// Removed from the workspace since switching; fall back quietly.
+	}
+	return s.personalWorkspace(userID)
+}
+
+func (s *workspaceService) GetMembers(id, userID uint) ([]models.Member, error) {
+	if _, err := s.authorize(id, userID, models.RoleGuest); err != nil {
+		return nil, err
+	}
+	return s.workspaceRepo.GetMembers(id)
+}
+
+// UpdateMemberRole changes a member's role. Admins manage members and
+// guests; only the owner can appoint admins or hand over ownership, which
+// demotes the previous owner to admin.
+func (s *workspaceService) UpdateMemberRole(id, userID, memberID uint, role models.WorkspaceRole) error {
+	workspace, err := s.authorize(id, userID, models.RoleAdmin)
+	if err != nil {
+		return err
+	}
+	if !role.IsValid() {
+		return fmt.Errorf("%w: role must be one of owner, admin, member, guest", ErrInvalidValue)
+	}
+
+	member, err := s.getMember(id, memberID)
+	if err != nil {
+		return err
+	}
+	if member.Role == models.RoleOwner {
+		return errors.New("the owner's role cannot be changed; transfer ownership instead")
+	}
+	if (role.AtLeast(models.RoleAdmin) || member.Role == models.RoleAdmin) && workspace.Role != models.RoleOwner {
+		return ErrWorkspaceForbidden
+	}
+
+	if role == models.RoleOwner {
+		err = s.workspaceRepo.TransferOwnership(id, userID, memberID)
+	} else {
+		err = s.workspaceRepo.UpdateMemberRole(id, memberID, role)
+	}
+	if err != nil {
+		return errors.New("failed to update member")
+	}
+	return nil
+}
+
+// RemoveMember removes someone from the workspace. Members may always leave;
+// removing others takes an admin, and only the owner can remove admins. The
+// owner cannot leave without transferring ownership first.
+func (s *workspaceService) RemoveMember(id, userID, memberID uint) error {
+	workspace, err := s.authorize(id, userID, models.RoleGuest)
+	if err != nil {
+		return err
+	}
+
+	member, err := s.getMember(id, memberID)
+	if err != nil {
+		return err
+	}
+	if member.Role == models.RoleOwner {
+		return errors.New("the owner cannot leave the workspace; transfer ownership first")
+	}
+	if memberID != userID {
+		if !workspace.Role.AtLeast(models.RoleAdmin) {
+			return ErrWorkspaceForbidden
+		}
+		if member.Role == models.RoleAdmin && workspace.Role != models.RoleOwner {
+			return ErrWorkspaceForbidden
+		}
+	}
+
+	if err := s.workspaceRepo.RemoveMember(id, memberID); err != nil {
+		return errors.New("failed to remove member")
+	}
+	return nil
+}
+
+func (s *workspaceService) Invite(id, userID uint, input InviteInput) (*models.WorkspaceInvitation, error) {
+	workspace, err := s.authorize(id, userID, models.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if workspace.Personal {
+		return nil, errors.New("the personal workspace cannot be shared")
+	}
+
+	if input.Role == "" {
+		input.Role = models.RoleMember
+	}
+	if !input.Role.IsValid() || input.Role == models.RoleOwner {
+		return nil, fmt.Errorf("%w: role must be one of admin, member, guest", ErrInvalidValue)
+	}
+	if input.Role == models.RoleAdmin && workspace.Role != models.RoleOwner {
+		return nil, ErrWorkspaceForbidden
+	}
+
+	invitation := &models.WorkspaceInvitation{
+		WorkspaceID: id,
+		InviterID:   userID,
+		Role:        input.Role,
+	}
+
+	var invitee *models.User
+	username := strings.TrimSpace(input.Username)
+	email := strings.ToLower(strings.TrimSpace(input.Email))
+	switch {
+	case username != "":
+		if invitee, err = s.userRepo.GetByUsername(username); err != nil {
+			return nil, errors.New("user not found")
+		}
+	case email != "":
+		if !strings.Contains(email, "@") {
+			return nil, fmt.Errorf("%w: invalid email address", ErrInvalidValue)
+		}
+		invitation.Email = email
+		if user, err := s.userRepo.GetByEmail(email); err == nil {
+			invitee = user
+		}
+	default:
+		return nil, fmt.Errorf("%w: username or email is required", ErrInvalidValue)
+	}
+
+	if invitee != nil {
+		if _, err := s.workspaceRepo.GetMember(id, invitee.ID); err == nil {
+			return nil, errors.New("user is already a member")
+		}
+		invitation.InviteeID = &invitee.ID
+		invitation.Email = invitee.Email
+	}
+
+	if err := s.workspaceRepo.CreateInvitation(invitation); err != nil {
+		return nil, errors.New("failed to create invitation")
+	}
+
+	if invitee != nil {
+		notification := &models.Notification{
+			UserID:  invitee.ID,
+			ActorID: userID,
+			Type:    models.NotificationWorkspaceInvite,
+			Message: fmt.Sprintf("You were invited to the workspace %q", workspace.Name),
+		}
+		if err := s.notificationService.Notify(notification); err != nil {
+			log.Printf("workspace: failed to notify user %d of invitation %d: %v", invitee.ID, invitation.ID, err)
+		}
+	}
+	return invitation, nil
+}
+
+func (s *workspaceService) GetInvitations(id, userID uint) ([]models.WorkspaceInvitation, error) {
+	if _, err := s.authorize(id, userID, models.RoleAdmin); err != nil {
+		return nil, err
+	}
+	return s.workspaceRepo.GetInvitations(id)
+}
+
+func (s *workspaceService) RevokeInvitation(id, invitationID, userID uint) error {
+	if _, err := s.authorize(id, userID, models.RoleAdmin); err != nil {
+		return err
+	}
+
+	invitation, err := s.workspaceRepo.GetInvitation(invitationID)
+	if err != nil || invitation.WorkspaceID != id {
+		return errors.New("invitation not found")
+	}
+
+	if err := s.workspaceRepo.DeleteInvitation(invitationID); err != nil {
+		return errors.New("failed to revoke invitation")
+	}
+	return nil
+}
+
+func (s *workspaceService) GetMyInvitations(userID uint) ([]models.WorkspaceInvitation, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	return s.workspaceRepo.GetInvitationsFor(userID, user.Email)
+}
+
+func (s *workspaceService) AcceptInvitation(invitationID, userID uint) (*models.Workspace, error) {
+	invitation, err := s.getMyInvitation(invitationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.workspaceRepo.AcceptInvitation(invitation, userID); err != nil {
+		return nil, errors.New("failed to accept invitation")
+	}
+	return s.authorize(invitation.WorkspaceID, userID, models.RoleGuest)
+}
+
+func (s *workspaceService) DeclineInvitation(invitationID, userID uint) error {
+	if _, err := s.getMyInvitation(invitationID, userID); err != nil {
+		return err
+	}
+
+	if err := s.workspaceRepo.DeleteInvitation(invitationID); err != nil {
+		return errors.New("failed to decline invitation")
+	}
+	return nil
+}
+
+// authorize loads the workspace with the user's role set, provided the user
+// is a member with at least the min role. Non-members get "workspace not
+// found" so workspace IDs are not disclosed.
+func (s *workspaceService) authorize(id, userID uint, min models.WorkspaceRole) (*models.Workspace, error) {
+	role, err := workspaceRole(s.workspaceRepo, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !role.AtLeast(min) {
+		return nil, ErrWorkspaceForbidden
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("workspace not found")
+		}
+		return nil, errors.New("database error")
+	}
+	workspace.Role = role
+	return workspace, nil
+}
+
+// personalWorkspace returns the user's personal workspace, creating it on
+// first use. Users that existed before workspaces are backfilled by the
+// migration.
+func (s *workspaceService) personalWorkspace(userID uint) (*models.Workspace, error) {
+	workspace, err := s.workspaceRepo.GetPersonal(userID)
+	if err == nil {
+		workspace.Role = models.RoleOwner
+		return workspace, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("database error")
+	}
+
+	workspace = &models.Workspace{Name: personalWorkspaceName, Personal: true, OwnerID: userID}
+	if err := s.workspaceRepo.Create(workspace); err != nil {
+		// Another request may have created it concurrently.
+		if existing, getErr := s.workspaceRepo.GetPersonal(userID); getErr == nil {
+			existing.Role = models.RoleOwner
+			return existing, nil
+		}
+		return nil, errors.New("failed to create personal workspace")
+	}
+	return workspace, nil
+}
+
+func (s *workspaceService) getMember(id, memberID uint) (*models.WorkspaceMember, error) {
+	member, err := s.workspaceRepo.GetMember(id, memberID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("member not found")
+		}
+		return nil, errors.New("database error")
+	}
+	return member, nil
+}
+
+func (s *workspaceService) getMyInvitation(invitationID, userID uint) (*models.WorkspaceInvitation, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+
+	invitation, err := s.workspaceRepo.GetInvitation(invitationID)
+	if err != nil {
+		return nil, errors.New("invitation not found")
+	}
+	if invitation.InviteeID != nil && *invitation.InviteeID != userID {
+		return nil, errors.New("invitation not found")
+	}
+	if invitation.InviteeID == nil && !strings.EqualFold(invitation.Email, user.Email) {
+		return nil, errors.New("invitation not found")
+	}
+	return invitation, nil
+}
+
+// workspaceRole returns the user's role in the workspace, or "workspace not
+// found" when they are not a member.
+func workspaceRole(repo repository.WorkspaceRepository, workspaceID, userID uint) (models.WorkspaceRole, error) {
+	member, err := repo.GetMember(workspaceID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", errors.New("workspace not found")
+		}
+		return "", errors.New("database error")
+	}
+	return member.Role, nil
+}
+
+func validateWorkspaceName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("%w: name is required", ErrInvalidValue)
+	}
+	if len([]rune(name)) > workspaceNameMaxLength {
+		return "", fmt.Errorf("%w: name must be at most %d characters", ErrInvalidValue, workspaceNameMaxLength)
+	}
+	return name, nil
+}
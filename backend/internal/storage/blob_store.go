@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"task-management/internal/config"
+)
+
+// ErrNotFound is returned when a blob does not exist.
+var ErrNotFound = errors.New("blob not found")
+
+// BlobStore persists opaque binary objects addressed by key.
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// New builds the blob store selected by STORAGE_DRIVER.
+func New(cfg *config.Config) (BlobStore, error) {
+	switch cfg.Storage.Driver {
+	case "local":
+		return NewLocalStore(cfg.Storage.LocalPath)
+	case "s3":
+		return NewS3Store(cfg.Storage.S3)
+	}
+	return nil, fmt.Errorf("unknown storage driver %q", cfg.Storage.Driver)
+}
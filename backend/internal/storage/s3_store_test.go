@@ -0,0 +1,86 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"task-management/internal/config"
+	"task-management/internal/storage"
+)
+
+// newS3Store connects to the S3 server named by S3_TEST_ENDPOINT, such as a
+// local MinIO started with
+//
+//	docker run -p 9000:9000 minio/minio server /data
+//
+// and skips the test when it is unset.
+func newS3Store(t *testing.T) *storage.S3Store {
+	t.Helper()
+
+	endpoint := os.Getenv("S3_TEST_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("S3_TEST_ENDPOINT is not set")
+	}
+	env := func(key, fallback string) string {
+		if value := os.Getenv(key); value != "" {
+			return value
+		}
+		return fallback
+	}
+
+	store, err := storage.NewS3Store(config.S3Config{
+		Endpoint:  endpoint,
+		AccessKey: env("S3_TEST_ACCESS_KEY", "minioadmin"),
+		SecretKey: env("S3_TEST_SECRET_KEY", "minioadmin"),
+		Bucket:    env("S3_TEST_BUCKET", "attachments-test"),
+		Region:    env("S3_TEST_REGION", "us-east-1"),
+		UseSSL:    os.Getenv("S3_TEST_USE_SSL") == "true",
+	})
+	if err != nil {
+		t.Fatalf("NewS3Store: %v", err)
+	}
+	return store
+}
+
+func TestS3Store(t *testing.T) {
+	ctx := context.Background()
+	store := newS3Store(t)
+	key := "test/" + strings.ReplaceAll(t.Name(), "/", "-")
+	content := "hello, attachments"
+
+	if err := store.Put(ctx, key, strings.NewReader(content), int64(len(content)), "text/plain"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	t.Cleanup(func() { store.Delete(ctx, key) })
+
+	blob, err := store.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	got, err := io.ReadAll(blob)
+	blob.Close()
+	if err != nil {
+		t.Fatalf("read blob: %v", err)
+	}
+	if string(got) != content {
+		t.Errorf("Get = %q, want %q", got, content)
+	}
+
+	// A body shorter than the declared size must not be stored.
+	short := key + "-short"
+	if err := store.Put(ctx, short, strings.NewReader("short"), 30, "text/plain"); err == nil {
+		store.Delete(ctx, short)
+		t.Error("Put with a short body succeeded, want an error")
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := store.Get(ctx, key); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("Get after Delete: got %v, want ErrNotFound", err)
+	}
+}